@@ -0,0 +1,165 @@
+package goillogical
+
+import (
+	"runtime"
+	"sync"
+
+	e "github.com/spaceavocado/goillogical/evaluable"
+)
+
+// Result of the expression evaluated in a data context of a stream, see EvaluateStream.
+type Result struct {
+	// Index of the data context within the stream.
+	Index int
+	// Evaluated value, nil if the evaluation failed.
+	Value any
+	// Evaluation error.
+	Error error
+}
+
+// Parse and compile the expression into an evaluation function of the data context, see
+// evaluate.
+func (i illogical) compile(exp any) (func(e.Context) (any, error), error) {
+	eval, err := i.parse(exp)
+	if err != nil {
+		return nil, err
+	}
+
+	fn, err := e.Compile(eval)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := e.FlattenTag(i.opts.ContextTag)
+	return func(ctx e.Context) (any, error) {
+		flattened, err := e.Flatten(ctx, tag)
+		if err != nil {
+			return nil, err
+		}
+
+		return i.result(fn(flattened))
+	}, nil
+}
+
+// Number of the workers evaluating the expression concurrently, see WithWorkers.
+func (i illogical) workers() int {
+	if i.opts.Workers > 0 {
+		return i.opts.Workers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// Evaluate given raw expression in each of the given data contexts, the expression is parsed
+// once, and evaluated concurrently by the workers, see WithWorkers. The values, and the errors,
+// are in the order of the data contexts. If the expression could not be parsed, the parse error
+// is reported for each of the data contexts.
+//
+// Example:
+//
+// values, errs := i.EvaluateBatch([]any{">", "$age", 18}, []e.Context{{"age": 21}, {"age": 16}})
+//
+// values // [true false]
+func (i illogical) EvaluateBatch(exp any, contexts []e.Context) ([]any, []error) {
+	values := make([]any, len(contexts))
+	errs := make([]error, len(contexts))
+
+	evaluate, err := i.compile(exp)
+	if err != nil {
+		for j := range errs {
+			errs[j] = err
+		}
+		return values, errs
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(i.workers(), len(contexts)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range indexes {
+				values[j], errs[j] = evaluate(contexts[j])
+			}
+		}()
+	}
+
+	for j := range contexts {
+		indexes <- j
+	}
+	close(indexes)
+	wg.Wait()
+
+	return values, errs
+}
+
+type job struct {
+	index int
+	ctx   e.Context
+	res   chan Result
+}
+
+// Evaluate given raw expression in each of the data contexts received from the stream, the
+// expression is parsed once, and evaluated concurrently by the workers, see WithWorkers. The
+// results are sent in the order of the data contexts, the results channel is closed once the
+// data contexts channel is closed, and all of the results are sent. The results must be
+// received, otherwise the workers are blocked. If the expression could not be parsed, the
+// parse error is reported for each of the data contexts.
+//
+// Example:
+//
+//	contexts := make(chan e.Context)
+//	go func() {
+//		defer close(contexts)
+//		for _, user := range users {
+//			contexts <- user
+//		}
+//	}()
+//
+//	for res := range i.EvaluateStream([]any{">", "$age", 18}, contexts) {
+//		res.Index, res.Value, res.Error
+//	}
+func (i illogical) EvaluateStream(exp any, contexts <-chan e.Context) <-chan Result {
+	workers := i.workers()
+	results := make(chan Result, workers)
+
+	evaluate, err := i.compile(exp)
+	if err != nil {
+		evaluate = func(e.Context) (any, error) {
+			return nil, err
+		}
+	}
+
+	jobs := make(chan job)
+	for range workers {
+		go func() {
+			for j := range jobs {
+				val, err := evaluate(j.ctx)
+				j.res <- Result{j.index, val, err}
+			}
+		}()
+	}
+
+	// Pending results in the order of the data contexts, bounding the number of the evaluated
+	// data contexts ahead of the received results.
+	pending := make(chan chan Result, workers)
+	go func() {
+		defer close(pending)
+		defer close(jobs)
+		index := 0
+		for ctx := range contexts {
+			res := make(chan Result, 1)
+			pending <- res
+			jobs <- job{index, ctx, res}
+			index++
+		}
+	}()
+
+	go func() {
+		defer close(results)
+		for res := range pending {
+			results <- <-res
+		}
+	}()
+
+	return results
+}
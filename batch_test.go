@@ -0,0 +1,105 @@
+package goillogical
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	e "github.com/spaceavocado/goillogical/evaluable"
+)
+
+func contexts(n int) []e.Context {
+	res := make([]e.Context, n)
+	for j := range res {
+		res[j] = e.Context{"age": j}
+	}
+	return res
+}
+
+func TestEvaluateBatch(t *testing.T) {
+	var tests = []struct {
+		opts     []Option
+		input    any
+		contexts []e.Context
+		expected []any
+		errs     []error
+	}{
+		{nil, []any{">", "$age", 1}, contexts(3), []any{false, false, true}, []error{nil, nil, nil}},
+		{[]Option{WithWorkers(1)}, []any{"+", "$age", 1}, contexts(3), []any{1, 2, 3}, []error{nil, nil, nil}},
+		{[]Option{WithWorkers(8)}, []any{"+", "$age", 1}, contexts(2), []any{1, 2}, []error{nil, nil}},
+		{[]Option{WithThreeValuedLogic()}, []any{"==", "$x", 1}, contexts(2), []any{TruthUnknown, TruthUnknown}, []error{nil, nil}},
+		{nil, []any{"==", "$age", 1}, []e.Context{}, []any{}, []error{}},
+		{
+			nil,
+			[]any{"/", 1, "$age"},
+			[]e.Context{{"age": 0}, {"age": 2}, {"age": func() {}}},
+			[]any{nil, 0.5, nil},
+			[]error{ErrDivisionByZero, nil, ErrUnsupportedValue},
+		},
+		{nil, []any{"==", struct{}{}, 1}, contexts(2), []any{nil, nil}, []error{ErrInvalidOperand, ErrInvalidOperand}},
+	}
+
+	for _, test := range tests {
+		illogical := New(test.opts...)
+		output, errs := illogical.EvaluateBatch(test.input, test.contexts)
+		if !reflect.DeepEqual(output, test.expected) || len(errs) != len(test.errs) {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v", test.input, test.expected, test.errs, output, errs)
+			continue
+		}
+		for j, err := range errs {
+			if !errors.Is(err, test.errs[j]) || (err == nil) != (test.errs[j] == nil) {
+				t.Errorf("input (%v): expected %v, got %v", test.input, test.errs[j], err)
+			}
+		}
+	}
+}
+
+func TestEvaluateStream(t *testing.T) {
+	var tests = []struct {
+		opts     []Option
+		input    any
+		contexts []e.Context
+		expected []any
+		err      error
+	}{
+		{nil, []any{">", "$age", 1}, contexts(3), []any{false, false, true}, nil},
+		{[]Option{WithWorkers(1)}, []any{"+", "$age", 1}, contexts(3), []any{1, 2, 3}, nil},
+		{[]Option{WithWorkers(4)}, []any{"+", "$age", 1}, contexts(100), nil, nil},
+		{[]Option{WithThreeValuedLogic()}, []any{"==", "$x", 1}, contexts(2), []any{TruthUnknown, TruthUnknown}, nil},
+		{nil, []any{"==", "$age", 1}, []e.Context{}, []any{}, nil},
+		{nil, []any{"==", struct{}{}, 1}, contexts(2), []any{nil, nil}, ErrInvalidOperand},
+	}
+
+	for _, test := range tests {
+		illogical := New(test.opts...)
+
+		stream := make(chan e.Context)
+		go func() {
+			defer close(stream)
+			for _, ctx := range test.contexts {
+				stream <- ctx
+			}
+		}()
+
+		output := []any{}
+		for res := range illogical.EvaluateStream(test.input, stream) {
+			if res.Index != len(output) {
+				t.Errorf("input (%v): expected index %v, got %v", test.input, len(output), res.Index)
+			}
+			if !errors.Is(res.Error, test.err) || (res.Error == nil) != (test.err == nil) {
+				t.Errorf("input (%v): expected %v, got %v", test.input, test.err, res.Error)
+			}
+			output = append(output, res.Value)
+		}
+
+		expected := test.expected
+		if expected == nil {
+			for j := range test.contexts {
+				expected = append(expected, j+1)
+			}
+		}
+		if !reflect.DeepEqual(output, expected) {
+			t.Errorf("input (%v): expected %v, got %v", test.input, expected, output)
+		}
+	}
+}
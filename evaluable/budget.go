@@ -0,0 +1,168 @@
+package evaluable
+
+import (
+	"context"
+	"reflect"
+)
+
+// Evaluation budget, i.e. the limits of a single evaluation, a zero limit is unlimited.
+type Budget struct {
+	// Maximal number of the evaluated nodes, i.e. expressions, collections, references and values.
+	// A node evaluated multiple times, e.g. the predicate of a quantifier, is counted each time.
+	MaxNodes int
+	// Maximal number of elements of an evaluated collection, e.g. a collection operand, the
+	// values selected by a reference, e.g. "$items[*]", or the elements of a quantifier.
+	MaxCollectionSize int
+}
+
+type budgetKey struct{}
+
+// Attach the evaluation budget to the context, see EvaluateContext.
+func WithBudget(ctx context.Context, budget Budget) context.Context {
+	return context.WithValue(ctx, budgetKey{}, budget)
+}
+
+// Get the evaluation budget attached to the context, false if not attached.
+func BudgetOf(ctx context.Context) (Budget, bool) {
+	budget, ok := ctx.Value(budgetKey{}).(Budget)
+	return budget, ok
+}
+
+// Evaluation state shared by the nodes of a single evaluation.
+type guard struct {
+	ctx    context.Context
+	budget Budget
+	nodes  int
+}
+
+type guardKey struct{}
+
+// Get the evaluation state of the context, creating a new one, i.e. a new evaluation, if the
+// context does not carry any.
+func guardOf(ctx context.Context) (context.Context, *guard) {
+	if g, ok := ctx.Value(guardKey{}).(*guard); ok {
+		return ctx, g
+	}
+	budget, _ := BudgetOf(ctx)
+	g := &guard{ctx: ctx, budget: budget}
+	return context.WithValue(ctx, guardKey{}, g), g
+}
+
+// Visit a node, fails if the context is done, or the number of the evaluated nodes exceeds the
+// budget.
+func (g *guard) visit() error {
+	if err := g.ctx.Err(); err != nil {
+		return err
+	}
+	g.nodes++
+	if g.budget.MaxNodes > 0 && g.nodes > g.budget.MaxNodes {
+		return &BudgetError{Limit: "nodes", Max: g.budget.MaxNodes}
+	}
+	return nil
+}
+
+// Check the number of elements of a collection, fails if it exceeds the budget.
+func (g *guard) size(n int) error {
+	if g.budget.MaxCollectionSize > 0 && n > g.budget.MaxCollectionSize {
+		return &BudgetError{Limit: "collection size", Max: g.budget.MaxCollectionSize}
+	}
+	return nil
+}
+
+// Scan an evaluated value, fails if the value is a collection exceeding the budget.
+func (g *guard) scan(val any) error {
+	if val == nil {
+		return nil
+	}
+	if v := reflect.ValueOf(val); v.Kind() == reflect.Slice {
+		return g.size(v.Len())
+	}
+	return nil
+}
+
+// Evaluate a node within the evaluation carried by the context, i.e. the node is counted, and its
+// evaluated value is scanned, against the evaluation budget, see EvaluateContext. The evaluate
+// function evaluates the node, given the context to evaluate its operands with.
+//
+// Example:
+//
+//	func (c comparison) EvaluateContext(ctx context.Context, data Context) (any, error) {
+//		return Guard(ctx, func(ctx context.Context) (any, error) {
+//			evaluated, err := EvaluateOperands(ctx, c.operands, data)
+//			...
+//		})
+//	}
+func Guard(ctx context.Context, evaluate func(context.Context) (any, error)) (any, error) {
+	ctx, g := guardOf(ctx)
+	if err := g.visit(); err != nil {
+		return nil, err
+	}
+	val, err := evaluate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.scan(val); err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+// Check the number of elements of a collection evaluated element by element, e.g. the elements of
+// a quantifier, against the evaluation budget carried by the context, see Guard.
+func GuardSize(ctx context.Context, n int) error {
+	if g, ok := ctx.Value(guardKey{}).(*guard); ok {
+		return g.size(n)
+	}
+	return nil
+}
+
+// Evaluate each of the operands in the given data context, within the evaluation carried by the
+// context, see Guard.
+func EvaluateOperands(ctx context.Context, operands []Evaluable, data Context) ([]any, error) {
+	res := make([]any, len(operands))
+	for i, operand := range operands {
+		val, err := operand.EvaluateContext(ctx, data)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = val
+	}
+	return res, nil
+}
+
+type bound struct {
+	Evaluable
+	ctx context.Context
+}
+
+func (b bound) Evaluate(data Context) (any, error) {
+	return b.Evaluable.EvaluateContext(b.ctx, data)
+}
+
+// Bind the operands to the evaluation carried by the context, i.e. the operands are evaluated
+// with EvaluateContext, useful for the handlers evaluating the operands themselves.
+func BindOperands(ctx context.Context, operands []Evaluable) []Evaluable {
+	res := make([]Evaluable, len(operands))
+	for i, operand := range operands {
+		res[i] = bound{operand, ctx}
+	}
+	return res
+}
+
+// Evaluate the evaluable in the given data context, honoring the cancellation of the context
+// between the evaluation of the nodes, and the evaluation budget attached to the context, see
+// WithBudget. Returns the context error if the context is done, or a BudgetError, see
+// ErrBudgetExceeded, if the budget has been exceeded. Equal to Evaluable.EvaluateContext.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+//	defer cancel()
+//
+//	EvaluateContext(WithBudget(ctx, Budget{MaxNodes: 1000}), eval, data)
+func EvaluateContext(ctx context.Context, eval Evaluable, data Context) (any, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return eval.EvaluateContext(ctx, data)
+}
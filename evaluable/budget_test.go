@@ -0,0 +1,142 @@
+package evaluable
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type list struct {
+	node
+}
+
+func (l list) Evaluate(ctx Context) (any, error) {
+	res := []any{}
+	for _, o := range l.operands {
+		val, err := o.Evaluate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, val)
+	}
+	return res, nil
+}
+
+func (l list) EvaluateContext(ctx context.Context, data Context) (any, error) {
+	return Guard(ctx, func(ctx context.Context) (any, error) {
+		return EvaluateOperands(ctx, l.operands, data)
+	})
+}
+
+// Evaluated element by element, e.g. a quantifier.
+type elements struct {
+	node
+	n int
+}
+
+func (el elements) EvaluateContext(ctx context.Context, data Context) (any, error) {
+	return Guard(ctx, func(ctx context.Context) (any, error) {
+		return el.n, GuardSize(ctx, el.n)
+	})
+}
+
+func TestEvaluateContext(t *testing.T) {
+	tree := list{node{"a", []Evaluable{plain{1}, list{node{"b", []Evaluable{plain{2}, plain{3}}}}}}}
+
+	var tests = []struct {
+		budget   Budget
+		expected any
+		err      error
+	}{
+		{Budget{}, []any{1, []any{2, 3}}, nil},
+		{Budget{MaxNodes: 5}, []any{1, []any{2, 3}}, nil},
+		{Budget{MaxNodes: 4}, nil, &BudgetError{Limit: "nodes", Max: 4}},
+		{Budget{MaxCollectionSize: 2}, []any{1, []any{2, 3}}, nil},
+		{Budget{MaxCollectionSize: 1}, nil, &BudgetError{Limit: "collection size", Max: 1}},
+	}
+
+	for _, test := range tests {
+		output, err := EvaluateContext(WithBudget(context.Background(), test.budget), tree, Context{})
+		if !reflect.DeepEqual(output, test.expected) || !reflect.DeepEqual(err, test.err) {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v", test.budget, test.expected, test.err, output, err)
+		}
+		if test.err != nil && !errors.Is(err, ErrBudgetExceeded) {
+			t.Errorf("input (%v): expected %v, got %v", test.budget, ErrBudgetExceeded, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := EvaluateContext(ctx, tree, Context{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("input (%v): expected %v, got %v", tree, context.Canceled, err)
+	}
+
+	// Cancelled between the node evaluations.
+	ctx, cancel = context.WithCancel(context.Background())
+	cancelling := list{node{"a", []Evaluable{plain{1}, cancelled{cancel}, plain{2}}}}
+	if _, err := EvaluateContext(ctx, cancelling, Context{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("input (%v): expected %v, got %v", cancelling, context.Canceled, err)
+	}
+}
+
+type cancelled struct {
+	cancel context.CancelFunc
+}
+
+func (c cancelled) Evaluate(Context) (any, error) { c.cancel(); return nil, nil }
+func (c cancelled) EvaluateContext(ctx context.Context, data Context) (any, error) {
+	return Guard(ctx, func(context.Context) (any, error) { return c.Evaluate(data) })
+}
+func (c cancelled) Serialize() any                    { return nil }
+func (c cancelled) Simplify(Context) (any, Evaluable) { return nil, c }
+func (c cancelled) String() string                    { return "cancelled" }
+
+func TestGuardSize(t *testing.T) {
+	var tests = []struct {
+		budget   Budget
+		input    int
+		expected error
+	}{
+		{Budget{}, 1000, nil},
+		{Budget{MaxCollectionSize: 10}, 10, nil},
+		{Budget{MaxCollectionSize: 10}, 1000, &BudgetError{Limit: "collection size", Max: 10}},
+	}
+
+	for _, test := range tests {
+		eval := elements{node{"el", nil}, test.input}
+		if _, err := EvaluateContext(WithBudget(context.Background(), test.budget), eval, Context{}); !reflect.DeepEqual(err, test.expected) {
+			t.Errorf("input (%v, %v): expected %v, got %v", test.budget, test.input, test.expected, err)
+		}
+	}
+
+	// Not evaluated within an evaluation.
+	if err := GuardSize(WithBudget(context.Background(), Budget{MaxCollectionSize: 1}), 2); err != nil {
+		t.Errorf("input (%v): expected nil, got %v", 2, err)
+	}
+}
+
+// Each evaluation has its own evaluation state, i.e. the budget is not shared by the evaluations.
+func TestGuardEvaluations(t *testing.T) {
+	ctx := WithBudget(context.Background(), Budget{MaxNodes: 3})
+	tree := list{node{"a", []Evaluable{plain{1}, plain{2}}}}
+
+	for i := 0; i < 2; i++ {
+		if _, err := EvaluateContext(ctx, tree, Context{}); err != nil {
+			t.Errorf("input (%v): expected nil, got %v", tree, err)
+		}
+	}
+	bound := BindOperands(ctx, []Evaluable{tree})
+	if _, err := bound[0].Evaluate(Context{}); err != nil {
+		t.Errorf("input (%v): expected nil, got %v", tree, err)
+	}
+}
+
+func TestBudgetOf(t *testing.T) {
+	if _, ok := BudgetOf(context.Background()); ok {
+		t.Errorf("input (%v): expected no budget", context.Background())
+	}
+	if budget, ok := BudgetOf(WithBudget(context.Background(), Budget{MaxNodes: 1})); !ok || budget.MaxNodes != 1 {
+		t.Errorf("input (%v): expected %v, got %v/%v", 1, Budget{MaxNodes: 1}, budget, ok)
+	}
+}
@@ -0,0 +1,56 @@
+package evaluable
+
+// Compiled evaluation function of an Evaluable, evaluating it in an already flattened context.
+type Func func(Context) (any, error)
+
+// Compilable is an Evaluable which could be compiled into an evaluation function, i.e.
+// everything what does not depend on the evaluation context is resolved upfront.
+type Compilable interface {
+	// Compile the evaluable into an evaluation function.
+	Compile() (Func, error)
+}
+
+type compiled struct {
+	Evaluable
+	fn Func
+}
+
+func (c compiled) Evaluate(ctx Context) (any, error) {
+	return c.fn(ctx)
+}
+
+// Compile the evaluable into an evaluation function, an evaluable which is not Compilable is
+// evaluated as it is.
+func Compile(eval Evaluable) (Func, error) {
+	if c, ok := eval.(Compilable); ok {
+		return c.Compile()
+	}
+	return eval.Evaluate, nil
+}
+
+// Compile each of the operands, the compiled operands are evaluated by their compiled evaluation
+// function, while being serialized, simplified and represented as the original operands.
+func CompileOperands(operands []Evaluable) ([]Evaluable, error) {
+	res := make([]Evaluable, len(operands))
+	for i, operand := range operands {
+		fn, err := Compile(operand)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = compiled{operand, fn}
+	}
+	return res, nil
+}
+
+// Compile each of the operands into its evaluation function.
+func CompileFuncs(operands []Evaluable) ([]Func, error) {
+	res := make([]Func, len(operands))
+	for i, operand := range operands {
+		fn, err := Compile(operand)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = fn
+	}
+	return res, nil
+}
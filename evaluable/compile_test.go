@@ -0,0 +1,101 @@
+package evaluable
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type constant struct {
+	val any
+}
+
+func (c constant) Evaluate(Context) (any, error) { return c.val, nil }
+func (c constant) EvaluateContext(ctx context.Context, data Context) (any, error) {
+	return Guard(ctx, func(context.Context) (any, error) { return c.Evaluate(data) })
+}
+func (c constant) Serialize() any                    { return c.val }
+func (c constant) Simplify(Context) (any, Evaluable) { return c.val, nil }
+func (c constant) String() string                    { return "constant" }
+func (c constant) Compile() (Func, error) {
+	return func(Context) (any, error) { return "compiled", nil }, nil
+}
+
+type invalid struct {
+	constant
+}
+
+func (invalid) Compile() (Func, error) { return nil, errors.New("invalid") }
+
+type plain struct {
+	val any
+}
+
+func (p plain) Evaluate(Context) (any, error) { return p.val, nil }
+func (p plain) EvaluateContext(ctx context.Context, data Context) (any, error) {
+	return Guard(ctx, func(context.Context) (any, error) { return p.Evaluate(data) })
+}
+func (p plain) Serialize() any                    { return p.val }
+func (p plain) Simplify(Context) (any, Evaluable) { return p.val, nil }
+func (p plain) String() string                    { return "plain" }
+
+func TestCompile(t *testing.T) {
+	var tests = []struct {
+		input    Evaluable
+		expected any
+	}{
+		{constant{1}, "compiled"},
+		{plain{1}, 1},
+	}
+
+	for _, test := range tests {
+		fn, err := Compile(test.input)
+		if err != nil {
+			t.Errorf("input (%v): unexpected error %v", test.input, err)
+			continue
+		}
+		if output, err := fn(Context{}); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	if _, err := Compile(invalid{}); err == nil || err.Error() != "invalid" {
+		t.Errorf("input (%v): expected %v, got %v", invalid{}, "invalid", err)
+	}
+}
+
+func TestCompileOperands(t *testing.T) {
+	operands, err := CompileOperands([]Evaluable{constant{1}, plain{2}})
+	if err != nil || len(operands) != 2 {
+		t.Fatalf("expected 2 compiled operands, got %v/%v", operands, err)
+	}
+
+	var tests = []struct {
+		operand   Evaluable
+		evaluated any
+		serialize any
+		str       string
+	}{
+		{operands[0], "compiled", 1, "constant"},
+		{operands[1], 2, 2, "plain"},
+	}
+
+	for _, test := range tests {
+		if output, err := test.operand.Evaluate(Context{}); output != test.evaluated || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.operand, test.evaluated, output, err)
+		}
+		if output := test.operand.Serialize(); output != test.serialize {
+			t.Errorf("input (%v): expected %v, got %v", test.operand, test.serialize, output)
+		}
+		if output := test.operand.String(); output != test.str {
+			t.Errorf("input (%v): expected %v, got %v", test.operand, test.str, output)
+		}
+	}
+
+	if _, err := CompileOperands([]Evaluable{constant{1}, invalid{}}); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+	if _, err := CompileFuncs([]Evaluable{invalid{}}); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
@@ -0,0 +1,127 @@
+package evaluable
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors of the expression parsing, usable with errors.Is.
+var (
+	// The expression input is not defined.
+	ErrUnexpectedInput = errors.New("unexpected input")
+	// The operand is not a valid value, reference or collection.
+	ErrInvalidOperand = errors.New("invalid operand")
+	// The reference operand is not valid, e.g. unsupported data type casting.
+	ErrInvalidReference = errors.New("invalid reference")
+	// The expression operator is not a known operator.
+	ErrUnexpectedOperator = errors.New("unexpected logical operator")
+	// The expression operator is given an invalid number of operands.
+	ErrOperatorArity = errors.New("invalid number of operands")
+	// The static pattern operand is not a valid regular expression.
+	ErrInvalidPattern = errors.New("invalid pattern")
+	// The custom operator could not be registered, e.g. its name could not be expressed in the
+	// statement, or it accepts no operands.
+	ErrInvalidOperator = errors.New("invalid operator")
+)
+
+// Sentinel errors of the arithmetic expressions evaluation, usable with errors.Is.
+var (
+	// The arithmetic operand is not a number.
+	ErrNonNumericOperand = errors.New("non numeric operand")
+	// The arithmetic division, or modulo, by zero.
+	ErrDivisionByZero = errors.New("division by zero")
+)
+
+// Sentinel error of the context flattening, the context value could not be flattened, e.g. a
+// function, a channel or a map with non-string keys.
+var ErrUnsupportedValue = errors.New("unsupported context value")
+
+// Sentinel error of the evaluation, the evaluation budget has been exceeded, see Budget.
+var ErrBudgetExceeded = errors.New("evaluation budget exceeded")
+
+// Budget error describing the exceeded limit of the evaluation budget, see Budget.
+type BudgetError struct {
+	// Exceeded limit, i.e. "nodes" or "collection size".
+	Limit string
+	// Maximal value of the limit.
+	Max int
+}
+
+func (e *BudgetError) Error() string {
+	return fmt.Sprintf("evaluation budget exceeded, %s over %d", e.Limit, e.Max)
+}
+
+func (e *BudgetError) Unwrap() error {
+	return ErrBudgetExceeded
+}
+
+// Number of operands accepted by an operator.
+type Arity struct {
+	// Minimal number of operands.
+	Min int
+	// Maximal number of operands, negative for unbounded number of operands.
+	Max int
+}
+
+// Is the given number of operands accepted.
+func (a Arity) Accepts(n int) bool {
+	return n >= a.Min && (a.Max < 0 || n <= a.Max)
+}
+
+func (a Arity) String() string {
+	switch {
+	case a.Max < 0:
+		return fmt.Sprintf("at least %d", a.Min)
+	case a.Min == a.Max:
+		return fmt.Sprintf("%d", a.Min)
+	default:
+		return fmt.Sprintf("%d to %d", a.Min, a.Max)
+	}
+}
+
+// Parse error locating the failing node within the raw expression.
+type ParseError struct {
+	// JSON pointer of the failing node within the raw expression, e.g. "/2/1/0". Empty for the
+	// root of the expression.
+	Path string
+	// Raw expression fragment of the failing node.
+	Fragment any
+	// Expected operator arity, nil if not applicable.
+	Arity *Arity
+	// Sentinel cause of the error.
+	Cause error
+	// Error description.
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s, at \"%s\"", e.Message, e.Path)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Cause
+}
+
+// Create new parse error of the given sentinel cause.
+func NewParseError(cause error, message string) *ParseError {
+	return &ParseError{Cause: cause, Message: message}
+}
+
+// Create new parse error of the invalid number of operands.
+func NewArityError(arity Arity, message string) *ParseError {
+	return &ParseError{Arity: &arity, Cause: ErrOperatorArity, Message: message}
+}
+
+// Locate the error of a nested node at the given index within its parent node, i.e. prefix the
+// error path with the index. Errors other than ParseError are wrapped as invalid operand.
+func LocateParseError(err error, index int) error {
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		pe = &ParseError{Cause: ErrInvalidOperand, Message: err.Error()}
+	}
+	pe.Path = fmt.Sprintf("/%d%s", index, pe.Path)
+	return pe
+}
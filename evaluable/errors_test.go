@@ -0,0 +1,54 @@
+package evaluable
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestArity(t *testing.T) {
+	var tests = []struct {
+		arity    Arity
+		n        int
+		accepts  bool
+		expected string
+	}{
+		{Arity{1, 1}, 1, true, "1"},
+		{Arity{1, 1}, 2, false, "1"},
+		{Arity{2, 3}, 3, true, "2 to 3"},
+		{Arity{2, -1}, 1, false, "at least 2"},
+		{Arity{2, -1}, 10, true, "at least 2"},
+	}
+
+	for _, test := range tests {
+		if accepts, output := test.arity.Accepts(test.n), test.arity.String(); accepts != test.accepts || output != test.expected {
+			t.Errorf("input (%v, %v): expected %v/%v, got %v/%v", test.arity, test.n, test.accepts, test.expected, accepts, output)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	var tests = []struct {
+		err      error
+		indexes  []int
+		expected string
+		path     string
+		cause    error
+	}{
+		{NewParseError(ErrInvalidOperand, "invalid"), []int{}, "invalid", "", ErrInvalidOperand},
+		{NewParseError(ErrInvalidOperand, "invalid"), []int{1, 2}, "invalid, at \"/2/1\"", "/2/1", ErrInvalidOperand},
+		{NewArityError(Arity{1, 1}, "arity"), []int{0}, "arity, at \"/0\"", "/0", ErrOperatorArity},
+		{errors.New("bogus"), []int{3}, "bogus, at \"/3\"", "/3", ErrInvalidOperand},
+	}
+
+	for _, test := range tests {
+		err := test.err
+		for _, i := range test.indexes {
+			err = LocateParseError(err, i)
+		}
+
+		var pe *ParseError
+		if !errors.As(err, &pe) || err.Error() != test.expected || pe.Path != test.path || !errors.Is(err, test.cause) {
+			t.Errorf("input (%v, %v): expected %v, got %v", test.err, test.indexes, test.expected, err)
+		}
+	}
+}
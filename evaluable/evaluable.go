@@ -4,8 +4,9 @@
 package evaluable
 
 import (
-	"fmt"
-	"reflect"
+	"context"
+	"encoding/json"
+	"time"
 )
 
 const FlattenContextKey string = "_flattenContext"
@@ -25,11 +26,11 @@ const FlattenContextKey string = "_flattenContext"
 //		"age":     21,
 //		"options": []int{1, 2, 3},
 //		"address": struct {
-//			city    string
-//			country string
+//			City    string `illogical:"city"`
+//			Country string `illogical:"country"`
 //		}{
-//			city:    "Toronto",
-//			country: "Canada",
+//			City:    "Toronto",
+//			Country: "Canada",
 //		},
 //		"index":     2,
 //		"segment":   "city",
@@ -66,8 +67,90 @@ const (
 	Overlap
 	Prefix
 	Suffix
+	Custom
+	Matches
+	IMatches
+	Contains
+	NotContains
+	Between
+	BetweenExclusive
+	Before
+	After
+	Add
+	Sub
+	Mul
+	Div
+	Mod
+	Abs
+	Min
+	Max
+	Round
+	Any
+	All
+	None
+	Count
 )
 
+var kindNames = map[Kind]string{
+	Unknown:          "Unknown",
+	Value:            "Value",
+	Reference:        "Reference",
+	Collection:       "Collection",
+	And:              "And",
+	Or:               "Or",
+	Nor:              "Nor",
+	Xor:              "Xor",
+	Not:              "Not",
+	Eq:               "Eq",
+	Ne:               "Ne",
+	Gt:               "Gt",
+	Ge:               "Ge",
+	Lt:               "Lt",
+	Le:               "Le",
+	Nil:              "Nil",
+	Present:          "Present",
+	In:               "In",
+	Nin:              "Nin",
+	Overlap:          "Overlap",
+	Prefix:           "Prefix",
+	Suffix:           "Suffix",
+	Custom:           "Custom",
+	Matches:          "Matches",
+	IMatches:         "IMatches",
+	Contains:         "Contains",
+	NotContains:      "NotContains",
+	Between:          "Between",
+	BetweenExclusive: "BetweenExclusive",
+	Before:           "Before",
+	After:            "After",
+	Add:              "Add",
+	Sub:              "Sub",
+	Mul:              "Mul",
+	Div:              "Div",
+	Mod:              "Mod",
+	Abs:              "Abs",
+	Min:              "Min",
+	Max:              "Max",
+	Round:            "Round",
+	Any:              "Any",
+	All:              "All",
+	None:             "None",
+	Count:            "Count",
+}
+
+// Get the name of the expression kind, e.g. "Eq".
+func (k Kind) String() string {
+	if name, ok := kindNames[k]; ok {
+		return name
+	}
+	return kindNames[Unknown]
+}
+
+// Kind is represented by its name in the text and JSON forms.
+func (k Kind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
 // Operator mapping represents a map between an expression kind (symbol) and the actual
 // text literal denoting an expression.
 //
@@ -81,6 +164,9 @@ type OperatorMapping = map[Kind]string
 type Evaluable interface {
 	// Evaluate given raw expression in the given context.
 	Evaluate(Context) (any, error)
+	// Evaluate given raw expression in the given data context, honoring the cancellation of the
+	// context, and the evaluation budget attached to the context, see WithBudget and Guard.
+	EvaluateContext(context.Context, Context) (any, error)
 	// Serialized the evaluable into raw data form, i.e. parse-able expression.
 	Serialize() any
 	// Simplify the evaluable with a given context into an reduced Evaluable or evaluated value.
@@ -94,18 +180,24 @@ func IsEvaluatedPrimitive(value any) bool {
 	switch value.(type) {
 	case int, int8, int16, int32, int64:
 		return true
-	case float32, float64:
+	case uint, uint8, uint16, uint32, uint64:
+		return true
+	case float32, float64, json.Number:
 		return true
 	case bool:
 		return true
 	case string:
 		return true
+	case time.Time:
+		return true
 	default:
 		return false
 	}
 }
 
-// Flatten context into a map of map[property path]value.
+// Flatten context into a map of map[property path]value. Exported struct fields are named by
+// the "illogical" tag, or the "json" tag, pointers and interfaces are dereferenced. A value which
+// could not be flattened, e.g. a function, is omitted, see Flatten.
 //
 // Example:
 //
@@ -113,11 +205,11 @@ func IsEvaluatedPrimitive(value any) bool {
 //			"name":    "peter",
 //			"options": []int{1, 2, 3},
 //			"address": struct {
-//				city    string
-//				country string
+//				City    string `illogical:"city"`
+//				Country string `json:"country"`
 //			}{
-//				city:    "Toronto",
-//				country: "Canada",
+//				City:    "Toronto",
+//				Country: "Canada",
 //			},
 //		}
 //
@@ -132,52 +224,6 @@ func IsEvaluatedPrimitive(value any) bool {
 //			"address.country": "Canada",
 //		}
 func FlattenContext(ctx Context) map[string]any {
-	if ctx == nil {
-		return nil
-	}
-
-	if value, ok := ctx[FlattenContextKey]; ok && value == FlattenContextKey {
-		return ctx
-	}
-
-	res := make(map[string]any)
-	res[FlattenContextKey] = FlattenContextKey
-
-	var lookup func(p any, path string)
-
-	joinPath := func(a string, b string) string {
-		if len(a) == 0 {
-			return b
-		}
-		return fmt.Sprintf("%s.%s", a, b)
-	}
-
-	lookup = func(val any, path string) {
-		v := reflect.ValueOf(val)
-		switch v.Kind() {
-		case reflect.Bool:
-			fallthrough
-		case reflect.Int, reflect.Int8, reflect.Int32, reflect.Int64:
-			fallthrough
-		case reflect.Uint, reflect.Uint8, reflect.Uint32, reflect.Uint64:
-			fallthrough
-		case reflect.Float32, reflect.Float64:
-			fallthrough
-		case reflect.String:
-			res[path] = val
-		case reflect.Map:
-			for prop, val := range val.(map[string]any) {
-				lookup(val, joinPath(path, prop))
-			}
-		case reflect.Slice:
-			for i := 0; i < v.Len(); i++ {
-				lookup(v.Index(i).Interface(), fmt.Sprintf("%s[%d]", path, i))
-			}
-		default:
-			return
-		}
-	}
-
-	lookup(ctx, "")
+	res, _ := flatten(ctx, newFlattenOptions(nil), false)
 	return res
 }
@@ -1,8 +1,10 @@
 package evaluable
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestFlattenContext(t *testing.T) {
@@ -34,6 +36,9 @@ func TestIsEvaluatedPrimitive(t *testing.T) {
 		{1.1, true},
 		{true, true},
 		{"val", true},
+		{uint8(1), true},
+		{json.Number("1"), true},
+		{time.Time{}, true},
 		{[]any{1}, false},
 	}
 
@@ -0,0 +1,290 @@
+package evaluable
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default struct field tag used to name the struct fields in the flattened context, the json
+// tag is used as a fallback.
+const DefaultContextTag string = "illogical"
+
+type flattenOptions struct {
+	tag string
+}
+
+// Option customizing the context flattening.
+type FlattenOption func(*flattenOptions)
+
+// Struct field tag used to name the struct fields in the flattened context, e.g.
+// `illogical:"name"`, the json tag is used as a fallback.
+func FlattenTag(tag string) FlattenOption {
+	return func(o *flattenOptions) {
+		o.tag = tag
+	}
+}
+
+func newFlattenOptions(opts []FlattenOption) flattenOptions {
+	res := flattenOptions{tag: DefaultContextTag}
+	for _, opt := range opts {
+		opt(&res)
+	}
+	return res
+}
+
+type field struct {
+	index []int
+	name  string
+}
+
+type fieldsKey struct {
+	t   reflect.Type
+	tag string
+}
+
+// Time values are flattened as they are, i.e. not as structs.
+var timeType = reflect.TypeOf(time.Time{})
+
+// Struct fields per struct type and tag.
+var fieldsCache sync.Map
+
+// Name of the struct field given by the tag, or the json tag, returns false if the field is
+// ignored, i.e. "-".
+func tagName(f reflect.StructField, tag string) (string, bool) {
+	for _, t := range []string{tag, "json"} {
+		if value, ok := f.Tag.Lookup(t); ok {
+			name, _, _ := strings.Cut(value, ",")
+			if name == "-" {
+				return "", false
+			}
+			if name != "" {
+				return name, true
+			}
+		}
+	}
+	return "", true
+}
+
+// Exported fields of the struct type, fields of the untagged embedded structs are promoted.
+func structFields(t reflect.Type, tag string) []field {
+	key := fieldsKey{t, tag}
+	if cached, ok := fieldsCache.Load(key); ok {
+		return cached.([]field)
+	}
+
+	res := []field{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, ok := tagName(f, tag)
+		if !ok {
+			continue
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if name == "" && f.Anonymous && ft.Kind() == reflect.Struct {
+			for _, promoted := range structFields(ft, tag) {
+				res = append(res, field{append([]int{i}, promoted.index...), promoted.name})
+			}
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		res = append(res, field{[]int{i}, name})
+	}
+
+	fieldsCache.Store(key, res)
+	return res
+}
+
+func unsupported(v reflect.Value, path string) error {
+	return fmt.Errorf("%w, %s at \"%s\"", ErrUnsupportedValue, v.Type(), path)
+}
+
+func joinPath(a string, b string) string {
+	if len(a) == 0 {
+		return b
+	}
+	return a + "." + b
+}
+
+// Pointer, map or slice being flattened, identified by its address and type.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+type flattener struct {
+	opts    flattenOptions
+	strict  bool
+	res     Context
+	visited map[visit]bool
+}
+
+// Enter the pointer, map or slice, reported as ErrUnsupportedValue if it is already being
+// flattened, i.e. it references itself. Returns the function leaving the value.
+func (f *flattener) enter(v reflect.Value, path string) (func(), error) {
+	key := visit{v.Pointer(), v.Type()}
+	if f.visited[key] {
+		return nil, fmt.Errorf("%w, cyclic %s at \"%s\"", ErrUnsupportedValue, v.Type(), path)
+	}
+	f.visited[key] = true
+	return func() { delete(f.visited, key) }, nil
+}
+
+func (f *flattener) lookup(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.String:
+		f.res[path] = v.Interface()
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return f.lookup(v.Elem(), path)
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		leave, err := f.enter(v, path)
+		if err != nil {
+			return err
+		}
+		defer leave()
+		return f.lookup(v.Elem(), path)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return unsupported(v, path)
+		}
+		leave, err := f.enter(v, path)
+		if err != nil {
+			return err
+		}
+		defer leave()
+		for iter := v.MapRange(); iter.Next(); {
+			if err := f.lookup(iter.Value(), joinPath(path, iter.Key().String())); err != nil && f.strict {
+				return err
+			}
+		}
+	case reflect.Slice:
+		leave, err := f.enter(v, path)
+		if err != nil {
+			return err
+		}
+		defer leave()
+		return f.elements(v, path)
+	case reflect.Array:
+		return f.elements(v, path)
+	case reflect.Struct:
+		if v.Type() == timeType {
+			f.res[path] = v.Interface()
+			return nil
+		}
+		for _, field := range structFields(v.Type(), f.opts.tag) {
+			fv, err := v.FieldByIndexErr(field.index)
+			if err != nil {
+				continue
+			}
+			if err := f.lookup(fv, joinPath(path, field.name)); err != nil && f.strict {
+				return err
+			}
+		}
+	default:
+		return unsupported(v, path)
+	}
+	return nil
+}
+
+// Look up the elements of the slice, or array, indexed by their position.
+func (f *flattener) elements(v reflect.Value, path string) error {
+	for i := 0; i < v.Len(); i++ {
+		if err := f.lookup(v.Index(i), path+"["+strconv.Itoa(i)+"]"); err != nil && f.strict {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flatten context into a map of map[property path]value, see FlattenContext. Contrary to
+// FlattenContext, a value which could not be flattened, e.g. a function, a channel or a map
+// with non-string keys, is reported as ErrUnsupportedValue error.
+//
+// Example:
+//
+//	type Address struct {
+//		City    string `illogical:"city"`
+//		Country string `json:"country"`
+//	}
+//
+//	flattened, err := Flatten(Context{"address": &Address{"Toronto", "Canada"}})
+//
+//	flattened := Context{
+//		"address.city": "Toronto",
+//		"address.country": "Canada",
+//	}
+func Flatten(ctx Context, opts ...FlattenOption) (Context, error) {
+	return flatten(ctx, newFlattenOptions(opts), true)
+}
+
+// Flatten a value, e.g. a struct, a pointer to a struct or a map, into a map of map[property
+// path]value, see Flatten. The fields of the value are the top level properties of the flattened
+// context. A Context value is flattened as Flatten does.
+//
+// Example:
+//
+//	type User struct {
+//		Name string `illogical:"name"`
+//	}
+//
+//	flattened, err := FlattenValue(&User{"peter"})
+//
+//	flattened := Context{
+//		"name": "peter",
+//	}
+func FlattenValue(val any, opts ...FlattenOption) (Context, error) {
+	if ctx, ok := val.(Context); ok {
+		return Flatten(ctx, opts...)
+	}
+
+	f := flattener{opts: newFlattenOptions(opts), strict: true, res: Context{FlattenContextKey: FlattenContextKey}, visited: map[visit]bool{}}
+	if err := f.lookup(reflect.ValueOf(val), ""); err != nil {
+		return nil, err
+	}
+	return f.res, nil
+}
+
+func flatten(ctx Context, opts flattenOptions, strict bool) (Context, error) {
+	if ctx == nil {
+		return nil, nil
+	}
+
+	if value, ok := ctx[FlattenContextKey]; ok && value == FlattenContextKey {
+		return ctx, nil
+	}
+
+	if _, ok := resolverOf(ctx); ok {
+		return ctx, nil
+	}
+
+	f := flattener{opts: opts, strict: strict, res: Context{FlattenContextKey: FlattenContextKey}, visited: map[visit]bool{}}
+	if err := f.lookup(reflect.ValueOf(ctx), ""); err != nil && strict {
+		return nil, err
+	}
+	return f.res, nil
+}
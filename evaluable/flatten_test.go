@@ -0,0 +1,180 @@
+package evaluable
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type Geo struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+type Address struct {
+	City     string `illogical:"city"`
+	Country  string `json:"country,omitempty"`
+	Zip      string `illogical:"-"`
+	Internal string `json:"-"`
+	Street   string
+	Geo      *Geo `illogical:"geo"`
+	note     string
+}
+
+type Base struct {
+	ID int `json:"id"`
+}
+
+type Tier string
+
+type User struct {
+	Base
+	Name    string            `illogical:"name" json:"full_name"`
+	Tier    Tier              `json:"tier"`
+	Address Address           `json:"address"`
+	Tags    []string          `json:"tags"`
+	Scores  map[Tier]int      `json:"scores"`
+	Labels  map[string]string `json:"labels"`
+	Meta    any               `json:"meta"`
+	Manager *User             `json:"manager"`
+}
+
+func TestFlatten(t *testing.T) {
+	shared := map[string]any{"x": 1}
+	user := &User{
+		Base:    Base{ID: 7},
+		Name:    "peter",
+		Tier:    "gold",
+		Address: Address{City: "Toronto", Country: "Canada", Zip: "M5V", Internal: "x", Street: "King", Geo: &Geo{43.6, -79.3}, note: "n"},
+		Tags:    []string{"a", "b"},
+		Scores:  map[Tier]int{"gold": 1},
+		Labels:  map[string]string{"team": "core"},
+		Meta:    map[string]any{"active": true},
+	}
+	at := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	var tests = []struct {
+		input    Context
+		opts     []FlattenOption
+		expected Context
+	}{
+		{nil, nil, nil},
+		{Context{"user": user}, nil, Context{
+			"user.id":              7,
+			"user.name":            "peter",
+			"user.tier":            Tier("gold"),
+			"user.address.city":    "Toronto",
+			"user.address.country": "Canada",
+			"user.address.Street":  "King",
+			"user.address.geo.lat": 43.6,
+			"user.address.geo.lng": -79.3,
+			"user.tags[0]":         "a",
+			"user.tags[1]":         "b",
+			"user.scores.gold":     1,
+			"user.labels.team":     "core",
+			"user.meta.active":     true,
+			FlattenContextKey:      FlattenContextKey,
+		}},
+		{Context{"user": User{Name: "peter"}}, []FlattenOption{FlattenTag("yaml")}, Context{
+			"user.id":              0,
+			"user.full_name":       "peter",
+			"user.tier":            Tier(""),
+			"user.address.City":    "",
+			"user.address.Zip":     "",
+			"user.address.country": "",
+			"user.address.Street":  "",
+			FlattenContextKey:      FlattenContextKey,
+		}},
+		{Context{"a": [2]int{1, 2}, "b": (*int)(nil), "c": nil}, nil, Context{"a[0]": 1, "a[1]": 2, FlattenContextKey: FlattenContextKey}},
+		{Context{"a": shared, "b": shared, "c": []any{shared, shared}}, nil, Context{"a.x": 1, "b.x": 1, "c[0].x": 1, "c[1].x": 1, FlattenContextKey: FlattenContextKey}},
+		{Context{"at": at, "ref": &at, "e": struct{ At time.Time }{at}}, nil, Context{"at": at, "ref": at, "e.At": at, FlattenContextKey: FlattenContextKey}},
+	}
+
+	for _, test := range tests {
+		if output, err := Flatten(test.input, test.opts...); !reflect.DeepEqual(output, test.expected) || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	cyclic := &User{Name: "peter"}
+	cyclic.Manager = cyclic
+	cyclicMap := map[string]any{"name": "peter"}
+	cyclicMap["self"] = cyclicMap
+	cyclicSlice := []any{1, nil}
+	cyclicSlice[1] = cyclicSlice
+
+	var errs = []struct {
+		input    Context
+		expected error
+	}{
+		{Context{"fn": func() {}}, errors.New("unsupported context value, func() at \"fn\"")},
+		{Context{"a": []any{1, make(chan int)}}, errors.New("unsupported context value, chan int at \"a[1]\"")},
+		{Context{"a": map[int]string{1: "a"}}, errors.New("unsupported context value, map[int]string at \"a\"")},
+		{Context{"a": complex(1, 1)}, errors.New("unsupported context value, complex128 at \"a\"")},
+		{Context{"user": cyclic}, errors.New("unsupported context value, cyclic *evaluable.User at \"user.manager\"")},
+		{Context{"user": cyclicMap}, errors.New("unsupported context value, cyclic map[string]interface {} at \"user.self\"")},
+		{Context{"list": cyclicSlice}, errors.New("unsupported context value, cyclic []interface {} at \"list[1]\"")},
+	}
+
+	for _, test := range errs {
+		if _, err := Flatten(test.input); err == nil || err.Error() != test.expected.Error() || !errors.Is(err, ErrUnsupportedValue) {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, err)
+		}
+		// Unsupported values are omitted.
+		if output := FlattenContext(test.input); output == nil {
+			t.Errorf("input (%v): expected flattened context, got %v", test.input, output)
+		}
+	}
+}
+
+func TestFlattenValue(t *testing.T) {
+	var tests = []struct {
+		input    any
+		expected Context
+	}{
+		{&User{Name: "peter", Tags: []string{"a"}}, Context{
+			"id":              0,
+			"name":            "peter",
+			"tier":            Tier(""),
+			"address.city":    "",
+			"address.country": "",
+			"address.Street":  "",
+			"tags[0]":         "a",
+			FlattenContextKey: FlattenContextKey,
+		}},
+		{map[string]int{"a": 1}, Context{"a": 1, FlattenContextKey: FlattenContextKey}},
+		{Context{"a": Context{"b": 1}}, Context{"a.b": 1, FlattenContextKey: FlattenContextKey}},
+		{nil, Context{FlattenContextKey: FlattenContextKey}},
+	}
+
+	for _, test := range tests {
+		if output, err := FlattenValue(test.input); !reflect.DeepEqual(output, test.expected) || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	if _, err := FlattenValue(map[int]string{1: "a"}); !errors.Is(err, ErrUnsupportedValue) {
+		t.Errorf("input (%v): expected %v, got %v", map[int]string{1: "a"}, ErrUnsupportedValue, err)
+	}
+}
+
+func TestStructFields(t *testing.T) {
+	var tests = []struct {
+		input    reflect.Type
+		tag      string
+		expected []field
+	}{
+		{reflect.TypeOf(Geo{}), DefaultContextTag, []field{{[]int{0}, "lat"}, {[]int{1}, "lng"}}},
+		{reflect.TypeOf(User{}), DefaultContextTag, []field{{[]int{0, 0}, "id"}, {[]int{1}, "name"}, {[]int{2}, "tier"}, {[]int{3}, "address"}, {[]int{4}, "tags"}, {[]int{5}, "scores"}, {[]int{6}, "labels"}, {[]int{7}, "meta"}, {[]int{8}, "manager"}}},
+		{reflect.TypeOf(Address{}), "yaml", []field{{[]int{0}, "City"}, {[]int{1}, "country"}, {[]int{2}, "Zip"}, {[]int{4}, "Street"}, {[]int{5}, "Geo"}}},
+	}
+
+	for _, test := range tests {
+		for i := 0; i < 2; i++ {
+			if output := structFields(test.input, test.tag); !reflect.DeepEqual(output, test.expected) {
+				t.Errorf("input (%v, %v): expected %v, got %v", test.input, test.tag, test.expected, output)
+			}
+		}
+	}
+}
@@ -0,0 +1,474 @@
+package evaluable
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Resolver resolves the reference paths on demand, i.e. an alternative to the data context
+// which does not need to be flattened upfront. The path is in the flattened form, e.g.
+// "address.city", "options[0]".
+type Resolver interface {
+	// Lookup the value of the given path, returns false if the path could not be found.
+	Lookup(path string) (any, bool, error)
+}
+
+// Key of the resolver of a resolved data context, see resolved.
+const resolvedContextKey string = "_resolved"
+
+// Resolver of a resolved data context, i.e. the data context the evaluables are given while
+// being evaluated by a resolver, see EvaluateResolver. The type is not exported, i.e. a data
+// context given by the caller is never taken for a resolved data context.
+type resolved struct {
+	resolver Resolver
+}
+
+// Create a data context backed by the given resolver.
+func resolvedContext(r Resolver) Context {
+	return Context{resolvedContextKey: resolved{r}}
+}
+
+// Get the resolver of the resolved data context.
+func resolverOf(ctx Context) (Resolver, bool) {
+	r, ok := ctx[resolvedContextKey].(resolved)
+	return r.resolver, ok
+}
+
+// Evaluate the evaluable, looking up the references by the given resolver instead of the data
+// context.
+//
+// Example:
+//
+//	EvaluateResolver(eval, NewMapResolver(map[string]any{"name": "peter"}))
+func EvaluateResolver(eval Evaluable, r Resolver) (any, error) {
+	return eval.Evaluate(resolvedContext(r))
+}
+
+// Simplify the evaluable, looking up the references by the given resolver instead of the data
+// context, see EvaluateResolver.
+func SimplifyResolver(eval Evaluable, r Resolver) (any, Evaluable) {
+	return eval.Simplify(resolvedContext(r))
+}
+
+// Run the compiled evaluation function, looking up the references by the given resolver instead
+// of the data context, see EvaluateResolver.
+func RunResolver(fn Func, r Resolver) (any, error) {
+	return fn(resolvedContext(r))
+}
+
+// Lookup the value of the given path, either in the resolver of the data context, or directly
+// in the flattened data context.
+func Lookup(ctx Context, path string) (any, bool, error) {
+	if r, ok := resolverOf(ctx); ok {
+		return r.Lookup(path)
+	}
+	val, ok := ctx[path]
+	return val, ok, nil
+}
+
+// Resolver able to determine the number of elements of the slice at the given path, e.g.
+// "items" of the flattened "items[0].price", "items[1].price" paths.
+type LengthResolver interface {
+	// Get the number of elements of the slice at the given path, returns false if the path could
+	// not be found, or it is not a slice.
+	Length(path string) (int, bool, error)
+}
+
+// Get the number of elements of the slice at the given path, either by the resolver of the
+// data context, or by the indexes of the flattened data context keys. A resolver which is not a
+// LengthResolver is probed for the elements, i.e. "items[0]", "items[1]", etc., until an element
+// is not found.
+func Length(ctx Context, path string) (int, bool, error) {
+	if r, ok := resolverOf(ctx); ok {
+		return resolverLength(r, path)
+	}
+	n := flattenedLength(ctx, path)
+	return n, n > 0, nil
+}
+
+func resolverLength(r Resolver, path string) (int, bool, error) {
+	if lr, ok := r.(LengthResolver); ok {
+		return lr.Length(path)
+	}
+
+	n := 0
+	for ; ; n++ {
+		_, found, err := r.Lookup(fmt.Sprintf("%s[%d]", path, n))
+		if err != nil {
+			return 0, false, err
+		}
+		if !found {
+			break
+		}
+	}
+	return n, n > 0, nil
+}
+
+// Number of elements of the slice at the given path, i.e. the highest index of the flattened
+// keys prefixed by the path, plus one. Empty slices are omitted by the flattening.
+func flattenedLength(ctx Context, path string) int {
+	prefix := path + "["
+	n := 0
+	for key := range ctx {
+		rest, ok := strings.CutPrefix(key, prefix)
+		if !ok {
+			continue
+		}
+		end := strings.IndexByte(rest, ']')
+		if end < 0 {
+			continue
+		}
+		if i, err := strconv.Atoi(rest[:end]); err == nil && i >= n {
+			n = i + 1
+		}
+	}
+	return n
+}
+
+// Resolver able to enumerate the paths of the leaf values nested at the given path, e.g.
+// "items[0].price", "items[1].price" of "items".
+type PathsResolver interface {
+	// Get the paths of the leaf values nested at the given path, including the path itself if it
+	// is a leaf value, ordered by the natural order of the paths, see ComparePaths.
+	Paths(path string) ([]string, error)
+}
+
+// Get the paths of the leaf values nested at the given path, either by the resolver of the data
+// context, or by the flattened data context keys. The "" path stands for the whole data context.
+// A resolver which is not a PathsResolver has no paths.
+//
+// Example:
+//
+//	Paths(FlattenContext(Context{"items": []any{Context{"price": 5}}}), "items") // ["items[0].price"]
+func Paths(ctx Context, path string) ([]string, error) {
+	if r, ok := resolverOf(ctx); ok {
+		if pr, ok := r.(PathsResolver); ok {
+			return pr.Paths(path)
+		}
+		return nil, nil
+	}
+	return flattenedPaths(ctx, path), nil
+}
+
+func flattenedPaths(ctx Context, path string) []string {
+	res := []string{}
+	for key := range ctx {
+		if key != FlattenContextKey && isNestedPath(key, path) {
+			res = append(res, key)
+		}
+	}
+	slices.SortFunc(res, ComparePaths)
+	return res
+}
+
+// Whether the key is the path itself, or nested at the path.
+func isNestedPath(key string, path string) bool {
+	rest, ok := strings.CutPrefix(key, path)
+	return ok && (path == "" || rest == "" || rest[0] == '.' || rest[0] == '[')
+}
+
+// Compare the paths by their natural order, i.e. the numbers within the paths are compared by
+// their value, e.g. "items[2]" < "items[10]".
+func ComparePaths(a string, b string) int {
+	for len(a) > 0 && len(b) > 0 {
+		if isDigit(a[0]) && isDigit(b[0]) {
+			i, j := digits(a), digits(b)
+			na, nb := strings.TrimLeft(a[:i], "0"), strings.TrimLeft(b[:j], "0")
+			if c := cmp.Compare(len(na), len(nb)); c != 0 {
+				return c
+			}
+			if c := strings.Compare(na, nb); c != 0 {
+				return c
+			}
+			a, b = a[i:], b[j:]
+			continue
+		}
+		if a[0] != b[0] {
+			return cmp.Compare(a[0], b[0])
+		}
+		a, b = a[1:], b[1:]
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// Length of the leading digits.
+func digits(s string) int {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return i
+}
+
+// Split the path into the candidate keys and the rest of the path, i.e. the keys ending at a
+// path separator, or at the end of the path.
+func splitPath(path string, yield func(key string, rest string) bool) {
+	for i := 1; i <= len(path); i++ {
+		if i < len(path) && path[i] != '.' && path[i] != '[' {
+			continue
+		}
+		rest := path[i:]
+		if strings.HasPrefix(rest, ".") {
+			if rest = rest[1:]; rest == "" {
+				continue
+			}
+		}
+		if !yield(path[:i], rest) {
+			return
+		}
+	}
+}
+
+// Lookup the path in the nested value, the path is resolved the same way as the flattened
+// data context keys.
+func lookupValue(v reflect.Value, path string, full string, opts flattenOptions) (any, bool, error) {
+	node, found, err := lookupNode(v, path, full, opts)
+	if err != nil || !found {
+		return nil, false, err
+	}
+
+	switch node.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.String:
+		return node.Interface(), true, nil
+	case reflect.Struct:
+		if node.Type() == timeType {
+			return node.Interface(), true, nil
+		}
+		return nil, false, nil
+	case reflect.Map:
+		if node.Type().Key().Kind() != reflect.String {
+			return nil, false, unsupported(node, full)
+		}
+		return nil, false, nil
+	case reflect.Slice, reflect.Array:
+		return nil, false, nil
+	default:
+		return nil, false, unsupported(node, full)
+	}
+}
+
+// Lookup the node of the path in the nested value, i.e. a leaf value, or a nested map, struct
+// or slice, pointers and interfaces are dereferenced.
+func lookupNode(v reflect.Value, path string, full string, opts flattenOptions) (reflect.Value, bool, error) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return v, false, nil
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			return v, false, nil
+		}
+		return lookupNode(v.Elem(), path, full, opts)
+	}
+
+	if path == "" {
+		return v, true, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.String:
+		return v, false, nil
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return v, false, unsupported(v, full)
+		}
+		var res reflect.Value
+		var found bool
+		var err error
+		splitPath(path, func(key string, rest string) bool {
+			child := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+			if !child.IsValid() {
+				return true
+			}
+			res, found, err = lookupNode(child, rest, full, opts)
+			return !found && err == nil
+		})
+		return res, found, err
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return v, false, nil
+		}
+		fields := structFields(v.Type(), opts.tag)
+		var res reflect.Value
+		var found bool
+		var err error
+		splitPath(path, func(key string, rest string) bool {
+			for _, field := range fields {
+				if field.name != key {
+					continue
+				}
+				child, e := v.FieldByIndexErr(field.index)
+				if e != nil {
+					return true
+				}
+				res, found, err = lookupNode(child, rest, full, opts)
+				return !found && err == nil
+			}
+			return true
+		})
+		return res, found, err
+	case reflect.Slice, reflect.Array:
+		end := strings.IndexByte(path, ']')
+		if path[0] != '[' || end < 0 {
+			return v, false, nil
+		}
+		i, err := strconv.Atoi(path[1:end])
+		if err != nil || i < 0 || i >= v.Len() || strconv.Itoa(i) != path[1:end] {
+			return v, false, nil
+		}
+		rest := path[end+1:]
+		if strings.HasPrefix(rest, ".") {
+			if rest = rest[1:]; rest == "" {
+				return v, false, nil
+			}
+		}
+		return lookupNode(v.Index(i), rest, full, opts)
+	default:
+		return v, false, unsupported(v, full)
+	}
+}
+
+type mapResolver struct {
+	ctx  map[string]any
+	opts flattenOptions
+}
+
+func (r *mapResolver) Lookup(path string) (any, bool, error) {
+	return lookupValue(reflect.ValueOf(r.ctx), path, path, r.opts)
+}
+
+func (r *mapResolver) Length(path string) (int, bool, error) {
+	node, found, err := lookupNode(reflect.ValueOf(r.ctx), path, path, r.opts)
+	if err != nil || !found {
+		return 0, false, err
+	}
+	if node.Kind() != reflect.Slice && node.Kind() != reflect.Array {
+		return 0, false, nil
+	}
+	return node.Len(), true, nil
+}
+
+func (r *mapResolver) Paths(path string) ([]string, error) {
+	node, found, err := lookupNode(reflect.ValueOf(r.ctx), path, path, r.opts)
+	if err != nil || !found {
+		return nil, err
+	}
+	return nodePaths(node, path, r.opts)
+}
+
+// Paths of the leaf values of the node, prefixed by the path of the node.
+func nodePaths(node reflect.Value, path string, opts flattenOptions) ([]string, error) {
+	f := flattener{opts: opts, strict: true, res: Context{}, visited: map[visit]bool{}}
+	if err := f.lookup(node, path); err != nil {
+		return nil, err
+	}
+	return flattenedPaths(f.res, path), nil
+}
+
+// Create a resolver looking up the paths in the nested data context on demand, i.e. without
+// flattening the whole data context. A value which could not be resolved, e.g. a function, is
+// reported as ErrUnsupportedValue error.
+func NewMapResolver(ctx map[string]any, opts ...FlattenOption) Resolver {
+	return &mapResolver{ctx, newFlattenOptions(opts)}
+}
+
+type flattenedResolver struct {
+	ctx Context
+	err error
+}
+
+func (r *flattenedResolver) Lookup(path string) (any, bool, error) {
+	if r.err != nil {
+		return nil, false, r.err
+	}
+	val, ok := r.ctx[path]
+	return val, ok, nil
+}
+
+func (r *flattenedResolver) Length(path string) (int, bool, error) {
+	if r.err != nil {
+		return 0, false, r.err
+	}
+	n := flattenedLength(r.ctx, path)
+	return n, n > 0, nil
+}
+
+func (r *flattenedResolver) Paths(path string) ([]string, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return flattenedPaths(r.ctx, path), nil
+}
+
+// Create a resolver looking up the paths in the flattened data context, the data context is
+// flattened once, unless it is flattened already. An error of the flattening, see Flatten, is
+// reported by every lookup.
+func NewFlattenedResolver(ctx Context, opts ...FlattenOption) Resolver {
+	flattened, err := Flatten(ctx, opts...)
+	return &flattenedResolver{flattened, err}
+}
+
+type cached struct {
+	val   any
+	found bool
+}
+
+type cachingResolver struct {
+	resolver Resolver
+	mu       sync.RWMutex
+	cache    map[string]cached
+}
+
+func (r *cachingResolver) Lookup(path string) (any, bool, error) {
+	r.mu.RLock()
+	res, ok := r.cache[path]
+	r.mu.RUnlock()
+	if ok {
+		return res.val, res.found, nil
+	}
+
+	val, found, err := r.resolver.Lookup(path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	r.mu.Lock()
+	r.cache[path] = cached{val, found}
+	r.mu.Unlock()
+	return val, found, nil
+}
+
+// The length is not cached, see Length.
+func (r *cachingResolver) Length(path string) (int, bool, error) {
+	return resolverLength(r.resolver, path)
+}
+
+// The paths are not cached, see Paths.
+func (r *cachingResolver) Paths(path string) ([]string, error) {
+	if pr, ok := r.resolver.(PathsResolver); ok {
+		return pr.Paths(path)
+	}
+	return nil, nil
+}
+
+// Create a resolver caching the results of the given resolver, i.e. each path is looked up at
+// most once. Errors are not cached.
+func NewCachingResolver(r Resolver) Resolver {
+	return &cachingResolver{resolver: r, cache: map[string]cached{}}
+}
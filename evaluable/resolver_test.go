@@ -0,0 +1,325 @@
+package evaluable
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLookupValue(t *testing.T) {
+	ctx := map[string]any{
+		"name":    "peter",
+		"age":     21,
+		"options": []int{1, 2, 3},
+		"address": map[string]any{
+			"city": "Toronto",
+			"geo":  []any{43.6, -79.3},
+		},
+		"matrix": []any{[]any{1, 2}, map[string]any{"a": true}},
+		"a.b":    "dotted",
+		"empty":  nil,
+	}
+
+	// Every flattened path must be resolved into the same value.
+	for path, expected := range FlattenContext(ctx) {
+		if path == FlattenContextKey {
+			continue
+		}
+		if output, found, err := NewMapResolver(ctx).Lookup(path); !found || output != expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v/%v", path, expected, output, found, err)
+		}
+	}
+
+	var misses = []string{"", "missing", "address", "options", "options[3]", "options[-1]", "options[01]", "options.0", "address.", "address.city.x", "matrix[0]", "empty", "name[0]"}
+	for _, path := range misses {
+		if output, found, err := NewMapResolver(ctx).Lookup(path); found || err != nil {
+			t.Errorf("input (%v): expected not found, got %v/%v", path, output, err)
+		}
+	}
+}
+
+func TestMapResolverStructs(t *testing.T) {
+	user := &User{
+		Base:    Base{ID: 7},
+		Name:    "peter",
+		Tier:    "gold",
+		Address: Address{City: "Toronto", Geo: &Geo{43.6, -79.3}},
+		Tags:    []string{"a", "b"},
+		Scores:  map[Tier]int{"gold": 1},
+		Meta:    map[string]any{"active": true},
+	}
+	ctx := map[string]any{"user": user, "users": []any{user}}
+
+	flattened, err := Flatten(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, expected := range flattened {
+		if path == FlattenContextKey {
+			continue
+		}
+		if output, found, err := NewMapResolver(ctx).Lookup(path); !found || output != expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v/%v", path, expected, output, found, err)
+		}
+	}
+
+	at := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	for _, path := range []string{"at", "event.At"} {
+		if output, found, err := NewMapResolver(map[string]any{"at": at, "event": struct{ At time.Time }{at}}).Lookup(path); output != at || !found || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v/%v", path, at, output, found, err)
+		}
+	}
+	if output, found, err := NewMapResolver(map[string]any{"at": at}).Lookup("at.wall"); found || output != nil || err != nil {
+		t.Errorf("input (%v): expected not found, got %v/%v/%v", "at.wall", output, found, err)
+	}
+
+	if output, found, err := NewMapResolver(ctx, FlattenTag("yaml")).Lookup("user.full_name"); output != "peter" || !found || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v/%v", "user.full_name", "peter", output, found, err)
+	}
+
+	var errs = []struct {
+		ctx      map[string]any
+		path     string
+		expected error
+	}{
+		{map[string]any{"fn": func() {}}, "fn", errors.New("unsupported context value, func() at \"fn\"")},
+		{map[string]any{"a": map[int]int{}}, "a.1", errors.New("unsupported context value, map[int]int at \"a.1\"")},
+	}
+
+	for _, test := range errs {
+		if _, _, err := NewMapResolver(test.ctx).Lookup(test.path); err == nil || err.Error() != test.expected.Error() || !errors.Is(err, ErrUnsupportedValue) {
+			t.Errorf("input (%v): expected %v, got %v", test.path, test.expected, err)
+		}
+		if _, _, err := NewFlattenedResolver(test.ctx).Lookup("other"); !errors.Is(err, ErrUnsupportedValue) {
+			t.Errorf("input (%v): expected %v, got %v", test.path, ErrUnsupportedValue, err)
+		}
+	}
+}
+
+type countingResolver struct {
+	calls map[string]int
+	err   error
+}
+
+func (r countingResolver) Lookup(path string) (any, bool, error) {
+	r.calls[path]++
+	if r.err != nil {
+		return nil, false, r.err
+	}
+	if path == "name" {
+		return "peter", true, nil
+	}
+	return nil, false, nil
+}
+
+func TestResolvers(t *testing.T) {
+	nested := map[string]any{
+		"address": map[string]any{"city": "Toronto"},
+	}
+
+	var tests = []struct {
+		resolver Resolver
+		path     string
+		expected any
+		found    bool
+	}{
+		{NewMapResolver(nested), "address.city", "Toronto", true},
+		{NewMapResolver(nested), "address", nil, false},
+		{NewFlattenedResolver(nested), "address.city", "Toronto", true},
+		{NewFlattenedResolver(FlattenContext(nested)), "address.city", "Toronto", true},
+		{NewFlattenedResolver(nested), "address.country", nil, false},
+		{NewCachingResolver(NewMapResolver(nested)), "address.city", "Toronto", true},
+	}
+
+	for _, test := range tests {
+		if output, found, err := test.resolver.Lookup(test.path); output != test.expected || found != test.found || err != nil {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v/%v", test.path, test.expected, test.found, output, found, err)
+		}
+	}
+}
+
+func TestCachingResolver(t *testing.T) {
+	counting := countingResolver{calls: map[string]int{}}
+	resolver := NewCachingResolver(counting)
+
+	for i := 0; i < 3; i++ {
+		if output, found, err := resolver.Lookup("name"); output != "peter" || !found || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v/%v", "name", "peter", output, found, err)
+		}
+		if _, found, _ := resolver.Lookup("missing"); found {
+			t.Errorf("input (%v): expected not found", "missing")
+		}
+	}
+	if counting.calls["name"] != 1 || counting.calls["missing"] != 1 {
+		t.Errorf("expected each path to be looked up once, got %v", counting.calls)
+	}
+
+	failing := countingResolver{calls: map[string]int{}, err: errors.New("failed")}
+	resolver = NewCachingResolver(failing)
+	for i := 0; i < 2; i++ {
+		if _, _, err := resolver.Lookup("name"); err == nil || err.Error() != "failed" {
+			t.Errorf("input (%v): expected %v, got %v", "name", "failed", err)
+		}
+	}
+	if failing.calls["name"] != 2 {
+		t.Errorf("expected errors not to be cached, got %v", failing.calls)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	resolver := NewMapResolver(map[string]any{"name": "peter"})
+
+	var tests = []struct {
+		ctx      Context
+		path     string
+		expected any
+		found    bool
+	}{
+		{Context{"name": "peter"}, "name", "peter", true},
+		{Context{"name": "peter"}, "age", nil, false},
+		{nil, "name", nil, false},
+		{resolvedContext(resolver), "name", "peter", true},
+		{resolvedContext(resolver), "age", nil, false},
+	}
+
+	for _, test := range tests {
+		if output, found, err := Lookup(test.ctx, test.path); output != test.expected || found != test.found || err != nil {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v/%v", test.path, test.expected, test.found, output, found, err)
+		}
+	}
+
+	ctx := resolvedContext(resolver)
+	if output := FlattenContext(ctx); len(output) != 1 {
+		t.Errorf("input (%v): expected resolver context not to be flattened, got %v", ctx, output)
+	}
+	if r, ok := resolverOf(ctx); !ok || r != resolver {
+		t.Errorf("input (%v): expected %v, got %v/%v", ctx, resolver, r, ok)
+	}
+
+	// The resolver given by the data context is a value of the data context.
+	ctx = Context{resolvedContextKey: resolver, "name": "john"}
+	if output, found, err := Lookup(FlattenContext(ctx), "name"); output != "john" || !found || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v/%v", ctx, "john", output, found, err)
+	}
+	if r, ok := resolverOf(ctx); ok {
+		t.Errorf("input (%v): expected no resolver, got %v", ctx, r)
+	}
+}
+
+func TestLength(t *testing.T) {
+	nested := map[string]any{
+		"items":  []any{map[string]any{"price": 5}, map[string]any{"price": 50}},
+		"tags":   [2]string{"a", "b"},
+		"empty":  []any{},
+		"name":   "peter",
+		"matrix": []any{[]any{1, 2, 3}},
+	}
+	counting := countingResolver{calls: map[string]int{}}
+
+	var tests = []struct {
+		ctx      Context
+		path     string
+		expected int
+		found    bool
+	}{
+		{FlattenContext(nested), "items", 2, true},
+		{FlattenContext(nested), "tags", 2, true},
+		{FlattenContext(nested), "matrix[0]", 3, true},
+		{FlattenContext(nested), "empty", 0, false},
+		{FlattenContext(nested), "name", 0, false},
+		{FlattenContext(nested), "missing", 0, false},
+		{resolvedContext(NewMapResolver(nested)), "items", 2, true},
+		{resolvedContext(NewMapResolver(nested)), "tags", 2, true},
+		{resolvedContext(NewMapResolver(nested)), "matrix[0]", 3, true},
+		{resolvedContext(NewMapResolver(nested)), "empty", 0, true},
+		{resolvedContext(NewMapResolver(nested)), "name", 0, false},
+		{resolvedContext(NewMapResolver(nested)), "missing", 0, false},
+		{resolvedContext(NewFlattenedResolver(nested)), "items", 2, true},
+		{resolvedContext(NewCachingResolver(NewMapResolver(nested))), "items", 2, true},
+		{resolvedContext(counting), "name", 0, false},
+	}
+
+	for _, test := range tests {
+		if output, found, err := Length(test.ctx, test.path); output != test.expected || found != test.found || err != nil {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v/%v", test.path, test.expected, test.found, output, found, err)
+		}
+	}
+	if counting.calls["name[0]"] != 1 {
+		t.Errorf("expected the resolver to be probed, got %v", counting.calls)
+	}
+
+	failing := countingResolver{calls: map[string]int{}, err: errors.New("failed")}
+	if _, _, err := Length(resolvedContext(failing), "items"); err == nil || err.Error() != "failed" {
+		t.Errorf("input (%v): expected %v, got %v", "items", "failed", err)
+	}
+}
+
+func TestPaths(t *testing.T) {
+	nested := map[string]any{
+		"items": []any{
+			map[string]any{"name": "a", "tags": []any{"x"}},
+			map[string]any{"name": "b"},
+		},
+		"name": "peter",
+	}
+	for i := 0; i < 11; i++ {
+		nested[fmt.Sprintf("list%d", i)] = i
+	}
+	counting := countingResolver{calls: map[string]int{}}
+
+	var tests = []struct {
+		ctx      Context
+		path     string
+		expected []string
+	}{
+		{FlattenContext(nested), "items", []string{"items[0].name", "items[0].tags[0]", "items[1].name"}},
+		{FlattenContext(nested), "items[0]", []string{"items[0].name", "items[0].tags[0]"}},
+		{FlattenContext(nested), "name", []string{"name"}},
+		{FlattenContext(nested), "nam", []string{}},
+		{FlattenContext(nested), "missing", []string{}},
+		{resolvedContext(NewMapResolver(nested)), "items", []string{"items[0].name", "items[0].tags[0]", "items[1].name"}},
+		{resolvedContext(NewMapResolver(nested)), "name", []string{"name"}},
+		{resolvedContext(NewMapResolver(nested)), "missing", nil},
+		{resolvedContext(NewFlattenedResolver(nested)), "items[1]", []string{"items[1].name"}},
+		{resolvedContext(NewCachingResolver(NewMapResolver(nested))), "items[1]", []string{"items[1].name"}},
+		{resolvedContext(NewCachingResolver(counting)), "name", nil},
+		{resolvedContext(counting), "name", nil},
+	}
+
+	for _, test := range tests {
+		if output, err := Paths(test.ctx, test.path); !reflect.DeepEqual(output, test.expected) || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.path, test.expected, output, err)
+		}
+	}
+
+	output, _ := Paths(FlattenContext(nested), "")
+	if len(output) != 15 || output[3] != "list0" || output[4] != "list1" || output[5] != "list2" || output[13] != "list10" {
+		t.Errorf("input (%v): expected naturally ordered paths, got %v", "", output)
+	}
+}
+
+func TestComparePaths(t *testing.T) {
+	var tests = []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{"a", "a", 0},
+		{"a", "b", -1},
+		{"b", "a", 1},
+		{"a", "a.b", -1},
+		{"items[2]", "items[10]", -1},
+		{"items[10]", "items[2]", 1},
+		{"items[10].a", "items[10].b", -1},
+		{"items[02]", "items[2]", 0},
+		{"a1b", "a1c", -1},
+	}
+
+	for _, test := range tests {
+		if output := ComparePaths(test.a, test.b); output != test.expected {
+			t.Errorf("input (%v, %v): expected %v, got %v", test.a, test.b, test.expected, output)
+		}
+	}
+}
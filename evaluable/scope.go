@@ -0,0 +1,129 @@
+package evaluable
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Resolver of a scoped data context, i.e. the data context with an alias bound to an element of
+// a collection, see ScopeContext and ScopeValue.
+type scopedResolver struct {
+	ctx   Context
+	alias string
+	path  string
+	// The alias is bound to the value, rather than to the path.
+	bound bool
+	value reflect.Value
+	opts  flattenOptions
+}
+
+// Get the rest of the path scoped by the alias, i.e. the path following the alias, returns false
+// if the path is not scoped by the alias.
+//
+// Example:
+//
+//	ScopedPath("item", "item.price") // ".price", true
+//	ScopedPath("item", "items") // "", false
+//	ScopedPath("", ".price") // ".price", true
+func ScopedPath(alias string, path string) (string, bool) {
+	rest, ok := strings.CutPrefix(path, alias)
+	if !ok {
+		return "", false
+	}
+	switch {
+	case rest == "" || rest == ".":
+		return "", true
+	case rest[0] == '.' || rest[0] == '[':
+		return rest, true
+	default:
+		return "", false
+	}
+}
+
+func (r *scopedResolver) Lookup(path string) (any, bool, error) {
+	rest, ok := ScopedPath(r.alias, path)
+	if !ok {
+		return Lookup(r.ctx, path)
+	}
+	if r.bound {
+		return lookupValue(r.value, strings.TrimPrefix(rest, "."), path, r.opts)
+	}
+	return Lookup(r.ctx, r.path+rest)
+}
+
+func (r *scopedResolver) Length(path string) (int, bool, error) {
+	rest, ok := ScopedPath(r.alias, path)
+	if !ok {
+		return Length(r.ctx, path)
+	}
+	if r.bound {
+		node, found, err := lookupNode(r.value, strings.TrimPrefix(rest, "."), path, r.opts)
+		if err != nil || !found || (node.Kind() != reflect.Slice && node.Kind() != reflect.Array) {
+			return 0, false, err
+		}
+		return node.Len(), true, nil
+	}
+	return Length(r.ctx, r.path+rest)
+}
+
+// The paths nested at the element are prefixed by the alias, e.g. "item.price".
+func (r *scopedResolver) Paths(path string) ([]string, error) {
+	rest, ok := ScopedPath(r.alias, path)
+	if !ok {
+		return Paths(r.ctx, path)
+	}
+
+	base := r.alias + rest
+	var paths []string
+	var err error
+	if r.bound {
+		node, found, e := lookupNode(r.value, strings.TrimPrefix(rest, "."), path, r.opts)
+		if e != nil || !found {
+			return nil, e
+		}
+		paths, err = nodePaths(node, "", r.opts)
+	} else {
+		prefix := r.path + rest
+		paths, err = Paths(r.ctx, prefix)
+		for i, p := range paths {
+			paths[i] = p[len(prefix):]
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i, p := range paths {
+		if p == "" || p[0] == '.' || p[0] == '[' {
+			paths[i] = base + p
+		} else {
+			paths[i] = base + "." + p
+		}
+	}
+	return paths, nil
+}
+
+// Create a data context with the alias bound to the given path, i.e. the paths prefixed by the
+// alias are resolved relative to the path, any other paths are resolved in the data context.
+// The alias itself, or followed by ".", is resolved as the value at the path.
+//
+// Example:
+//
+//	ctx := ScopeContext(FlattenContext(Context{"items": []any{Context{"price": 5}}}), "item", "items[0]")
+//
+//	Lookup(ctx, "item.price") // 5
+func ScopeContext(ctx Context, alias string, path string) Context {
+	return resolvedContext(&scopedResolver{ctx: FlattenContext(ctx), alias: alias, path: path})
+}
+
+// Create a data context with the alias bound to the given value, see ScopeContext. The value is
+// resolved the same way as the nested data context, see NewMapResolver.
+//
+// Example:
+//
+//	ctx := ScopeValue(Context{}, "item", Context{"price": 5})
+//
+//	Lookup(ctx, "item.price") // 5
+func ScopeValue(ctx Context, alias string, value any, opts ...FlattenOption) Context {
+	return resolvedContext(&scopedResolver{ctx: FlattenContext(ctx), alias: alias, bound: true, value: reflect.ValueOf(value), opts: newFlattenOptions(opts)})
+}
@@ -0,0 +1,157 @@
+package evaluable
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScopedPath(t *testing.T) {
+	var tests = []struct {
+		alias    string
+		path     string
+		expected string
+		scoped   bool
+	}{
+		{"", "", "", true},
+		{"", ".", "", true},
+		{"", ".price", ".price", true},
+		{"", "[0]", "[0]", true},
+		{"", "items", "", false},
+		{"item", "item", "", true},
+		{"item", "item.price", ".price", true},
+		{"item", "item[0].price", "[0].price", true},
+		{"item", "items", "", false},
+		{"item", "price", "", false},
+	}
+
+	for _, test := range tests {
+		if output, scoped := ScopedPath(test.alias, test.path); output != test.expected || scoped != test.scoped {
+			t.Errorf("input (%v, %v): expected %v/%v, got %v/%v", test.alias, test.path, test.expected, test.scoped, output, scoped)
+		}
+	}
+}
+
+func TestScopeContext(t *testing.T) {
+	ctx := FlattenContext(map[string]any{
+		"items": []any{
+			map[string]any{"price": 5, "tags": []any{"a", "b"}},
+			map[string]any{"price": 50},
+		},
+		"limit": 10,
+	})
+
+	var tests = []struct {
+		alias    string
+		path     string
+		lookup   string
+		expected any
+		found    bool
+	}{
+		{"", "items[0]", ".price", 5, true},
+		{"", "items[1]", ".price", 50, true},
+		{"", "items[0]", ".tags[1]", "b", true},
+		{"", "items[0]", "limit", 10, true},
+		{"", "items[0].price", "", 5, true},
+		{"", "items[0].price", ".", 5, true},
+		{"", "items[0]", ".missing", nil, false},
+		{"item", "items[0]", "item.price", 5, true},
+		{"item", "items[0]", "item[0]", nil, false},
+		{"item", "items[0]", "items[1].price", 50, true},
+		{"item", "items[0]", "limit", 10, true},
+		{"item", "items[0].tags", "item[1]", "b", true},
+	}
+
+	for _, test := range tests {
+		scoped := ScopeContext(ctx, test.alias, test.path)
+		if output, found, err := Lookup(scoped, test.lookup); output != test.expected || found != test.found || err != nil {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v/%v", test.lookup, test.expected, test.found, output, found, err)
+		}
+	}
+
+	if output, found, err := Length(ScopeContext(ctx, "item", "items[0]"), "item.tags"); output != 2 || !found || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v/%v", "item.tags", 2, output, found, err)
+	}
+	if output, found, err := Length(ScopeContext(ctx, "item", "items[0]"), "items"); output != 2 || !found || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v/%v", "items", 2, output, found, err)
+	}
+
+	// Nested scopes resolve the outer alias in the outer scope.
+	nested := ScopeContext(ScopeContext(ctx, "item", "items[0]"), "tag", "item.tags[1]")
+	if output, found, err := Lookup(nested, "tag"); output != "b" || !found || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v/%v", "tag", "b", output, found, err)
+	}
+	if output, found, err := Lookup(nested, "item.price"); output != 5 || !found || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v/%v", "item.price", 5, output, found, err)
+	}
+}
+
+func TestScopeValue(t *testing.T) {
+	ctx := Context{"limit": 10}
+	item := map[string]any{"price": 5, "tags": []string{"a", "b"}}
+
+	var tests = []struct {
+		value    any
+		lookup   string
+		expected any
+		found    bool
+	}{
+		{item, ".price", 5, true},
+		{item, ".tags[0]", "a", true},
+		{item, "", nil, false},
+		{item, "limit", 10, true},
+		{7, "", 7, true},
+		{7, ".", 7, true},
+		{7, ".price", nil, false},
+		{nil, "", nil, false},
+		{nil, "limit", 10, true},
+	}
+
+	for _, test := range tests {
+		scoped := ScopeValue(ctx, "", test.value)
+		if output, found, err := Lookup(scoped, test.lookup); output != test.expected || found != test.found || err != nil {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v/%v", test.lookup, test.expected, test.found, output, found, err)
+		}
+	}
+
+	if output, found, err := Length(ScopeValue(ctx, "item", item), "item.tags"); output != 2 || !found || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v/%v", "item.tags", 2, output, found, err)
+	}
+
+	type Item struct {
+		Price int `illogical:"cost"`
+	}
+	if output, found, err := Lookup(ScopeValue(ctx, "item", Item{5}, FlattenTag("illogical")), "item.cost"); output != 5 || !found || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v/%v", "item.cost", 5, output, found, err)
+	}
+}
+
+func TestScopedPaths(t *testing.T) {
+	ctx := FlattenContext(map[string]any{
+		"items": []any{
+			map[string]any{"name": "a", "tags": []any{"x", "y"}},
+		},
+		"limit": 10,
+	})
+	item := map[string]any{"name": "a", "tags": []any{"x", "y"}}
+
+	var tests = []struct {
+		ctx      Context
+		path     string
+		expected []string
+	}{
+		{ScopeContext(ctx, "", "items[0]"), "", []string{".name", ".tags[0]", ".tags[1]"}},
+		{ScopeContext(ctx, "", "items[0]"), ".tags", []string{".tags[0]", ".tags[1]"}},
+		{ScopeContext(ctx, "item", "items[0]"), "item", []string{"item.name", "item.tags[0]", "item.tags[1]"}},
+		{ScopeContext(ctx, "item", "items[0]"), "limit", []string{"limit"}},
+		{ScopeValue(ctx, "", item), "", []string{".name", ".tags[0]", ".tags[1]"}},
+		{ScopeValue(ctx, "item", item), "item.tags", []string{"item.tags[0]", "item.tags[1]"}},
+		{ScopeValue(ctx, "item", "x"), "item", []string{"item"}},
+		{ScopeValue(ctx, "item", item), "item.missing", nil},
+	}
+
+	for _, test := range tests {
+		if output, err := Paths(test.ctx, test.path); !reflect.DeepEqual(output, test.expected) || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.path, test.expected, output, err)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package evaluable
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Trace of an evaluation, i.e. a tree mirroring the evaluated expression.
+type Trace struct {
+	// Kind of the evaluated expression.
+	Kind Kind `json:"kind"`
+	// String representation of the evaluated expression.
+	Expression string `json:"expression"`
+	// Resolved path of a reference, i.e. the path after the nested references interpolation.
+	Path string `json:"path,omitempty"`
+	// Evaluated value.
+	Value any `json:"value"`
+	// Evaluation error.
+	Error string `json:"error,omitempty"`
+	// Whether the expression has not been evaluated due to the short-circuiting.
+	Skipped bool `json:"skipped,omitempty"`
+	// Traces of the operands.
+	Operands []Trace `json:"operands,omitempty"`
+}
+
+func formatValue(val any) string {
+	if s, ok := val.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", val)
+}
+
+func (t Trace) write(sb *strings.Builder, indent string) {
+	sb.WriteString(fmt.Sprintf("%s%s: %s => ", indent, t.Kind, t.Expression))
+	switch {
+	case t.Skipped:
+		sb.WriteString("skipped")
+	case t.Error != "":
+		sb.WriteString(fmt.Sprintf("error: %s", t.Error))
+	default:
+		sb.WriteString(formatValue(t.Value))
+	}
+	if t.Path != "" {
+		sb.WriteString(fmt.Sprintf(", at \"%s\"", t.Path))
+	}
+	sb.WriteString("\n")
+
+	for _, operand := range t.Operands {
+		operand.write(sb, indent+"  ")
+	}
+}
+
+// Get the text representation of the trace, i.e. a line per expression, operands are indented.
+//
+// Example:
+//
+//	And: (({a} == 1) AND ({b} > 2)) => false
+//	  Eq: ({a} == 1) => false
+//	    Reference: {a} => 2, at "a"
+//	    Value: 1 => 1
+//	  Gt: ({b} > 2) => skipped
+func (t Trace) String() string {
+	var sb strings.Builder
+	t.write(&sb, "")
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// Get the JSON representation of the trace.
+func (t Trace) JSON() ([]byte, error) {
+	return json.Marshal(t)
+}
+
+// Explainable is an Evaluable which could trace its evaluation.
+type Explainable interface {
+	// Evaluate the evaluable in the given context, tracing the evaluation.
+	Explain(Context) (Trace, error)
+}
+
+// Get the kind of the evaluable, Unknown if the kind could not be determined.
+func KindOf(eval Evaluable) Kind {
+	if k, ok := eval.(interface{ Kind() Kind }); ok {
+		return k.Kind()
+	}
+	return Unknown
+}
+
+// Create a trace of the evaluated expression.
+func NewTrace(kind Kind, eval Evaluable, value any, err error, operands []Trace) Trace {
+	res := Trace{Kind: kind, Expression: eval.String(), Value: value, Operands: operands}
+	if err != nil {
+		res.Value = nil
+		res.Error = err.Error()
+	}
+	return res
+}
+
+// Create a trace of the expression which has not been evaluated.
+func SkippedTrace(eval Evaluable) Trace {
+	return Trace{Kind: KindOf(eval), Expression: eval.String(), Skipped: true}
+}
+
+// Evaluate the evaluable in the given context, tracing the evaluation. An evaluable which is not
+// Explainable is traced as a whole.
+func Explain(eval Evaluable, ctx Context) (Trace, error) {
+	if x, ok := eval.(Explainable); ok {
+		return x.Explain(ctx)
+	}
+	val, err := eval.Evaluate(ctx)
+	return NewTrace(KindOf(eval), eval, val, err, nil), err
+}
+
+// Explain each of the operands until an error occurs, the remaining operands are skipped.
+func ExplainOperands(ctx Context, operands []Evaluable) ([]any, []Trace, error) {
+	values := make([]any, len(operands))
+	traces := make([]Trace, len(operands))
+	for i, operand := range operands {
+		trace, err := Explain(operand, ctx)
+		traces[i] = trace
+		if err != nil {
+			for j := i + 1; j < len(operands); j++ {
+				traces[j] = SkippedTrace(operands[j])
+			}
+			return nil, traces, err
+		}
+		values[i] = trace.Value
+	}
+	return values, traces, nil
+}
+
+type traced struct {
+	Evaluable
+	trace *Trace
+}
+
+func (t traced) Evaluate(ctx Context) (any, error) {
+	trace, err := Explain(t.Evaluable, ctx)
+	*t.trace = trace
+	return trace.Value, err
+}
+
+// Wrap each of the operands to trace its evaluation, useful when the operands are evaluated by
+// a handler which might short-circuit. The returned function collects the traces, operands which
+// have not been evaluated are traced as skipped.
+func TraceOperands(operands []Evaluable) ([]Evaluable, func() []Trace) {
+	res := make([]Evaluable, len(operands))
+	traces := make([]Trace, len(operands))
+	for i, operand := range operands {
+		traces[i] = SkippedTrace(operand)
+		res[i] = traced{operand, &traces[i]}
+	}
+	return res, func() []Trace {
+		return traces
+	}
+}
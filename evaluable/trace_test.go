@@ -0,0 +1,110 @@
+package evaluable
+
+import (
+	"errors"
+	"testing"
+)
+
+type failing struct {
+	plain
+}
+
+func (failing) Evaluate(Context) (any, error) { return nil, errors.New("failing") }
+func (failing) Kind() Kind                    { return Custom }
+
+func TestKindString(t *testing.T) {
+	var tests = []struct {
+		input    Kind
+		expected string
+	}{
+		{Unknown, "Unknown"},
+		{Eq, "Eq"},
+		{Custom, "Custom"},
+		{Kind(255), "Unknown"},
+	}
+
+	for _, test := range tests {
+		if output := test.input.String(); output != test.expected {
+			t.Errorf("input (%v): expected %v, got %v", int(test.input), test.expected, output)
+		}
+	}
+}
+
+func TestExplain(t *testing.T) {
+	var tests = []struct {
+		input    Evaluable
+		expected Trace
+	}{
+		{plain{1}, Trace{Kind: Unknown, Expression: "plain", Value: 1}},
+		{failing{}, Trace{Kind: Custom, Expression: "plain", Error: "failing"}},
+	}
+
+	for _, test := range tests {
+		if output, _ := Explain(test.input, Context{}); output.String() != test.expected.String() {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, output)
+		}
+	}
+}
+
+func TestExplainOperands(t *testing.T) {
+	values, traces, err := ExplainOperands(Context{}, []Evaluable{plain{1}, plain{2}})
+	if err != nil || len(values) != 2 || values[0] != 1 || values[1] != 2 || len(traces) != 2 {
+		t.Errorf("expected [1 2], got %v/%v/%v", values, traces, err)
+	}
+
+	values, traces, err = ExplainOperands(Context{}, []Evaluable{failing{}, plain{2}})
+	if err == nil || values != nil || traces[0].Error != "failing" || !traces[1].Skipped {
+		t.Errorf("expected failing, got %v/%v/%v", values, traces, err)
+	}
+}
+
+func TestTraceOperands(t *testing.T) {
+	operands, traces := TraceOperands([]Evaluable{plain{1}, failing{}, plain{3}})
+	operands[0].Evaluate(Context{})
+	operands[1].Evaluate(Context{})
+
+	output := traces()
+	if output[0].Value != 1 || output[1].Error != "failing" || output[1].Kind != Custom || !output[2].Skipped {
+		t.Errorf("expected traced operands, got %v", output)
+	}
+}
+
+func TestTraceString(t *testing.T) {
+	trace := Trace{
+		Kind:       And,
+		Expression: "(({a} == \"x\") AND ({b} > 2))",
+		Value:      false,
+		Operands: []Trace{
+			{Kind: Eq, Expression: "({a} == \"x\")", Value: false, Operands: []Trace{
+				{Kind: Reference, Expression: "{a}", Path: "a", Value: "y"},
+				{Kind: Value, Expression: "\"x\"", Value: "x"},
+			}},
+			{Kind: Gt, Expression: "({b} > 2)", Skipped: true},
+		},
+	}
+	expected := `And: (({a} == "x") AND ({b} > 2)) => false
+  Eq: ({a} == "x") => false
+    Reference: {a} => "y", at "a"
+    Value: "x" => "x"
+  Gt: ({b} > 2) => skipped`
+
+	if output := trace.String(); output != expected {
+		t.Errorf("expected %v, got %v", expected, output)
+	}
+}
+
+func TestTraceJSON(t *testing.T) {
+	trace := Trace{
+		Kind:       Not,
+		Expression: "(NOT {a})",
+		Error:      "invalid",
+		Operands: []Trace{
+			{Kind: Reference, Expression: "{a}", Path: "a", Value: 1},
+		},
+	}
+	expected := `{"kind":"Not","expression":"(NOT {a})","value":null,"error":"invalid","operands":[{"kind":"Reference","expression":"{a}","path":"a","value":1}]}`
+
+	if output, err := trace.JSON(); string(output) != expected || err != nil {
+		t.Errorf("expected %v, got %v/%v", expected, string(output), err)
+	}
+}
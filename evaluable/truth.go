@@ -0,0 +1,92 @@
+package evaluable
+
+// Truth value of the three-valued (Kleene) logic, i.e. a boolean value which could be unknown,
+// e.g. a comparison of a reference which could not be found.
+type Truth int8
+
+const (
+	TruthFalse Truth = iota
+	TruthTrue
+	TruthUnknown
+)
+
+// ThreeValued is an Evaluable which could be evaluated with the three-valued logic, i.e. it is
+// evaluated into a Truth value, rather than into a boolean value.
+type ThreeValued interface {
+	// Get the three-valued form of the evaluable.
+	ThreeValued() Evaluable
+}
+
+// Get the truth value of the boolean value.
+func TruthOf(b bool) Truth {
+	if b {
+		return TruthTrue
+	}
+	return TruthFalse
+}
+
+// Get the truth value of the given value, i.e. a boolean or a Truth value, false if the value is
+// neither of them.
+func ToTruth(val any) (Truth, bool) {
+	switch typed := val.(type) {
+	case bool:
+		return TruthOf(typed), true
+	case Truth:
+		return typed, true
+	default:
+		return TruthUnknown, false
+	}
+}
+
+// Get the boolean value, false if the truth value is unknown.
+func (t Truth) Bool() (bool, bool) {
+	return t == TruthTrue, t != TruthUnknown
+}
+
+// Negation, unknown if the truth value is unknown.
+func (t Truth) Not() Truth {
+	switch t {
+	case TruthTrue:
+		return TruthFalse
+	case TruthFalse:
+		return TruthTrue
+	default:
+		return TruthUnknown
+	}
+}
+
+// Conjunction, false if either of the truth values is false, unknown if either of them is
+// unknown.
+func (t Truth) And(o Truth) Truth {
+	if t == TruthFalse || o == TruthFalse {
+		return TruthFalse
+	}
+	if t == TruthUnknown || o == TruthUnknown {
+		return TruthUnknown
+	}
+	return TruthTrue
+}
+
+// Disjunction, true if either of the truth values is true, unknown if either of them is unknown.
+func (t Truth) Or(o Truth) Truth {
+	return t.Not().And(o.Not()).Not()
+}
+
+func (t Truth) String() string {
+	switch t {
+	case TruthTrue:
+		return "true"
+	case TruthFalse:
+		return "false"
+	default:
+		return "unknown"
+	}
+}
+
+// Truth is represented as a boolean in the JSON form, the unknown truth value as null.
+func (t Truth) MarshalJSON() ([]byte, error) {
+	if t == TruthUnknown {
+		return []byte("null"), nil
+	}
+	return []byte(t.String()), nil
+}
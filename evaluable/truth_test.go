@@ -0,0 +1,81 @@
+package evaluable
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestToTruth(t *testing.T) {
+	var tests = []struct {
+		input    any
+		expected Truth
+		ok       bool
+	}{
+		{true, TruthTrue, true},
+		{false, TruthFalse, true},
+		{TruthUnknown, TruthUnknown, true},
+		{TruthTrue, TruthTrue, true},
+		{1, TruthUnknown, false},
+		{nil, TruthUnknown, false},
+	}
+
+	for _, test := range tests {
+		if output, ok := ToTruth(test.input); output != test.expected || ok != test.ok {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v", test.input, test.expected, test.ok, output, ok)
+		}
+	}
+}
+
+func TestTruth(t *testing.T) {
+	T, F, U := TruthTrue, TruthFalse, TruthUnknown
+
+	var tests = []struct {
+		left  Truth
+		right Truth
+		and   Truth
+		or    Truth
+	}{
+		{T, T, T, T},
+		{T, F, F, T},
+		{T, U, U, T},
+		{F, F, F, F},
+		{F, U, F, U},
+		{U, U, U, U},
+		{U, T, U, T},
+		{U, F, F, U},
+	}
+
+	for _, test := range tests {
+		if output := test.left.And(test.right); output != test.and {
+			t.Errorf("input (%v AND %v): expected %v, got %v", test.left, test.right, test.and, output)
+		}
+		if output := test.left.Or(test.right); output != test.or {
+			t.Errorf("input (%v OR %v): expected %v, got %v", test.left, test.right, test.or, output)
+		}
+	}
+
+	var nots = []struct {
+		input    Truth
+		expected Truth
+		str      string
+		json     string
+	}{
+		{T, F, "true", "true"},
+		{F, T, "false", "false"},
+		{U, U, "unknown", "null"},
+	}
+
+	for _, test := range nots {
+		output, _ := json.Marshal(test.input)
+		if test.input.Not() != test.expected || test.input.String() != test.str || string(output) != test.json {
+			t.Errorf("input (%v): expected %v/%v/%v, got %v/%v/%v", test.input, test.expected, test.str, test.json, test.input.Not(), test.input.String(), string(output))
+		}
+	}
+
+	if b, ok := U.Bool(); b || ok {
+		t.Errorf("input (%v): expected %v/%v, got %v/%v", U, false, false, b, ok)
+	}
+	if b, ok := T.Bool(); !b || !ok {
+		t.Errorf("input (%v): expected %v/%v, got %v/%v", T, true, true, b, ok)
+	}
+}
@@ -0,0 +1,80 @@
+package evaluable
+
+// Node is an Evaluable exposing its structure, i.e. the expression kind, the operator and the
+// operands. All of the built-in evaluables are nodes.
+type Node interface {
+	Evaluable
+	// Get the kind of the expression.
+	Kind() Kind
+	// Get the operator of the expression as used in the raw expression, e.g. "==", empty for
+	// the operands, i.e. values, references and collections.
+	Operator() string
+	// Get the operands of the expression, or the items of a collection.
+	Operands() []Evaluable
+	// Create a copy of the node with the given operands, the operands are not validated.
+	WithOperands([]Evaluable) Evaluable
+}
+
+// Get the operands of the evaluable, nil if the evaluable is not a Node.
+func OperandsOf(eval Evaluable) []Evaluable {
+	if n, ok := eval.(Node); ok {
+		return n.Operands()
+	}
+	return nil
+}
+
+// Visitor of the evaluables, returns false to skip the operands of the visited evaluable.
+type Visitor func(Evaluable) bool
+
+// Walk the evaluable tree in depth-first order, i.e. the evaluable is visited before its
+// operands.
+//
+// Example:
+//
+//	paths := []string{}
+//	Walk(eval, func(n Evaluable) bool {
+//		if KindOf(n) == Reference {
+//			paths = append(paths, n.String())
+//		}
+//		return true
+//	})
+func Walk(eval Evaluable, visit Visitor) {
+	if eval == nil || !visit(eval) {
+		return
+	}
+	for _, operand := range OperandsOf(eval) {
+		Walk(operand, visit)
+	}
+}
+
+// Rewrite the evaluable tree bottom-up, i.e. the operands are rewritten first, then the
+// evaluable, holding the rewritten operands, is passed to the rewrite function. Returning the
+// given evaluable, or nil, keeps it unchanged. The original tree is not modified.
+//
+// Example:
+//
+//	// Replace the {a} reference with the value of the {b} reference.
+//	Rewrite(eval, func(n Evaluable) Evaluable {
+//		if KindOf(n) == Reference && n.String() == "{a}" {
+//			return b
+//		}
+//		return n
+//	})
+func Rewrite(eval Evaluable, rewrite func(Evaluable) Evaluable) Evaluable {
+	if eval == nil {
+		return nil
+	}
+
+	if n, ok := eval.(Node); ok && len(n.Operands()) > 0 {
+		operands := make([]Evaluable, len(n.Operands()))
+		for i, operand := range n.Operands() {
+			operands[i] = Rewrite(operand, rewrite)
+		}
+		eval = n.WithOperands(operands)
+	}
+
+	if res := rewrite(eval); res != nil {
+		return res
+	}
+	return eval
+}
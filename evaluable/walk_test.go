@@ -0,0 +1,108 @@
+package evaluable
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type node struct {
+	name     string
+	operands []Evaluable
+}
+
+func (n node) Evaluate(Context) (any, error) { return nil, nil }
+func (n node) EvaluateContext(ctx context.Context, data Context) (any, error) {
+	return Guard(ctx, func(context.Context) (any, error) { return n.Evaluate(data) })
+}
+func (n node) Serialize() any                    { return n.name }
+func (n node) Simplify(Context) (any, Evaluable) { return nil, n }
+func (n node) Kind() Kind                        { return Custom }
+func (n node) Operator() string                  { return n.name }
+func (n node) Operands() []Evaluable             { return n.operands }
+func (n node) String() string {
+	res := []string{n.name}
+	for _, o := range n.operands {
+		res = append(res, o.String())
+	}
+	return "(" + strings.Join(res, " ") + ")"
+}
+func (n node) WithOperands(operands []Evaluable) Evaluable {
+	n.operands = operands
+	return n
+}
+
+func TestOperandsOf(t *testing.T) {
+	if output := OperandsOf(plain{1}); output != nil {
+		t.Errorf("input (%v): expected nil, got %v", plain{1}, output)
+	}
+	if output := OperandsOf(node{"a", []Evaluable{plain{1}}}); len(output) != 1 {
+		t.Errorf("input (%v): expected 1 operand, got %v", "a", output)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	tree := node{"a", []Evaluable{node{"b", []Evaluable{plain{1}}}, node{"c", []Evaluable{plain{2}}}, plain{3}}}
+
+	var tests = []struct {
+		skip     string
+		expected string
+	}{
+		{"", "(a (b plain) (c plain) plain),(b plain),plain,(c plain),plain,plain"},
+		{"(b plain)", "(a (b plain) (c plain) plain),(b plain),(c plain),plain,plain"},
+		{"(a (b plain) (c plain) plain)", "(a (b plain) (c plain) plain)"},
+	}
+
+	for _, test := range tests {
+		visited := []string{}
+		Walk(tree, func(eval Evaluable) bool {
+			visited = append(visited, eval.String())
+			return eval.String() != test.skip
+		})
+		if output := strings.Join(visited, ","); output != test.expected {
+			t.Errorf("input (%v): expected %v, got %v", test.skip, test.expected, output)
+		}
+	}
+
+	Walk(nil, func(Evaluable) bool {
+		t.Error("input (nil): unexpected visit")
+		return true
+	})
+}
+
+func TestRewrite(t *testing.T) {
+	tree := node{"a", []Evaluable{node{"b", []Evaluable{plain{1}}}, plain{3}}}
+
+	var tests = []struct {
+		rewrite  func(Evaluable) Evaluable
+		expected string
+	}{
+		{func(eval Evaluable) Evaluable { return eval }, "(a (b plain) plain)"},
+		{func(eval Evaluable) Evaluable { return nil }, "(a (b plain) plain)"},
+		{func(eval Evaluable) Evaluable {
+			if _, ok := eval.(plain); ok {
+				return node{"x", nil}
+			}
+			return eval
+		}, "(a (b (x)) (x))"},
+		{func(eval Evaluable) Evaluable {
+			if n, ok := eval.(node); ok && n.name == "b" {
+				return n.operands[0]
+			}
+			return eval
+		}, "(a plain plain)"},
+	}
+
+	for _, test := range tests {
+		if output := Rewrite(tree, test.rewrite); output.String() != test.expected {
+			t.Errorf("input (%v): expected %v, got %v", tree, test.expected, output)
+		}
+	}
+
+	if output := tree.String(); output != "(a (b plain) plain)" {
+		t.Errorf("input (%v): expected unmodified tree, got %v", tree, output)
+	}
+	if output := Rewrite(nil, func(eval Evaluable) Evaluable { return eval }); output != nil {
+		t.Errorf("input (nil): expected nil, got %v", output)
+	}
+}
@@ -0,0 +1,33 @@
+package goillogical
+
+import (
+	e "github.com/spaceavocado/goillogical/evaluable"
+)
+
+// Trace of an evaluation, i.e. a tree mirroring the evaluated expression, holding the kind,
+// the evaluated value, the resolved reference path and whether the expression has been skipped
+// due to the short-circuiting, for each of the nodes.
+type Trace = e.Trace
+
+// Evaluate the evaluable in the given context, tracing the evaluation. The trace could be
+// rendered as text, see Trace.String, or JSON, see Trace.JSON. The context is flattened by the
+// given options, e.g. the context tag of the engine, see FlattenTag and WithContextTag.
+//
+// Example:
+//
+//	eval, _ := i.Parse([]any{"AND", []any{"==", "$a", 1}, []any{">", "$b", 2}})
+//	trace, err := illogical.Explain(eval, map[string]any{"a": 2})
+//
+//	trace.String()
+//	// And: (({a} == 1) AND ({b} > 2)) => false
+//	//   Eq: ({a} == 1) => false
+//	//     Reference: {a} => 2, at "a"
+//	//     Value: 1 => 1
+//	//   Gt: ({b} > 2) => skipped
+func Explain(eval e.Evaluable, ctx e.Context, opts ...FlattenOption) (Trace, error) {
+	flattened, err := e.Flatten(ctx, opts...)
+	if err != nil {
+		return Trace{}, err
+	}
+	return e.Explain(eval, flattened)
+}
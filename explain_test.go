@@ -0,0 +1,53 @@
+package goillogical
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	illogical := New()
+	ctx := map[string]any{
+		"a":   2,
+		"key": "b",
+		"b":   "x",
+	}
+
+	var tests = []struct {
+		input    any
+		expected string
+	}{
+		{
+			[]any{"AND", []any{"==", "$a", 1}, []any{">", "$b", 2}},
+			"And: (({a} == 1) AND ({b} > 2)) => false\n  Eq: ({a} == 1) => false\n    Reference: {a} => 2, at \"a\"\n    Value: 1 => 1\n  Gt: ({b} > 2) => skipped",
+		},
+		{
+			[]any{"OR", []any{"==", "${key}", "y"}, []any{"NOT", []any{"NIL", "$a"}}},
+			"Or: (({{key}} == \"y\") OR (NOT ({a} <is nil>))) => true\n  Eq: ({{key}} == \"y\") => false\n    Reference: {{key}} => \"x\", at \"b\"\n    Value: \"y\" => \"y\"\n  Not: (NOT ({a} <is nil>)) => true\n    Nil: ({a} <is nil>) => false\n      Reference: {a} => 2, at \"a\"",
+		},
+	}
+
+	for _, test := range tests {
+		eval, err := illogical.Parse(test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output, err := Explain(eval, ctx); output.String() != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	eval, _ := illogical.Parse([]any{"==", "$a", 1})
+	if _, err := Explain(eval, map[string]any{"a": func() {}}); !errors.Is(err, ErrUnsupportedValue) {
+		t.Errorf("input (%v): expected %v, got %v", eval, ErrUnsupportedValue, err)
+	}
+
+	type user struct {
+		Age int `rule:"age"`
+	}
+
+	eval, _ = illogical.Parse([]any{">=", "$user.age", 18})
+	if output, err := Explain(eval, map[string]any{"user": user{21}}, FlattenTag("rule")); output.Value != true || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", eval, true, output.Value, err)
+	}
+}
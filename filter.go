@@ -0,0 +1,210 @@
+package goillogical
+
+import (
+	"errors"
+
+	e "github.com/spaceavocado/goillogical/evaluable"
+)
+
+// Flattening strategy of the items, the item is flattened itself, e.g. a struct, if the data
+// context function is not given, see evaluable.FlattenValue.
+func flattener[T any](contextOf func(T) e.Context, opts []FlattenOption) func(T) (e.Context, error) {
+	if contextOf == nil {
+		return func(item T) (e.Context, error) {
+			return e.FlattenValue(item, opts...)
+		}
+	}
+	return func(item T) (e.Context, error) {
+		return e.Flatten(contextOf(item), opts...)
+	}
+}
+
+// Compile the evaluable into a matching function of a flattened data context, the evaluable
+// must be evaluated into a boolean, or a Truth, value. An unknown Truth value does not match.
+func matcher(eval e.Evaluable) (func(e.Context) (bool, error), error) {
+	fn, err := e.Compile(eval)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx e.Context) (bool, error) {
+		val, err := fn(ctx)
+		if err != nil {
+			return false, err
+		}
+		t, ok := e.ToTruth(val)
+		if !ok {
+			return false, errors.New("invalid evaluated expression, must be boolean value")
+		}
+		return t == e.TruthTrue, nil
+	}, nil
+}
+
+// Evaluate the evaluable for each of the items, until the visit returns false.
+func each[T any](eval e.Evaluable, items []T, contextOf func(T) e.Context, opts []FlattenOption, visit func(T, bool) bool) error {
+	flatten := flattener(contextOf, opts)
+	match, err := matcher(eval)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		ctx, err := flatten(item)
+		if err != nil {
+			return err
+		}
+		ok, err := match(ctx)
+		if err != nil {
+			return err
+		}
+		if !visit(item, ok) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// Filter the items matching the evaluable, the data context of an item is given by the context
+// function, or the item itself is the data context if the function is nil, e.g. a struct, see
+// evaluable.FlattenValue. The data context is flattened by the given options, e.g. the context
+// tag of the engine, see FlattenTag and WithContextTag. The evaluable is compiled once, the
+// filtering stops at the first evaluation error.
+//
+// Example:
+//
+//	type User struct {
+//		Name string `illogical:"name"`
+//		Age  int    `illogical:"age"`
+//	}
+//
+//	eval, _ := i.Parse([]any{">=", "$age", 18})
+//
+//	adults, err := illogical.Filter(eval, users, nil)
+//	adults, err := illogical.Filter(eval, users, func(u User) e.Context { return e.Context{"age": u.Age} })
+//	adults, err := illogical.Filter(eval, users, nil, illogical.FlattenTag("rule"))
+func Filter[T any](eval e.Evaluable, items []T, contextOf func(T) e.Context, opts ...FlattenOption) ([]T, error) {
+	res := []T{}
+	err := each(eval, items, contextOf, opts, func(item T, ok bool) bool {
+		if ok {
+			res = append(res, item)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Partition the items into the items matching the evaluable, and the rest of them, see Filter.
+//
+// Example:
+//
+// adults, minors, err := illogical.Partition(eval, users, nil)
+func Partition[T any](eval e.Evaluable, items []T, contextOf func(T) e.Context, opts ...FlattenOption) ([]T, []T, error) {
+	matched, rest := []T{}, []T{}
+	err := each(eval, items, contextOf, opts, func(item T, ok bool) bool {
+		if ok {
+			matched = append(matched, item)
+		} else {
+			rest = append(rest, item)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return matched, rest, nil
+}
+
+// Find the first item matching the evaluable, false if none of the items matches, see Filter.
+// The items following the first matching item are not evaluated.
+//
+// Example:
+//
+// adult, found, err := illogical.Find(eval, users, nil)
+func Find[T any](eval e.Evaluable, items []T, contextOf func(T) e.Context, opts ...FlattenOption) (T, bool, error) {
+	var res T
+	found := false
+	err := each(eval, items, contextOf, opts, func(item T, ok bool) bool {
+		if ok {
+			res, found = item, true
+		}
+		return !ok
+	})
+	return res, found, err
+}
+
+// Count the items matching the evaluable, see Filter.
+//
+// Example:
+//
+// adults, err := illogical.Count(eval, users, nil)
+func Count[T any](eval e.Evaluable, items []T, contextOf func(T) e.Context, opts ...FlattenOption) (int, error) {
+	res := 0
+	err := each(eval, items, contextOf, opts, func(_ T, ok bool) bool {
+		if ok {
+			res++
+		}
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+	return res, nil
+}
+
+// Group the items by the first matching rule, i.e. the group of a rule is at the index of the
+// rule, and the items matching none of the rules, see Filter. An item is flattened once, and the
+// rules following the first matching rule are not evaluated.
+//
+// Example:
+//
+//	minor, _ := i.Parse([]any{"<", "$age", 18})
+//	senior, _ := i.Parse([]any{">=", "$age", 65})
+//
+//	groups, rest, err := illogical.GroupByRule([]e.Evaluable{minor, senior}, users, nil)
+//
+//	groups[0] // minors
+//	groups[1] // seniors
+//	rest // neither of them
+func GroupByRule[T any](rules []e.Evaluable, items []T, contextOf func(T) e.Context, opts ...FlattenOption) ([][]T, []T, error) {
+	flatten := flattener(contextOf, opts)
+	matches := make([]func(e.Context) (bool, error), len(rules))
+	for j, rule := range rules {
+		match, err := matcher(rule)
+		if err != nil {
+			return nil, nil, err
+		}
+		matches[j] = match
+	}
+
+	groups, rest := make([][]T, len(rules)), []T{}
+	for j := range groups {
+		groups[j] = []T{}
+	}
+
+	for _, item := range items {
+		ctx, err := flatten(item)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		matched := false
+		for j, match := range matches {
+			ok, err := match(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			if ok {
+				groups[j] = append(groups[j], item)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			rest = append(rest, item)
+		}
+	}
+	return groups, rest, nil
+}
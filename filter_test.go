@@ -0,0 +1,159 @@
+package goillogical
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	e "github.com/spaceavocado/goillogical/evaluable"
+)
+
+type member struct {
+	Name string `illogical:"name"`
+	Age  int    `json:"age"`
+}
+
+var members = []member{{"peter", 21}, {"john", 16}, {"anna", 70}, {"mark", 35}}
+
+func memberContext(m member) e.Context {
+	return e.Context{"name": m.Name, "age": m.Age}
+}
+
+func parse(t *testing.T, exp any, opts ...Option) e.Evaluable {
+	eval, err := New(opts...).Parse(exp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return eval
+}
+
+func TestFilter(t *testing.T) {
+	var tests = []struct {
+		input     any
+		contextOf func(member) e.Context
+		expected  []member
+		count     int
+	}{
+		{[]any{">=", "$age", 18}, nil, []member{{"peter", 21}, {"anna", 70}, {"mark", 35}}, 3},
+		{[]any{">=", "$age", 18}, memberContext, []member{{"peter", 21}, {"anna", 70}, {"mark", 35}}, 3},
+		{[]any{"PREFIX", "jo", "$name"}, nil, []member{{"john", 16}}, 1},
+		{[]any{"==", "$age", 99}, nil, []member{}, 0},
+	}
+
+	for _, test := range tests {
+		eval := parse(t, test.input)
+
+		if output, err := Filter(eval, members, test.contextOf); !reflect.DeepEqual(output, test.expected) || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+		if output, err := Count(eval, members, test.contextOf); output != test.count || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.count, output, err)
+		}
+
+		matched, rest, err := Partition(eval, members, test.contextOf)
+		if !reflect.DeepEqual(matched, test.expected) || len(matched)+len(rest) != len(members) || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v/%v", test.input, test.expected, matched, rest, err)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	var tests = []struct {
+		input    any
+		expected member
+		found    bool
+	}{
+		{[]any{">=", "$age", 18}, member{"peter", 21}, true},
+		{[]any{">", "$age", 50}, member{"anna", 70}, true},
+		{[]any{"==", "$age", 99}, member{}, false},
+		// Short-circuited, the division by zero of the following items is not evaluated.
+		{[]any{"==", []any{"/", 21, []any{"-", "$age", 16}}, 4.2}, member{"peter", 21}, true},
+	}
+
+	for _, test := range tests {
+		if output, found, err := Find(parse(t, test.input), members, nil); output != test.expected || found != test.found || err != nil {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v/%v", test.input, test.expected, test.found, output, found, err)
+		}
+	}
+}
+
+func TestGroupByRule(t *testing.T) {
+	rules := []e.Evaluable{
+		parse(t, []any{"<", "$age", 18}),
+		parse(t, []any{">=", "$age", 65}),
+		parse(t, []any{"<", "$age", 30}),
+	}
+
+	groups, rest, err := GroupByRule(rules, members, nil)
+	expected := [][]member{{{"john", 16}}, {{"anna", 70}}, {{"peter", 21}}}
+	if !reflect.DeepEqual(groups, expected) || !reflect.DeepEqual(rest, []member{{"mark", 35}}) || err != nil {
+		t.Errorf("input (%v): expected %v/%v, got %v/%v/%v", rules, expected, []member{{"mark", 35}}, groups, rest, err)
+	}
+
+	groups, rest, err = GroupByRule([]e.Evaluable{}, members, memberContext)
+	if !reflect.DeepEqual(groups, [][]member{}) || !reflect.DeepEqual(rest, members) || err != nil {
+		t.Errorf("input (%v): expected %v/%v, got %v/%v/%v", []e.Evaluable{}, [][]member{}, members, groups, rest, err)
+	}
+}
+
+func TestFilterContextTag(t *testing.T) {
+	type account struct {
+		Owner member `rule:"owner"`
+	}
+	accounts := []account{{member{"peter", 21}}, {member{"john", 16}}}
+	eval := parse(t, []any{">=", "$owner.age", 18})
+
+	if output, err := Filter(eval, accounts, nil, FlattenTag("rule")); !reflect.DeepEqual(output, accounts[:1]) || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", eval, accounts[:1], output, err)
+	}
+	if groups, rest, err := GroupByRule([]e.Evaluable{eval}, accounts, nil, FlattenTag("rule")); !reflect.DeepEqual(groups, [][]account{accounts[:1]}) || !reflect.DeepEqual(rest, accounts[1:]) || err != nil {
+		t.Errorf("input (%v): expected %v/%v, got %v/%v/%v", eval, accounts[:1], accounts[1:], groups, rest, err)
+	}
+
+	// Default context tag.
+	if output, err := Filter(eval, accounts, nil); !reflect.DeepEqual(output, []account{}) || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", eval, []account{}, output, err)
+	}
+}
+
+func TestFilterThreeValued(t *testing.T) {
+	eval := parse(t, []any{"OR", []any{"==", "$missing", 1}, []any{">", "$age", 50}}, WithThreeValuedLogic())
+
+	// Unknown does not match.
+	if output, err := Filter(eval, members, nil); !reflect.DeepEqual(output, []member{{"anna", 70}}) || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", eval, []member{{"anna", 70}}, output, err)
+	}
+}
+
+func TestFilterErrors(t *testing.T) {
+	var tests = []struct {
+		input    any
+		items    []any
+		expected error
+		sentinel error
+	}{
+		{[]any{"+", "$age", 1}, []any{member{"peter", 21}}, errors.New("invalid evaluated expression, must be boolean value"), nil},
+		{[]any{"==", []any{"/", 1, "$age"}, 1}, []any{member{"peter", 0}}, nil, ErrDivisionByZero},
+		{[]any{"==", "$age", 1}, []any{map[int]string{1: "a"}}, nil, ErrUnsupportedValue},
+	}
+
+	for _, test := range tests {
+		eval := parse(t, test.input)
+		check := func(err error) {
+			if err == nil || (test.expected != nil && err.Error() != test.expected.Error()) || (test.sentinel != nil && !errors.Is(err, test.sentinel)) {
+				t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, err)
+			}
+		}
+
+		_, err := Filter(eval, test.items, nil)
+		check(err)
+		_, _, err = Partition(eval, test.items, nil)
+		check(err)
+		_, _, err = Find(eval, test.items, nil)
+		check(err)
+		_, err = Count(eval, test.items, nil)
+		check(err)
+		_, _, err = GroupByRule([]e.Evaluable{eval}, test.items, nil)
+		check(err)
+	}
+}
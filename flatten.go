@@ -0,0 +1,33 @@
+package goillogical
+
+import (
+	e "github.com/spaceavocado/goillogical/evaluable"
+)
+
+// Option customizing the context flattening.
+type FlattenOption = e.FlattenOption
+
+// Struct field tag used to name the struct fields in the flattened context, e.g.
+// `illogical:"name"`, the json tag is used as a fallback.
+func FlattenTag(tag string) FlattenOption {
+	return e.FlattenTag(tag)
+}
+
+// Flatten the evaluation data context into a map of map[property path]value, exported struct
+// fields are named by the "illogical" tag, or the "json" tag, pointers and interfaces are
+// dereferenced. A value which could not be flattened, e.g. a function, a channel or a map with
+// non-string keys, is reported as ErrUnsupportedValue error. A flattened context is not
+// flattened again, e.g. by Program.Run.
+//
+// Example:
+//
+//	type User struct {
+//		Name string `json:"name"`
+//	}
+//
+//	ctx, err := illogical.Flatten(map[string]any{"user": &User{"peter"}})
+//
+//	ctx // map[user.name:peter]
+func Flatten(ctx e.Context, opts ...FlattenOption) (e.Context, error) {
+	return e.Flatten(ctx, opts...)
+}
@@ -0,0 +1,110 @@
+package goillogical
+
+import (
+	"errors"
+	"testing"
+)
+
+type address struct {
+	City    string `illogical:"city" rule:"town"`
+	Country string `json:"country"`
+}
+
+type customer struct {
+	Name    string            `json:"name"`
+	Address *address          `json:"address"`
+	Tags    []string          `json:"tags"`
+	Labels  map[string]string `json:"labels"`
+	Meta    any               `json:"meta"`
+}
+
+func TestFlatten(t *testing.T) {
+	illogical := New()
+	ctx := map[string]any{
+		"customer": &customer{
+			Name:    "peter",
+			Address: &address{City: "Toronto", Country: "Canada"},
+			Tags:    []string{"vip"},
+			Labels:  map[string]string{"team": "core"},
+			Meta:    map[string]any{"score": 10},
+		},
+	}
+
+	var tests = []struct {
+		input    any
+		expected any
+	}{
+		{[]any{"==", "$customer.name", "peter"}, true},
+		{[]any{"==", "$customer.address.city", "Toronto"}, true},
+		{[]any{"==", "$customer.address.country", "Canada"}, true},
+		{[]any{"==", "$customer.tags[0]", "vip"}, true},
+		{[]any{"==", "$customer.labels.team", "core"}, true},
+		{[]any{">", "$customer.meta.score", 5}, true},
+	}
+
+	flattened, err := Flatten(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		if output, err := illogical.Evaluate(test.input, ctx); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+
+		eval, _ := illogical.Parse(test.input)
+		p, _ := Compile(eval)
+		if output, err := p.Run(flattened); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	invalid := map[string]any{"fn": func() {}}
+	expected := errors.New("unsupported context value, func() at \"fn\"")
+	if _, err := illogical.Evaluate([]any{"==", 1, 1}, invalid); err == nil || err.Error() != expected.Error() || !errors.Is(err, ErrUnsupportedValue) {
+		t.Errorf("input (%v): expected %v, got %v", invalid, expected, err)
+	}
+	if _, _, err := illogical.Simplify([]any{"==", 1, 1}, invalid); !errors.Is(err, ErrUnsupportedValue) {
+		t.Errorf("input (%v): expected %v, got %v", invalid, expected, err)
+	}
+	eval, _ := illogical.Parse([]any{"==", 1, 1})
+	p, _ := Compile(eval)
+	if _, err := p.Run(invalid); !errors.Is(err, ErrUnsupportedValue) {
+		t.Errorf("input (%v): expected %v, got %v", invalid, expected, err)
+	}
+}
+
+func TestWithContextTag(t *testing.T) {
+	illogical := New(WithContextTag("rule"))
+	if output := illogical.ContextTag(); output != "rule" {
+		t.Errorf("expected %v, got %v", "rule", output)
+	}
+	if output := New().ContextTag(); output != "illogical" {
+		t.Errorf("expected %v, got %v", "illogical", output)
+	}
+	ctx := map[string]any{
+		"address": address{City: "Toronto", Country: "Canada"},
+	}
+
+	var tests = []struct {
+		input    any
+		expected any
+	}{
+		{[]any{"==", "$address.town", "Toronto"}, true},
+		{[]any{"==", "$address.country", "Canada"}, true},
+		{[]any{"NIL", "$address.city"}, true},
+	}
+
+	for _, test := range tests {
+		if output, err := illogical.Evaluate(test.input, ctx); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	if output, err := Flatten(ctx, FlattenTag("rule")); err != nil || output["address.town"] != "Toronto" {
+		t.Errorf("input (%v): expected %v, got %v/%v", ctx, "Toronto", output, err)
+	}
+	if output, found, err := NewMapResolver(ctx, FlattenTag("rule")).Lookup("address.town"); output != "Toronto" || !found || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v/%v", ctx, "Toronto", output, found, err)
+	}
+}
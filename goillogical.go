@@ -4,11 +4,17 @@
 package goillogical
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	e "github.com/spaceavocado/goillogical/evaluable"
+	custom "github.com/spaceavocado/goillogical/internal/expression/custom"
 	c "github.com/spaceavocado/goillogical/internal/operand/collection"
 	r "github.com/spaceavocado/goillogical/internal/operand/reference"
 	o "github.com/spaceavocado/goillogical/internal/options"
 	p "github.com/spaceavocado/goillogical/internal/parser"
+	s "github.com/spaceavocado/goillogical/internal/statement"
 )
 
 // Simplify options for reference expression.
@@ -28,18 +34,87 @@ type CollectionSerializeOptions = struct {
 	EscapeCharacter string
 }
 
+// Parse error locating the failing node within the raw expression, i.e. JSON pointer path of
+// the node, raw fragment of the node, expected operator arity and a sentinel cause.
+//
+// Example:
+//
+//	_, err := i.Parse([]any{"AND", []any{"==", 1, 1}, []any{"==", struct{}{}, 1}})
+//
+//	var pe *illogical.ParseError
+//	if errors.As(err, &pe) {
+//		pe.Path // /2/1
+//	}
+//	errors.Is(err, illogical.ErrInvalidOperand) // true
+type ParseError = e.ParseError
+
+// Number of operands accepted by an operator.
+type Arity = e.Arity
+
+// Truth value of the three-valued (Kleene) logic, i.e. a boolean value which could be unknown,
+// see WithThreeValuedLogic.
+type Truth = e.Truth
+
+// Truth values of the three-valued logic.
+const (
+	TruthFalse   = e.TruthFalse
+	TruthTrue    = e.TruthTrue
+	TruthUnknown = e.TruthUnknown
+)
+
+// Sentinel causes of the parse errors.
+var (
+	ErrUnexpectedInput    = e.ErrUnexpectedInput
+	ErrInvalidOperand     = e.ErrInvalidOperand
+	ErrInvalidReference   = e.ErrInvalidReference
+	ErrUnexpectedOperator = e.ErrUnexpectedOperator
+	ErrOperatorArity      = e.ErrOperatorArity
+	ErrInvalidPattern     = e.ErrInvalidPattern
+	ErrInvalidOperator    = e.ErrInvalidOperator
+)
+
+// Sentinel causes of the arithmetic expressions evaluation errors.
+var (
+	ErrNonNumericOperand = e.ErrNonNumericOperand
+	ErrDivisionByZero    = e.ErrDivisionByZero
+)
+
+// Evaluation budget, i.e. the limits of a single evaluation, see EvaluateContext.
+type Budget = e.Budget
+
+// Budget error describing the exceeded limit of the evaluation budget.
+type BudgetError = e.BudgetError
+
+// Sentinel error of the evaluation, the evaluation budget has been exceeded.
+var ErrBudgetExceeded = e.ErrBudgetExceeded
+
+// Sentinel error of the context flattening, the context value could not be flattened, e.g. a
+// function, a channel or a map with non-string keys.
+var ErrUnsupportedValue = e.ErrUnsupportedValue
+
 // Goillogical engine engine providing access to parsing, evaluation and simplification
 // of expressions.
 type Goillogical interface {
 	Evaluate(any, e.Context) (any, error)
+	EvaluateContext(context.Context, any, e.Context) (any, error)
+	EvaluateResolver(any, Resolver) (any, error)
+	EvaluateBatch(any, []e.Context) ([]any, []error)
+	EvaluateStream(any, <-chan e.Context) <-chan Result
 	Parse(any) (e.Evaluable, error)
+	ParseStatement(string) (e.Evaluable, error)
 	Statement(any) (string, error)
 	Simplify(any, e.Context) (any, e.Evaluable, error)
+	SimplifyResolver(any, Resolver) (any, e.Evaluable, error)
+	ContextTag() string
+	ThreeValued() bool
 }
 
 type illogical struct {
-	opts   o.Options
-	parser p.Parser
+	opts      o.Options
+	parser    p.Parser
+	statement s.Parser
+	// Error of the options, e.g. an invalid custom operator, returned on parsing.
+	err error
 }
 
 // Evaluate given raw expression in the given context.
@@ -53,11 +128,94 @@ type illogical struct {
 // i.Evaluate([]any{"==", 5, 5}, ctx)
 // i.Evaluate([]any{"AND", []any{"==", 5, 5}, []any{"==", 10, 10}}, ctx)
 func (i illogical) Evaluate(exp any, ctx e.Context) (any, error) {
-	eval, err := i.parser.Parse(exp)
+	return i.evaluate(exp, ctx, func(eval e.Evaluable, flattened e.Context) (any, error) {
+		return eval.Evaluate(flattened)
+	})
+}
+
+// Evaluate given raw expression in the given data context, honoring the cancellation of the
+// context between the evaluation of the expression nodes, and the evaluation budget, see
+// WithBudget. The budget attached to the context, see evaluable.WithBudget, takes precedence.
+// Returns the context error if the context is done, or a BudgetError, see ErrBudgetExceeded,
+// if the budget has been exceeded.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+//	defer cancel()
+//
+// i.EvaluateContext(ctx, []any{"IN", "$country", "$countries[*]"}, data)
+func (i illogical) EvaluateContext(ctx context.Context, exp any, data e.Context) (any, error) {
+	if _, ok := e.BudgetOf(ctx); !ok {
+		ctx = e.WithBudget(ctx, i.opts.Budget)
+	}
+
+	return i.evaluate(exp, data, func(eval e.Evaluable, flattened e.Context) (any, error) {
+		return e.EvaluateContext(ctx, eval, flattened)
+	})
+}
+
+// Evaluate given raw expression, looking up the references by the given resolver instead of the
+// data context, see Resolver.
+//
+// Example:
+//
+// i.EvaluateResolver([]any{"IN", "premium", "$entitlements[0]"}, illogical.NewCachingResolver(profiles))
+func (i illogical) EvaluateResolver(exp any, r Resolver) (any, error) {
+	eval, err := i.parse(exp)
+	if err != nil {
+		return nil, err
+	}
+	return i.result(e.EvaluateResolver(eval, r))
+}
+
+// Parse and evaluate the expression in the flattened data context, the boolean result is a
+// Truth value with the three-valued logic.
+func (i illogical) evaluate(exp any, ctx e.Context, evaluate func(e.Evaluable, e.Context) (any, error)) (any, error) {
+	eval, err := i.parse(exp)
 	if err != nil {
 		return nil, err
 	}
-	return eval.Evaluate(e.FlattenContext(ctx))
+
+	flattened, err := e.Flatten(ctx, e.FlattenTag(i.opts.ContextTag))
+	if err != nil {
+		return nil, err
+	}
+
+	return i.result(evaluate(eval, flattened))
+}
+
+// Record the first error of the options.
+func (i *illogical) fail(err error) {
+	if i.err == nil {
+		i.err = err
+	}
+}
+
+// Parse the raw expression, unless the options are invalid, e.g. an invalid custom operator.
+func (i illogical) parse(exp any) (e.Evaluable, error) {
+	if i.err != nil {
+		return nil, i.err
+	}
+	return i.parser.Parse(exp)
+}
+
+// Struct field tag the data context is flattened by, see WithContextTag.
+func (i illogical) ContextTag() string {
+	return i.opts.ContextTag
+}
+
+// Evaluation with the three-valued logic, see WithThreeValuedLogic.
+func (i illogical) ThreeValued() bool {
+	return i.opts.ThreeValued
+}
+
+// Evaluation result, the boolean result is a Truth value with the three-valued logic.
+func (i illogical) result(res any, err error) (any, error) {
+	if b, ok := res.(bool); ok && i.opts.ThreeValued {
+		return e.TruthOf(b), err
+	}
+	return res, err
 }
 
 // Parse given raw expression in an Evaluable object., i.e. it returns the parsed
@@ -70,7 +228,34 @@ func (i illogical) Evaluate(exp any, ctx e.Context) (any, error) {
 // e.Evaluate(map[string]any{"name": "peter"}) // true
 // e.String() // ({name} == "peter")
 func (i illogical) Parse(exp any) (e.Evaluable, error) {
-	return i.parser.Parse(exp)
+	return i.parse(exp)
+}
+
+// Parse given expression statement, i.e. the string representation of an expression, into an
+// Evaluable object. This is the reverse operation of Statement, i.e. the serialized expression is
+// kept, except for the value types the statement has no literal for:
+//   - time values, parsed back as RFC 3339 strings,
+//   - json.Number values, parsed back as int or float64 values, e.g. json.Number("5.0") is 5.0,
+//   - sized integers, e.g. int8 or uint, parsed back as int, or uint64 beyond the int range,
+//   - float32 values, parsed back as float64 values, and NaN or infinite floats, not parsed.
+//
+// The parsed values evaluate the same, i.e. times are compared with RFC 3339 strings by their
+// instant, and numbers by their mathematical value.
+//
+// Example:
+//
+// e, err := i.ParseStatement(`(({a} == 5) AND ({b} <in> [1, 2]))`)
+//
+// e.Serialize() // [AND [== $a 5] [IN $b [1 2]]]
+func (i illogical) ParseStatement(statement string) (e.Evaluable, error) {
+	if i.err != nil {
+		return nil, i.err
+	}
+	exp, err := i.statement.Parse(statement)
+	if err != nil {
+		return nil, err
+	}
+	return i.parse(exp)
 }
 
 // Get expression string representation.
@@ -80,7 +265,7 @@ func (i illogical) Parse(exp any) (e.Evaluable, error) {
 // i.Statement([]any{"==", 5, 5}) // (5 == 5)
 // i.Statement([]any{"AND", []any{"==", 5, 5}, []any{"==", 10, 10}}) // ((5 == 5) AND (10 == 10))
 func (i illogical) Statement(exp any) (string, error) {
-	e, err := i.parser.Parse(exp)
+	e, err := i.parse(exp)
 	if err != nil {
 		return "", err
 	}
@@ -97,12 +282,29 @@ func (i illogical) Statement(exp any) (string, error) {
 // e.Simplify(map[string]any{"a": 10}) // ({b} == 20)
 // e.simplify(map[string]any{"a": 20}) // false
 func (i illogical) Simplify(exp any, ctx e.Context) (any, e.Evaluable, error) {
-	eval, err := i.parser.Parse(exp)
+	eval, err := i.parse(exp)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	val, eval := eval.Simplify(e.FlattenContext(ctx))
+	flattened, err := e.Flatten(ctx, e.FlattenTag(i.opts.ContextTag))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	val, eval := eval.Simplify(flattened)
+	return val, eval, nil
+}
+
+// Simplify an expression, looking up the references by the given resolver instead of the data
+// context, see Simplify and Resolver.
+func (i illogical) SimplifyResolver(exp any, r Resolver) (any, e.Evaluable, error) {
+	eval, err := i.parse(exp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	val, eval := e.SimplifyResolver(eval, r)
 	return val, eval, nil
 }
 
@@ -150,6 +352,7 @@ func WithCollectionSerializeOptions(o CollectionSerializeOptions) Option {
 //	referenceSimplifyOptions := illogical.WithReferenceSimplifyOptions(illogical.SimplifyOptions{
 //		IgnoredPaths   []string
 //		IgnoredPathsRx []regexp.Regexp
+//		UseDefaults    bool
 //	})
 //
 // i := illogical.New(referenceSimplifyOptions)
@@ -189,9 +392,32 @@ func WithReferenceSimplifyOptions(o SimplifyOptions) Option {
 //		e.Nin: "NOT IN",
 //		e.Prefix: "PREFIX",
 //		e.Suffix: "SUFFIX",
+//		e.Matches: "MATCHES",
+//		e.IMatches: "IMATCHES",
+//		e.Contains: "CONTAINS",
+//		e.NotContains: "NOT CONTAINS",
+//		e.Between: "BETWEEN",
+//		e.BetweenExclusive: "BETWEEN EXCLUSIVE",
+//		e.Before: "BEFORE",
+//		e.After: "AFTER",
 //		e.Overlap:, "OVERLAP",
 //		e.Nil: "NIL",
 //		e.Present: "PRESENT",
+//		// Arithmetic
+//		e.Add: "+",
+//		e.Sub: "-",
+//		e.Mul: "*",
+//		e.Div: "/",
+//		e.Mod: "%",
+//		e.Abs: "ABS",
+//		e.Min: "MIN",
+//		e.Max: "MAX",
+//		e.Round: "ROUND",
+//		// Quantifier
+//		e.Any: "ANY",
+//		e.All: "ALL",
+//		e.None: "NONE",
+//		e.Count: "COUNT",
 //		// Logical
 //		e.And: "AND",
 //		e.Or: "OR",
@@ -205,9 +431,217 @@ func WithOperatorMappingOptions(m e.OperatorMapping) Option {
 	}
 }
 
+// Custom operator evaluation handler, given the evaluated operands.
+type OperatorHandler = custom.Handler
+
+// Custom operator simplification handler, given the simplified operands and the flags whether
+// the given operand has been resolved. Returns the simplified value and whether the expression
+// has been simplified into the value.
+type OperatorSimplify = custom.Simplify
+
+// Illogical with a custom operator. The operator is parsed as any other built-in operator, i.e.
+// ["NAME", operand 1, ..., operand N], where the operands could be values, references,
+// collections or nested expressions. The expression is serialized back into its name, and
+// represented as (operand 1 NAME operand 2, ..., operand N) string. The operator name must be a
+// single word, e.g. "WITHIN", not reserved by the statement syntax, i.e. "AND", "OR", "NOR",
+// "XOR", "NOT", "ABS", "MIN", "MAX", "ROUND", "true" or "false", and the operator arity must
+// accept at least 1 operand, otherwise the parsing fails with ErrInvalidOperator. A custom
+// operator overrides a built-in operator of the same name.
+//
+// By default, the expression is simplified into a value only if all of its operands are
+// resolved, the optional simplify handler overrides this behavior.
+//
+// Example:
+//
+//	between := illogical.WithOperator("BETWEEN", illogical.Arity{Min: 3, Max: 3}, func(operands []any) (any, error) {
+//		v, ok1 := operands[0].(int)
+//		min, ok2 := operands[1].(int)
+//		max, ok3 := operands[2].(int)
+//		return ok1 && ok2 && ok3 && v >= min && v <= max, nil
+//	})
+//
+// i := illogical.New(between)
+//
+// i.Evaluate([]any{"BETWEEN", "$age", 18, 65}, map[string]any{"age": 21}) // true
+// i.Statement([]any{"BETWEEN", "$age", 18, 65}) // ({age} BETWEEN 18, 65)
+func WithOperator(name string, arity Arity, eval OperatorHandler, simplify ...OperatorSimplify) Option {
+	return func(i *illogical) {
+		if !s.IsOperatorName(name) {
+			i.fail(fmt.Errorf("%w, \"%s\" could not be expressed in the statement, must be a single non-reserved word", e.ErrInvalidOperator, name))
+			return
+		}
+		if arity.Min < 1 {
+			i.fail(fmt.Errorf("%w, \"%s\" must accept at least 1 operand, got arity %s", e.ErrInvalidOperator, name, arity))
+			return
+		}
+		operator := custom.Operator{Arity: arity, Handler: eval}
+		if len(simplify) > 0 {
+			operator.Simplify = simplify[0]
+		}
+		i.opts.Operators[name] = operator
+	}
+}
+
+// Illogical with strict parsing, i.e. an expression with a known operator given an invalid
+// operands, or an operator-like head, i.e. a near miss of an operator name, e.g. ["=<", 1, 1], or an
+// upper-case word followed by a reference or a nested expression, e.g. ["EQQ", "$a", 1], is
+// reported as an error instead of being silently reinterpreted as a collection. Collections
+// starting with an operator-like value must be escaped, see CollectionSerializeOptions.
+//
+// Example:
+//
+// i := illogical.New(illogical.WithStrictParsing())
+//
+// i.Parse([]any{"AND", []any{"==", 1}}) // invalid number of operands, "==" expects 2 operand(s), got 1
+// i.Parse([]any{"EQQ", "$a", 1}) // unexpected logical operator "EQQ"
+func WithStrictParsing() Option {
+	return func(i *illogical) {
+		i.opts.Strict = true
+	}
+}
+
+// Illogical with the three-valued (Kleene) logic, i.e. a comparison of a reference which could
+// not be found, e.g. ["==", "$x", 1], is evaluated as unknown rather than as false, and the
+// logical expressions and the quantifiers follow the Kleene logic, e.g. NOT unknown is unknown,
+// false AND unknown is false. The NIL and PRESENT comparisons remain definitive. The boolean
+// results are evaluated into a Truth value. Simplification is not affected.
+//
+// Example:
+//
+// i := illogical.New(illogical.WithThreeValuedLogic())
+//
+// i.Evaluate([]any{"NOT", []any{"==", "$x", 1}}, map[string]any{}) // TruthUnknown
+// i.Evaluate([]any{"OR", []any{"==", "$x", 1}, []any{"NIL", "$x"}}, map[string]any{}) // TruthTrue
+func WithThreeValuedLogic() Option {
+	return func(i *illogical) {
+		i.opts.ThreeValued = true
+	}
+}
+
+// Illogical with the evaluation budget of EvaluateContext, i.e. the maximal number of the
+// evaluated expression nodes, and the maximal size of an evaluated collection. A zero limit is
+// unlimited.
+//
+// Example:
+//
+// i := illogical.New(illogical.WithBudget(illogical.Budget{MaxNodes: 1000, MaxCollectionSize: 100}))
+//
+// _, err := i.EvaluateContext(ctx, []any{"IN", "$country", "$countries[*]"}, data)
+// errors.Is(err, illogical.ErrBudgetExceeded) // true, if more than 100 countries
+func WithBudget(budget Budget) Option {
+	return func(i *illogical) {
+		i.opts.Budget = budget
+	}
+}
+
+// Illogical with a custom number of the workers evaluating an expression concurrently in the
+// data contexts of EvaluateBatch and EvaluateStream. Defaults to runtime.GOMAXPROCS(0).
+//
+// Example:
+//
+// i := illogical.New(illogical.WithWorkers(4))
+//
+// i.EvaluateBatch([]any{">", "$age", 18}, contexts)
+func WithWorkers(n int) Option {
+	return func(i *illogical) {
+		i.opts.Workers = n
+	}
+}
+
+// Illogical with a custom struct field tag used to name the struct fields of the evaluation
+// data context, the json tag is used as a fallback. Defaults to "illogical".
+//
+// Example:
+//
+//	type User struct {
+//		Name string `rule:"name"`
+//	}
+//
+// i := illogical.New(illogical.WithContextTag("rule"))
+//
+// i.Evaluate([]any{"==", "$user.name", "peter"}, map[string]any{"user": User{"peter"}}) // true
+func WithContextTag(tag string) Option {
+	return func(i *illogical) {
+		i.opts.ContextTag = tag
+	}
+}
+
+// Illogical with a custom alias the elements of a collection are bound to while evaluating the
+// predicate of a quantifier, i.e. ANY, ALL, NONE and COUNT. Defaults to "", i.e. an element is
+// referenced as "$." and its fields as "$.field".
+//
+// Example:
+//
+// i := illogical.New(illogical.WithQuantifierAlias("item"))
+//
+// i.Evaluate([]any{"ANY", "$items", []any{">", "$item.price", 40}}, map[string]any{"items": []any{map[string]any{"price": 50}}}) // true
+func WithQuantifierAlias(alias string) Option {
+	return func(i *illogical) {
+		i.opts.QuantifierAlias = alias
+	}
+}
+
+// Illogical with a custom clock resolving the NOW reference, i.e. "$NOW", at the evaluation time.
+// Defaults to time.Now.
+//
+// Example:
+//
+// i := illogical.New(illogical.WithClock(func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }))
+//
+// i.Evaluate([]any{"BEFORE", "$signup", "$NOW"}, map[string]any{"signup": time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)}) // true
+func WithClock(clock func() time.Time) Option {
+	return func(i *illogical) {
+		i.opts.Time.Clock = clock
+	}
+}
+
+// Illogical with additional layouts used to parse a string cast to Date, e.g. "$signup.(Date)".
+// The RFC 3339 date, i.e. "2006-01-02", and date-time layouts are always accepted.
+//
+// Example:
+//
+// i := illogical.New(illogical.WithDateLayouts("02/01/2006"))
+//
+// i.Evaluate([]any{"==", "$signup.(Date)", "$NOW.(Date)"}, map[string]any{"signup": "01/06/2024"}) // signed up today, see WithLocation
+func WithDateLayouts(layouts ...string) Option {
+	return func(i *illogical) {
+		i.opts.Time.DateLayouts = layouts
+	}
+}
+
+// Illogical with additional layouts used to parse a string cast to DateTime, e.g.
+// "$ordered.(DateTime)". The RFC 3339 layout is always accepted.
+//
+// Example:
+//
+// i := illogical.New(illogical.WithDateTimeLayouts(time.RFC1123))
+//
+// i.Evaluate([]any{"AFTER", "$ordered.(DateTime)", "2024-01-01"}, map[string]any{"ordered": "Tue, 02 Jan 2024 15:04:05 UTC"}) // true
+func WithDateTimeLayouts(layouts ...string) Option {
+	return func(i *illogical) {
+		i.opts.Time.DateTimeLayouts = layouts
+	}
+}
+
+// Illogical with the location of the calendar days of the Date cast, e.g. "$signup.(Date)", and
+// of the Date and DateTime layouts without a time zone. The times, e.g. the NOW reference in the
+// local time of the clock, are converted into the location before being truncated to the
+// midnight. Defaults to UTC.
+//
+// Example:
+//
+// i := illogical.New(illogical.WithLocation(toronto))
+//
+// i.Evaluate([]any{"==", "$signup.(Date)", "2024-05-05"}, map[string]any{"signup": "2024-05-06T02:00:00Z"}) // true
+func WithLocation(loc *time.Location) Option {
+	return func(i *illogical) {
+		i.opts.Time.Location = loc
+	}
+}
+
 // Create new instance of the (go)illogical
 func New(opts ...Option) Goillogical {
-	i := &illogical{o.DefaultOptions(), nil}
+	i := &illogical{o.DefaultOptions(), nil, nil, nil}
 
 	for _, opt := range opts {
 		opt(i)
@@ -216,7 +650,11 @@ func New(opts ...Option) Goillogical {
 	for _, op := range i.opts.OperatorMapping {
 		i.opts.Serialize.Collection.EscapedOperators[op] = true
 	}
+	for op := range i.opts.Operators {
+		i.opts.Serialize.Collection.EscapedOperators[op] = true
+	}
 
 	i.parser = p.New(&i.opts)
+	i.statement = s.New(&i.opts)
 	return i
 }
@@ -1,12 +1,19 @@
 package goillogical
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"regexp"
 
-	. "github.com/spaceavocado/goillogical/evaluable"
+	e "github.com/spaceavocado/goillogical/evaluable"
 	eq "github.com/spaceavocado/goillogical/internal/expression/comparison/eq"
 	and "github.com/spaceavocado/goillogical/internal/expression/logical/and"
 	. "github.com/spaceavocado/goillogical/internal/mock"
@@ -47,7 +54,7 @@ func TestEvaluate(t *testing.T) {
 	}{
 		{nil, errors.New("unexpected input")},
 		{struct{ int }{4}, errors.New("invalid operand, {4}")},
-		{[]any{"==", struct{ int }{4}, 1}, errors.New("invalid operand, {4}")},
+		{[]any{"==", struct{ int }{4}, 1}, errors.New("invalid operand, {4}, at \"/1\"")},
 	}
 
 	for _, test := range errs {
@@ -57,12 +64,48 @@ func TestEvaluate(t *testing.T) {
 	}
 }
 
+func TestEvaluateNumeric(t *testing.T) {
+	illogical := New()
+
+	var ctx map[string]any
+	decoder := json.NewDecoder(strings.NewReader(`{"price": 10.5, "qty": 3, "id": 18446744073709551615}`))
+	decoder.UseNumber()
+	if err := decoder.Decode(&ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		input    any
+		expected any
+	}{
+		{[]any{">", "$price", 10}, true},
+		{[]any{"<=", "$qty", 3.0}, true},
+		{[]any{"==", "$qty", uint8(3)}, true},
+		{[]any{"==", "$id", uint64(18446744073709551615)}, true},
+		{[]any{"==", "$id", float64(18446744073709551615)}, false},
+		{[]any{"IN", "$qty", []any{1.0, 3.0}}, true},
+		{[]any{"==", json.Number("10.50"), "$price"}, true},
+		{[]any{"==", "$price.(Number)", 10.5}, true},
+		{[]any{"==", "$qty.(Integer)", 3}, true},
+		{[]any{"==", "$qty.(Float)", 3.0}, true},
+		{[]any{"==", "$user.Age.(Number)", 21}, true},
+	}
+
+	ctx["user"] = struct{ Age int64 }{21}
+
+	for _, test := range tests {
+		if output, err := illogical.Evaluate(test.input, ctx); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+}
+
 func TestParse(t *testing.T) {
 	illogical := New()
 
 	var tests = []struct {
 		input    any
-		expected Evaluable
+		expected e.Evaluable
 	}{
 		{1, Val(1)},
 		{true, Val(true)},
@@ -85,7 +128,7 @@ func TestParse(t *testing.T) {
 	}{
 		{nil, errors.New("unexpected input")},
 		{struct{ int }{4}, errors.New("invalid operand, {4}")},
-		{[]any{"==", struct{ int }{4}, 1}, errors.New("invalid operand, {4}")},
+		{[]any{"==", struct{ int }{4}, 1}, errors.New("invalid operand, {4}, at \"/1\"")},
 	}
 
 	for _, test := range errs {
@@ -123,7 +166,7 @@ func TestStatement(t *testing.T) {
 	}{
 		{nil, errors.New("unexpected input")},
 		{struct{ int }{4}, errors.New("invalid operand, {4}")},
-		{[]any{"==", struct{ int }{4}, 1}, errors.New("invalid operand, {4}")},
+		{[]any{"==", struct{ int }{4}, 1}, errors.New("invalid operand, {4}, at \"/1\"")},
 	}
 
 	for _, test := range errs {
@@ -133,17 +176,429 @@ func TestStatement(t *testing.T) {
 	}
 }
 
+func TestEvaluateTime(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	illogical := New(
+		WithClock(func() time.Time { return now }),
+		WithDateLayouts("02/01/2006"),
+		WithDateTimeLayouts(time.RFC1123),
+	)
+	ctx := map[string]any{
+		"signup":  time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+		"renewal": "01/06/2024",
+		"ordered": "Sat, 01 Jun 2024 10:00:00 UTC",
+		"user":    struct{ Joined time.Time }{time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	var tests = []struct {
+		input    any
+		expected any
+	}{
+		{[]any{"==", "$signup", "2024-01-02T15:04:05Z"}, true},
+		{[]any{"==", "$signup", "2024-01-02T10:04:05-05:00"}, true},
+		{[]any{"==", "$signup.(Date)", "2024-01-02"}, true},
+		{[]any{"<", "$signup", "$NOW"}, true},
+		{[]any{">", "$signup", "2024-01-03"}, false},
+		{[]any{"BETWEEN", "$signup", "2024-01-01", "2024-01-31"}, true},
+		{[]any{"BETWEEN", "$user.Joined", "$signup", "$NOW"}, false},
+		{[]any{"BEFORE", "$user.Joined", "$signup"}, true},
+		{[]any{"AFTER", "$NOW", "$signup"}, true},
+		{[]any{"==", "$renewal.(Date)", "$NOW.(Date)"}, true},
+		{[]any{"BEFORE", "$ordered.(DateTime)", "$NOW"}, true},
+		{[]any{"BEFORE", "$ordered", "$NOW"}, false},
+	}
+
+	for _, test := range tests {
+		if output, err := illogical.Evaluate(test.input, ctx); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	if output, err := illogical.Evaluate("$NOW", nil); output != now || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", "$NOW", now, output, err)
+	}
+
+	if _, err := illogical.Evaluate("$renewal.(DateTime)", ctx); err == nil {
+		t.Errorf("input (%v): expected error, got %v", "$renewal.(DateTime)", err)
+	}
+}
+
+func TestEvaluateTimeLocation(t *testing.T) {
+	toronto := time.FixedZone("EDT", -4*60*60)
+	local := time.Local
+	time.Local = toronto
+	defer func() { time.Local = local }()
+
+	// 22:00 in Toronto, i.e. the next day in UTC.
+	clock := func() time.Time { return time.Date(2024, 5, 5, 22, 0, 0, 0, time.Local) }
+	ctx := map[string]any{
+		"signup": "2024-05-06T01:00:00Z",
+		"utc":    "2024-05-05T10:00:00Z",
+		"offset": "2024-05-05T08:00:00+05:00",
+	}
+
+	var tests = []struct {
+		illogical Goillogical
+		input     any
+		expected  any
+	}{
+		{New(WithClock(clock)), []any{"==", "$signup.(Date)", "$NOW.(Date)"}, true},
+		{New(WithClock(clock)), []any{"==", "$NOW.(Date)", "2024-05-06"}, true},
+		{New(WithClock(clock)), []any{"==", "$utc.(Date)", "$offset.(Date)"}, true},
+		{New(WithClock(clock), WithLocation(toronto)), []any{"==", "$signup.(Date)", "$NOW.(Date)"}, true},
+		{New(WithClock(clock), WithLocation(toronto)), []any{"==", "$NOW.(Date)", "2024-05-05"}, true},
+		{New(WithClock(clock), WithLocation(toronto)), []any{"==", "$utc.(Date)", "$offset.(Date)"}, false},
+	}
+
+	for _, test := range tests {
+		if output, err := test.illogical.Evaluate(test.input, ctx); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+}
+
+func TestEvaluateArithmetic(t *testing.T) {
+	illogical := New()
+	ctx := map[string]any{
+		"total":    120.5,
+		"discount": 20,
+		"age":      21,
+		"months":   250,
+		"qty":      uint8(3),
+	}
+
+	var tests = []struct {
+		input    any
+		expected any
+	}{
+		{[]any{"-", "$total", "$discount"}, 100.5},
+		{[]any{">", []any{"-", "$total", "$discount"}, 100}, true},
+		{[]any{">=", []any{"*", "$age", 12}, "$months"}, true},
+		{[]any{"==", []any{"/", "$months", "$qty"}, 83.33333333333333}, true},
+		{[]any{"==", []any{"ROUND", []any{"/", "$months", "$qty"}, 2}, 83.33}, true},
+		{[]any{"==", []any{"%", "$age", 2}, 1}, true},
+		{[]any{"MAX", []any{"ABS", -30}, "$age", "$qty"}, 30},
+		{[]any{"IN", []any{"MIN", "$age", 18}, []any{18, 65}}, true},
+		{[]any{"+", "$missing", 1}, nil},
+		{[]any{">", []any{"+", "$missing", 1}, 0}, false},
+		{[]any{"OR", []any{">", []any{"+", "$missing", 1}, 0}, []any{"==", "$age", 21}}, true},
+		{[]any{"IN", 1, []any{"-", 1, 2}}, false},
+		{[]any{"IN", 1, []any{"\\-", 1, 2}}, true},
+	}
+
+	for _, test := range tests {
+		if output, err := illogical.Evaluate(test.input, ctx); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	var errs = []struct {
+		input    any
+		expected error
+	}{
+		{[]any{"/", "$total", []any{"-", "$age", 21}}, ErrDivisionByZero},
+		{[]any{"%", "$age", 0}, ErrDivisionByZero},
+		{[]any{">", []any{"+", "$total", "1"}, 0}, ErrNonNumericOperand},
+		{[]any{"AND", []any{"+", 1, 1}, true}, errors.New("invalid evaluated operand, must be boolean value")},
+	}
+
+	for _, test := range errs {
+		if _, err := illogical.Evaluate(test.input, ctx); err == nil || (!errors.Is(err, test.expected) && err.Error() != test.expected.Error()) {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, err)
+		}
+	}
+
+	var simplified = []struct {
+		input    any
+		value    any
+		expected string
+	}{
+		{[]any{"+", []any{"*", 2, 3}, 1}, 7, "null"},
+		{[]any{">", []any{"-", "$total", "$discount"}, 100}, true, "null"},
+		{[]any{"+", "$missing", []any{"*", "$age", 2}}, nil, "({missing} + 42)"},
+		{[]any{"/", "$age", 0}, nil, "(21 / 0)"},
+	}
+
+	// Arithmetic of a missing reference is unknown with the three-valued logic.
+	if output, err := New(WithThreeValuedLogic()).Evaluate([]any{">", []any{"+", "$missing", 1}, 0}, ctx); output != TruthUnknown || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", "+", TruthUnknown, output, err)
+	}
+
+	for _, test := range simplified {
+		if value, self, err := illogical.Simplify(test.input, ctx); value != test.value || Fprint(self) != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v/%v", test.input, test.value, test.expected, value, self, err)
+		}
+	}
+}
+
+func TestEvaluateQuantifier(t *testing.T) {
+	type Item struct {
+		Price int      `illogical:"price"`
+		Tags  []string `illogical:"tags"`
+	}
+
+	illogical := New()
+	ctx := map[string]any{
+		"items": []Item{{5, []string{"a"}}, {50, []string{"a", "b"}}},
+		"limit": 10,
+	}
+
+	var tests = []struct {
+		input    any
+		expected any
+	}{
+		{[]any{"ANY", "$items", []any{">", "$.price", 40}}, true},
+		{[]any{"ALL", "$items", []any{">", "$.price", "$limit"}}, false},
+		{[]any{"NONE", "$items", []any{"==", "$.price", 0}}, true},
+		{[]any{"COUNT", "$items", []any{">", "$.price", "$limit"}}, 1},
+		{[]any{">=", []any{"COUNT", "$items"}, 2}, true},
+		{[]any{"ANY", "$items", []any{"ANY", "$.tags", []any{"==", "$.", "b"}}}, true},
+		{[]any{"ALL", []any{1, 2, "$limit"}, []any{"<=", "$.", "$limit"}}, true},
+		{[]any{"ANY", "$missing", []any{"==", "$.", 1}}, false},
+		{[]any{"ALL", "$missing", []any{"==", "$.", 1}}, true},
+	}
+
+	for _, test := range tests {
+		if output, err := illogical.Evaluate(test.input, ctx); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	illogical = New(WithQuantifierAlias("item"))
+	input := []any{"ANY", "$items", []any{"AND", []any{">", "$item.price", 40}, []any{"ANY", "$item.tags", []any{"==", "$item", "c"}}}}
+	if output, err := illogical.Evaluate(input, ctx); output != false || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", input, false, output, err)
+	}
+	input = []any{"ANY", "$items", []any{">", "$item.price", 40}}
+	if output, err := illogical.Evaluate(input, ctx); output != true || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", input, true, output, err)
+	}
+
+	var simplified = []struct {
+		input    any
+		ctx      map[string]any
+		value    any
+		expected string
+	}{
+		{[]any{"ANY", "$items", []any{">", "$.price", 40}}, ctx, true, "null"},
+		{[]any{"COUNT", "$items"}, ctx, 2, "null"},
+		{[]any{"ALL", "$items", []any{">", "$.price", "$min"}}, ctx, nil, "({items} <all> ({.price} > {min}))"},
+		{[]any{"ANY", "$items", []any{">", "$.price", 40}}, map[string]any{}, nil, "({items} <any> ({.price} > 40))"},
+	}
+
+	illogical = New()
+	// Arithmetic of a missing reference is unknown with the three-valued logic.
+	if output, err := New(WithThreeValuedLogic()).Evaluate([]any{">", []any{"+", "$missing", 1}, 0}, ctx); output != TruthUnknown || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", "+", TruthUnknown, output, err)
+	}
+
+	for _, test := range simplified {
+		if value, self, err := illogical.Simplify(test.input, test.ctx); value != test.value || Fprint(self) != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v/%v", test.input, test.value, test.expected, value, self, err)
+		}
+	}
+}
+
+func TestEvaluatePaths(t *testing.T) {
+	illogical := New()
+	ctx := map[string]any{
+		"tags": []any{
+			map[string]any{"name": "sale"},
+			map[string]any{"name": "new"},
+		},
+		"items":  []any{5, 10, 15, 20},
+		"orders": map[string]any{"a": map[string]any{"total": 10}, "b": map[string]any{"total": 30}},
+		"last":   -1,
+	}
+
+	var tests = []struct {
+		input    any
+		expected any
+	}{
+		{"$tags[*].name", []any{"sale", "new"}},
+		{[]any{"IN", "sale", "$tags[*].name"}, true},
+		{[]any{"IN", "old", "$tags[*].name"}, false},
+		{[]any{"OVERLAP", "$tags[*].name", []any{"new", "old"}}, true},
+		{[]any{"CONTAINS", "$tags[*].name", "new"}, true},
+		{[]any{"==", "$items[-1]", 20}, true},
+		{[]any{"==", "$items[{last}]", 20}, true},
+		{[]any{"IN", 5, "$items[1:3]"}, false},
+		{[]any{"IN", 15, "$items[1:3]"}, true},
+		{[]any{"IN", 30, "$orders..total"}, true},
+		{[]any{"ANY", "$items[:2]", []any{">", "$.", 5}}, true},
+		{[]any{"ALL", "$tags[*].name", []any{"!=", "$.", "old"}}, true},
+	}
+
+	for _, test := range tests {
+		if output, err := illogical.Evaluate(test.input, ctx); Fprint(output) != Fprint(test.expected) || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	if output, err := illogical.Statement([]any{"IN", "sale", "$tags[*].name"}); output != "(\"sale\" <in> {tags[*].name})" || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", "$tags[*].name", "(\"sale\" <in> {tags[*].name})", output, err)
+	}
+}
+
+func TestEvaluateDefaults(t *testing.T) {
+	illogical := New()
+	ctx := map[string]any{
+		"points":  10,
+		"country": nil,
+	}
+
+	var tests = []struct {
+		input    any
+		expected any
+	}{
+		{"$loyalty_points|0", 0},
+		{"$points|0", 10},
+		{"$country|\"CA\"", "CA"},
+		{[]any{">=", "$loyalty_points|0", 0}, true},
+		{[]any{">=", "$loyalty_points", 0}, false},
+		{[]any{"==", "$country|\"CA\"", "CA"}, true},
+		{[]any{"+", "$points|0", "$bonus|0"}, 10},
+		{[]any{"NIL", "$loyalty_points|0"}, false},
+		{[]any{"==", "$loyalty_points.(String)|0", "0"}, true},
+	}
+
+	for _, test := range tests {
+		if output, err := illogical.Evaluate(test.input, ctx); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	if output, err := illogical.Statement([]any{"==", "$country|\"CA\"", "CA"}); output != "({country|\"CA\"} == \"CA\")" || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", "$country|\"CA\"", "({country|\"CA\"} == \"CA\")", output, err)
+	}
+
+	if _, err := illogical.Parse("$country|CA"); !errors.Is(err, ErrInvalidReference) {
+		t.Errorf("input (%v): expected %v, got %v", "$country|CA", ErrInvalidReference, err)
+	}
+}
+
+func TestWithThreeValuedLogic(t *testing.T) {
+	illogical := New(WithThreeValuedLogic())
+	ctx := map[string]any{
+		"role": "admin",
+		"age":  21,
+	}
+
+	var tests = []struct {
+		input    any
+		expected any
+	}{
+		{[]any{"==", "$role", "admin"}, TruthTrue},
+		{[]any{"==", "$x", 1}, TruthUnknown},
+		{[]any{"NOT", []any{"==", "$x", 1}}, TruthUnknown},
+		{[]any{"AND", []any{"==", "$x", 1}, []any{"==", "$role", "guest"}}, TruthFalse},
+		{[]any{"AND", []any{"==", "$x", 1}, []any{"==", "$role", "admin"}}, TruthUnknown},
+		{[]any{"OR", []any{"==", "$x", 1}, []any{"==", "$role", "admin"}}, TruthTrue},
+		{[]any{"OR", []any{"==", "$x", 1}, []any{"NIL", "$x"}}, TruthTrue},
+		{[]any{"PRESENT", "$x"}, TruthFalse},
+		{[]any{"XOR", []any{"==", "$x", 1}, []any{"==", "$age", 21}}, TruthUnknown},
+		{[]any{"==", "$x|1", 1}, TruthTrue},
+		{[]any{"ANY", []any{1, "$x"}, []any{"==", "$.", 1}}, TruthTrue},
+		{[]any{"ALL", []any{1, "$x"}, []any{"==", "$.", 1}}, TruthUnknown},
+		{[]any{"+", "$age", 1}, 22},
+		{"$role", "admin"},
+	}
+
+	for _, test := range tests {
+		if output, err := illogical.Evaluate(test.input, ctx); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	// Boolean logic by default
+	if output, err := New().Evaluate([]any{"NOT", []any{"==", "$x", 1}}, ctx); output != true || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", "NOT ($x == 1)", true, output, err)
+	}
+
+	// Simplification is not affected
+	if value, eval, err := illogical.Simplify([]any{"NOT", []any{"==", "$x", 1}}, ctx); value != nil || Fprint(eval) != "(NOT ({x} == 1))" || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v/%v", "NOT ($x == 1)", "(NOT ({x} == 1))", value, eval, err)
+	}
+}
+
+func TestEvaluateContext(t *testing.T) {
+	ctx := map[string]any{
+		"country":   "CA",
+		"countries": []any{"US", "MX", "CA"},
+		"items": []any{
+			map[string]any{"price": 10},
+			map[string]any{"price": 50},
+		},
+		"many": slices.Repeat([]any{map[string]any{"price": 1}}, 1000),
+	}
+
+	var tests = []struct {
+		budget   Budget
+		input    any
+		expected any
+		err      error
+	}{
+		{Budget{}, []any{"IN", "$country", "$countries[*]"}, true, nil},
+		{Budget{MaxNodes: 3}, []any{"IN", "$country", "$countries[*]"}, true, nil},
+		{Budget{MaxNodes: 2}, []any{"IN", "$country", "$countries[*]"}, nil, &BudgetError{Limit: "nodes", Max: 2}},
+		{Budget{MaxCollectionSize: 3}, []any{"IN", "$country", "$countries[*]"}, true, nil},
+		{Budget{MaxCollectionSize: 2}, []any{"IN", "$country", "$countries[*]"}, nil, &BudgetError{Limit: "collection size", Max: 2}},
+		{Budget{MaxCollectionSize: 2}, []any{"IN", "$country", []any{"US", "MX", "CA"}}, nil, &BudgetError{Limit: "collection size", Max: 2}},
+		{Budget{MaxNodes: 7}, []any{"ANY", "$items", []any{">", "$.price", 40}}, true, nil},
+		{Budget{MaxNodes: 6}, []any{"ANY", "$items", []any{">", "$.price", 40}}, nil, &BudgetError{Limit: "nodes", Max: 6}},
+		{Budget{MaxNodes: 4}, []any{"AND", false, []any{"==", "$country", "CA"}}, false, nil},
+		{Budget{MaxCollectionSize: 2}, []any{"COUNT", "$items"}, 2, nil},
+		{Budget{MaxCollectionSize: 10}, []any{"COUNT", "$many"}, nil, &BudgetError{Limit: "collection size", Max: 10}},
+		{Budget{MaxCollectionSize: 10}, []any{"ANY", "$many", []any{">", "$.price", 0}}, nil, &BudgetError{Limit: "collection size", Max: 10}},
+		{Budget{MaxCollectionSize: 10}, []any{"ALL", []any{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, []any{">", "$", 0}}, nil, &BudgetError{Limit: "collection size", Max: 10}},
+	}
+
+	for _, test := range tests {
+		illogical := New(WithBudget(test.budget))
+		output, err := illogical.EvaluateContext(context.Background(), test.input, ctx)
+		if output != test.expected && test.err == nil || Fprint(err) != Fprint(test.err) {
+			t.Errorf("input (%v, %v): expected %v/%v, got %v/%v", test.input, test.budget, test.expected, test.err, output, err)
+		}
+		if test.err != nil && !errors.Is(err, ErrBudgetExceeded) {
+			t.Errorf("input (%v, %v): expected %v, got %v", test.input, test.budget, ErrBudgetExceeded, err)
+		}
+	}
+
+	// Budget attached to the context takes precedence.
+	illogical := New(WithBudget(Budget{MaxNodes: 1}))
+	if output, err := illogical.EvaluateContext(e.WithBudget(context.Background(), Budget{}), []any{"IN", "$country", "$countries[*]"}, ctx); output != true || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", "IN", true, output, err)
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := New().EvaluateContext(cancelled, []any{"==", 1, 1}, ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("input (%v): expected %v, got %v", "==", context.Canceled, err)
+	}
+
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	if _, err := New().EvaluateContext(expired, []any{"==", 1, 1}, ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("input (%v): expected %v, got %v", "==", context.DeadlineExceeded, err)
+	}
+
+	if output, err := New(WithThreeValuedLogic()).EvaluateContext(context.Background(), []any{"==", "$x", 1}, ctx); output != TruthUnknown || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", "==", TruthUnknown, output, err)
+	}
+}
+
 func TestSimplify(t *testing.T) {
 	opts := r.DefaultSerializeOptions()
 	serOpts := r.DefaultSerializeOptions()
+	timeOpts := r.DefaultTimeOptions()
 	simOpts := SimplifyOptions{
 		IgnoredPaths:   []string{"ignored"},
 		IgnoredPathsRx: []regexp.Regexp{},
 	}
 	illogical := New(WithReferenceSerializeOptions(opts), WithReferenceSimplifyOptions(simOpts))
 
-	ref := func(val string) Evaluable {
-		e, _ := r.New(val, &serOpts, &simOpts)
+	ref := func(val string) e.Evaluable {
+		e, _ := r.New(val, &serOpts, &simOpts, &timeOpts)
 		return e
 	}
 
@@ -166,6 +621,8 @@ func TestSimplify(t *testing.T) {
 		{"$refB.refB1", 2, nil},
 		{"$refC.{refJ}", nil, ref("refC.{refJ}")},
 		{[]any{"==", 1, 1}, true, nil},
+		{"$refJ|0", nil, ref("refJ|0")},
+		{"$refA|0", 1, nil},
 	}
 
 	for _, test := range tests {
@@ -174,6 +631,13 @@ func TestSimplify(t *testing.T) {
 		}
 	}
 
+	// Default values used while simplified
+	simOpts.UseDefaults = true
+	illogical = New(WithReferenceSimplifyOptions(simOpts))
+	if value, eval, err := illogical.Simplify([]any{"AND", []any{">=", "$refJ|0", 0}, "$refX"}, ctx); Fprint(eval) != "{refX}" || value != nil || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v/%v", "$refJ|0", "{refX}", value, eval, err)
+	}
+
 	var errs = []struct {
 		input    any
 		expected error
@@ -189,7 +653,7 @@ func TestSimplify(t *testing.T) {
 }
 
 func TestWithOperatorMappingOptions(t *testing.T) {
-	illogical := New(WithOperatorMappingOptions(map[Kind]string{Eq: "IS"}))
+	illogical := New(WithOperatorMappingOptions(map[e.Kind]string{e.Eq: "IS"}))
 	ctx := map[string]any{
 		"refA": "resolvedA",
 	}
@@ -246,3 +710,228 @@ func TestSerialize(t *testing.T) {
 		}
 	}
 }
+
+func TestParseStatement(t *testing.T) {
+	illogical := New()
+
+	var tests = []any{
+		1,
+		1.5,
+		-2,
+		float64(1),
+		float64(-2),
+		1e21,
+		uint64(math.MaxUint64),
+		true,
+		"val",
+		"say \"hi\"",
+		"$refA",
+		"$refA.{refB}.(Number)",
+		"$refA|0",
+		"$refA.(Number)|-1.5",
+		[]any{"==", "$refA|\"a|b\"", "$refB.{refC}|true"},
+		"$a}b",
+		"$a{b",
+		"$a\\b",
+		"$refA|\"x}\"",
+		"$refA|\"{x}\"",
+		"${a.{b}}",
+		[]any{">=", "$age", float64(18)},
+		[]any{1, "val", "$refA"},
+		[]any{"\\==", 1, 1},
+		[]any{"==", "$refA", 1},
+		[]any{"!=", "$refA", "val"},
+		[]any{">", "$refA", 1},
+		[]any{">=", "$refA", 1.5},
+		[]any{"<", "$refA", -1},
+		[]any{"<=", "$refA", 1},
+		[]any{"IN", "$refA", []any{1, 2}},
+		[]any{"NOT IN", []any{"a", "$refB"}, "$refA"},
+		[]any{"OVERLAP", []any{1, 2}, []any{2, 3}},
+		[]any{"PREFIX", "pre", "$refA"},
+		[]any{"SUFFIX", "$refA", "fix"},
+		[]any{"MATCHES", "$refA", `^\w+@example\.com$`},
+		[]any{"IMATCHES", "$refA", "$refB"},
+		[]any{"CONTAINS", "$refA", "val"},
+		[]any{"NOT CONTAINS", []any{1, 2}, "$refA"},
+		[]any{"BETWEEN", "$refA", 1, 10},
+		[]any{"BETWEEN EXCLUSIVE", "$refA", "a", "$refB"},
+		[]any{"BEFORE", "$refA.(DateTime)", "2024-01-02"},
+		[]any{"AFTER", "$refA.(Date)", "$NOW"},
+		[]any{">", []any{"-", "$refA", "$refB"}, 100},
+		[]any{"-", []any{"-", "$refA", 1}, []any{"-", 2, "$refB"}},
+		[]any{"+", []any{"*", "$refA", 12}, []any{"/", []any{"%", "$refB", 2}, -1.5}},
+		[]any{"ABS", []any{"-", "$refA", "$refB"}},
+		[]any{"MIN", "$refA", 1, []any{"MAX", 2, "$refB"}},
+		[]any{"ROUND", []any{"/", "$refA", 3}, 2},
+		[]any{"IN", []any{"ROUND", "$refA"}, []any{1, []any{"+", "$refB", 1}}},
+		[]any{"ANY", "$refA", []any{">", "$.price", 40}},
+		[]any{"ALL", "$refA", []any{"NONE", "$.tags", []any{"==", "$.", "$refB"}}},
+		[]any{">", []any{"COUNT", "$refA"}, []any{"COUNT", "$refB", "$.active"}},
+		[]any{"NIL", "$refA"},
+		[]any{"PRESENT", "$refA"},
+		[]any{"AND", []any{"==", 1, 1}, []any{"!=", 2, 1}, true},
+		[]any{"OR", []any{"==", 1, 1}, []any{"NIL", "$refA"}},
+		[]any{"NOR", []any{"==", 1, 1}, []any{"==", 2, 1}},
+		[]any{"XOR", []any{"==", 1, 1}, []any{"AND", true, []any{"NOT", false}}},
+		[]any{"NOT", []any{"==", 1, 1}},
+	}
+
+	for _, test := range tests {
+		expected, _ := illogical.Parse(test)
+		if output, err := illogical.ParseStatement(expected.String()); err != nil || !reflect.DeepEqual(output.Serialize(), expected.Serialize()) {
+			t.Errorf("input (%v): expected %v, got %v/%v", expected.String(), Fprint(expected.Serialize()), output, err)
+		}
+	}
+
+	// Round-tripping stops at the time values, parsed back as RFC 3339 strings, the json.Number
+	// values, parsed back as int or float64 values, and the sized numbers, parsed back as int,
+	// uint64 or float64 values, all evaluating the same.
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	var lossy = []struct {
+		input    any
+		expected any
+	}{
+		{[]any{"==", "$refA", at}, []any{"==", "$refA", "2024-01-02T03:04:05Z"}},
+		{[]any{"==", "$refA", json.Number("5.0")}, []any{"==", "$refA", 5.0}},
+		{[]any{"==", "$refA", json.Number("5")}, []any{"==", "$refA", 5}},
+		{[]any{"==", "$refA", int8(5)}, []any{"==", "$refA", 5}},
+		{[]any{"==", "$refA", int32(5)}, []any{"==", "$refA", 5}},
+		{[]any{"==", "$refA", uint(5)}, []any{"==", "$refA", 5}},
+		{[]any{"==", "$refA", float32(1.5)}, []any{"==", "$refA", 1.5}},
+	}
+
+	ctx := map[string]any{"refA": at}
+	for _, test := range lossy {
+		eval, _ := illogical.Parse(test.input)
+		output, err := illogical.ParseStatement(eval.String())
+		if err != nil || !reflect.DeepEqual(output.Serialize(), test.expected) {
+			t.Errorf("input (%v): expected %v, got %v/%v", eval.String(), test.expected, output, err)
+			continue
+		}
+		expected, _ := eval.Evaluate(ctx)
+		if res, err := output.Evaluate(ctx); res != expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", eval.String(), expected, res, err)
+		}
+	}
+
+	var errs = []struct {
+		input    string
+		expected error
+	}{
+		{"", errors.New("unexpected end of statement at position 0")},
+		{"{refA} <bogus> 1", errors.New("unexpected \"<bogus>\" at position 7")},
+	}
+
+	for _, test := range errs {
+		if _, err := illogical.ParseStatement(test.input); err == nil || err.Error() != test.expected.Error() {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, err)
+		}
+	}
+}
+
+func TestWithStrictParsing(t *testing.T) {
+	illogical := New(WithStrictParsing())
+
+	if output, err := illogical.Evaluate([]any{"IN", "$country", []any{"CA", "US"}}, map[string]any{"country": "CA"}); output != true || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", []any{"IN", "$country", []any{"CA", "US"}}, true, output, err)
+	}
+
+	var errs = []struct {
+		input    any
+		expected error
+	}{
+		{[]any{"AND", []any{"==", 1}, true}, ErrOperatorArity},
+		{[]any{"ANDD", "$a", true}, ErrUnexpectedOperator},
+		{[]any{"EQQ", "$a", 1}, ErrUnexpectedOperator},
+	}
+
+	for _, test := range errs {
+		if _, err := illogical.Parse(test.input); !errors.Is(err, test.expected) {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, err)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	illogical := New()
+
+	_, err := illogical.Parse([]any{"AND", []any{"==", 1, 1}, []any{"==", struct{}{}, 1}})
+
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Path != "/2/1" || !errors.Is(err, ErrInvalidOperand) {
+		t.Errorf("expected %v, got %v", "/2/1", err)
+	}
+}
+
+func TestWithOperator(t *testing.T) {
+	between := func(operands []any) (any, error) {
+		v, ok1 := operands[0].(int)
+		min, ok2 := operands[1].(int)
+		max, ok3 := operands[2].(int)
+		if !ok1 || !ok2 || !ok3 {
+			return false, nil
+		}
+		return v >= min && v <= max, nil
+	}
+	illogical := New(WithOperator("WITHIN", Arity{Min: 3, Max: 3}, between), WithStrictParsing())
+	ctx := map[string]any{
+		"age": 21,
+	}
+
+	var tests = []struct {
+		input     any
+		expected  any
+		statement string
+	}{
+		{[]any{"WITHIN", "$age", 18, 65}, true, "({age} WITHIN 18, 65)"},
+		{[]any{"WITHIN", "$age", 30, 65}, false, "({age} WITHIN 30, 65)"},
+		{[]any{"AND", []any{"WITHIN", "$age", 18, 65}, []any{"==", 1, 1}}, true, "(({age} WITHIN 18, 65) AND (1 == 1))"},
+		{[]any{"\\WITHIN", 1, 2}, []any{"WITHIN", 1, 2}, "[\"WITHIN\", 1, 2]"},
+	}
+
+	for _, test := range tests {
+		if output, err := illogical.Evaluate(test.input, ctx); Fprint(output) != Fprint(test.expected) || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+
+		eval, _ := illogical.Parse(test.input)
+		if output := eval.String(); output != test.statement {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.statement, output)
+		}
+		if output := eval.Serialize(); Fprint(output) != Fprint(test.input) {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.input, output)
+		}
+		if output, err := illogical.ParseStatement(test.statement); err != nil || !reflect.DeepEqual(output.Serialize(), test.input) {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.statement, test.input, output, err)
+		}
+	}
+
+	if value, eval, err := illogical.Simplify([]any{"WITHIN", "$age", 18, "$max"}, ctx); value != nil || Fprint(eval) != "({age} WITHIN 18, {max})" || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v/%v", []any{"WITHIN", "$age", 18, "$max"}, "({age} WITHIN 18, {max})", value, eval, err)
+	}
+
+	if _, err := illogical.Parse([]any{"WITHIN", "$age", 18}); !errors.Is(err, ErrOperatorArity) {
+		t.Errorf("input (%v): expected %v, got %v", []any{"WITHIN", "$age", 18}, ErrOperatorArity, err)
+	}
+	var invalid = []struct {
+		name  string
+		arity Arity
+	}{
+		{"NEAR BY", Arity{Min: 2, Max: 2}},
+		{"~=", Arity{Min: 2, Max: 2}},
+		{"AND", Arity{Min: 2, Max: 2}},
+		{"true", Arity{Min: 1, Max: 1}},
+		{"RAND", Arity{Min: 0, Max: 0}},
+	}
+
+	for _, test := range invalid {
+		illogical := New(WithOperator(test.name, test.arity, between))
+		if _, err := illogical.Parse([]any{test.name, 1, 2}); !errors.Is(err, ErrInvalidOperator) {
+			t.Errorf("input (%v): expected %v, got %v", test.name, ErrInvalidOperator, err)
+		}
+		if _, err := illogical.ParseStatement("(1 == 1)"); !errors.Is(err, ErrInvalidOperator) {
+			t.Errorf("input (%v): expected %v, got %v", test.name, ErrInvalidOperator, err)
+		}
+	}
+}
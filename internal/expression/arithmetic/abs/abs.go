@@ -0,0 +1,14 @@
+package abs
+
+import (
+	e "github.com/spaceavocado/goillogical/evaluable"
+	a "github.com/spaceavocado/goillogical/internal/expression/arithmetic"
+)
+
+func handler(evaluated []any) (any, error) {
+	return a.Absolute(evaluated[0])
+}
+
+func New(operator string, operand e.Evaluable) (e.Evaluable, error) {
+	return a.NewFunction(e.Abs, operator, "ABS", []e.Evaluable{operand}, handler)
+}
@@ -0,0 +1,57 @@
+package abs
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/spaceavocado/goillogical/evaluable"
+	. "github.com/spaceavocado/goillogical/internal/mock"
+	. "github.com/spaceavocado/goillogical/internal/test"
+)
+
+func TestHandler(t *testing.T) {
+	var tests = []struct {
+		operand  Evaluable
+		expected any
+	}{
+		{Val(-1), 1},
+		{Val(1), 1},
+		{Val(-1.5), 1.5},
+		{Val(uint8(2)), 2},
+		{Ref("Missing"), nil},
+	}
+
+	for _, test := range tests {
+		a, _ := New("ABS", test.operand)
+		if output, err := a.Evaluate(map[string]any{}); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.operand.String(), test.expected, output, err)
+		}
+	}
+
+	for _, operand := range []Evaluable{Val("1"), Col(Val(1))} {
+		a, _ := New("ABS", operand)
+		if _, err := a.Evaluate(map[string]any{}); !errors.Is(err, ErrNonNumericOperand) {
+			t.Errorf("input (%v): expected %v, got %v", operand.String(), ErrNonNumericOperand, err)
+		}
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	ctx := map[string]any{"RefA": -10}
+
+	var tests = []struct {
+		operand  Evaluable
+		value    any
+		expected string
+	}{
+		{Ref("RefA"), 10, "null"},
+		{Ref("Missing"), nil, "(ABS {Missing})"},
+	}
+
+	for _, test := range tests {
+		a, _ := New("ABS", test.operand)
+		if value, self := a.Simplify(ctx); value != test.value || Fprint(self) != test.expected {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v", test.operand, test.value, test.expected, value, self)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package add
+
+import (
+	e "github.com/spaceavocado/goillogical/evaluable"
+	a "github.com/spaceavocado/goillogical/internal/expression/arithmetic"
+)
+
+func handler(evaluated []any) (any, error) {
+	return a.Sum(evaluated[0], evaluated[1])
+}
+
+func New(operator string, left e.Evaluable, right e.Evaluable) (e.Evaluable, error) {
+	return a.New(e.Add, operator, "+", []e.Evaluable{left, right}, handler)
+}
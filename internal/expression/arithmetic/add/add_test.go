@@ -0,0 +1,66 @@
+package add
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/spaceavocado/goillogical/evaluable"
+	. "github.com/spaceavocado/goillogical/internal/mock"
+	. "github.com/spaceavocado/goillogical/internal/test"
+)
+
+func TestHandler(t *testing.T) {
+	var tests = []struct {
+		left     Evaluable
+		right    Evaluable
+		expected any
+	}{
+		{Val(1), Val(2), 3},
+		{Val(1), Val(0.5), 1.5},
+		{Val(uint8(250)), Val(int8(10)), 260},
+		{Ref("Missing"), Val(1), nil},
+	}
+
+	for _, test := range tests {
+		a, _ := New("+", test.left, test.right)
+		if output, err := a.Evaluate(map[string]any{}); output != test.expected || err != nil {
+			t.Errorf("input (%v, %v): expected %v, got %v/%v", test.left.String(), test.right.String(), test.expected, output, err)
+		}
+	}
+
+	var errs = []struct {
+		left     Evaluable
+		right    Evaluable
+		expected error
+	}{
+		{Val("1"), Val(1), ErrNonNumericOperand},
+	}
+
+	for _, test := range errs {
+		a, _ := New("+", test.left, test.right)
+		if _, err := a.Evaluate(map[string]any{}); !errors.Is(err, test.expected) {
+			t.Errorf("input (%v, %v): expected %v, got %v", test.left.String(), test.right.String(), test.expected, err)
+		}
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	ctx := map[string]any{"RefA": 10}
+
+	var tests = []struct {
+		left     Evaluable
+		right    Evaluable
+		value    any
+		expected string
+	}{
+		{Ref("RefA"), Val(4), 14, "null"},
+		{Ref("Missing"), Val(4), nil, `({Missing} + 4)`},
+	}
+
+	for _, test := range tests {
+		a, _ := New("+", test.left, test.right)
+		if value, self := a.Simplify(ctx); value != test.value || Fprint(self) != test.expected {
+			t.Errorf("input (%v, %v): expected %v/%v, got %v/%v", test.left, test.right, test.value, test.expected, value, self)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package arithmetic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	e "github.com/spaceavocado/goillogical/evaluable"
+	value "github.com/spaceavocado/goillogical/internal/operand/value"
+)
+
+// Arithmetic operator evaluation handler, given the evaluated operands.
+type Handler func([]any) (any, error)
+
+type arithmetic struct {
+	kind     e.Kind
+	op       string
+	operator string
+	operands []e.Evaluable
+	handler  Handler
+	function bool
+}
+
+// Arithmetic result of the evaluated operands, nil if any of the operands is nil, e.g. a
+// reference which could not be found, i.e. the result is unknown rather than an error.
+func (a arithmetic) result(evaluated []any) (any, error) {
+	for _, val := range evaluated {
+		if val == nil {
+			return nil, nil
+		}
+	}
+	return a.handler(evaluated)
+}
+
+func (a arithmetic) Evaluate(ctx e.Context) (any, error) {
+	var flattenContext = e.FlattenContext(ctx)
+
+	evaluated := make([]any, len(a.operands))
+	for i, o := range a.operands {
+		val, err := o.Evaluate(flattenContext)
+		if err != nil {
+			return nil, err
+		}
+		evaluated[i] = val
+	}
+	return a.result(evaluated)
+}
+
+func (a arithmetic) EvaluateContext(ctx context.Context, data e.Context) (any, error) {
+	return e.Guard(ctx, func(ctx context.Context) (any, error) {
+		evaluated, err := e.EvaluateOperands(ctx, a.operands, e.FlattenContext(data))
+		if err != nil {
+			return nil, err
+		}
+		return a.result(evaluated)
+	})
+}
+
+func (a arithmetic) Compile() (e.Func, error) {
+	operands, err := e.CompileFuncs(a.operands)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx e.Context) (any, error) {
+		evaluated := make([]any, len(operands))
+		for i, fn := range operands {
+			val, err := fn(ctx)
+			if err != nil {
+				return nil, err
+			}
+			evaluated[i] = val
+		}
+		return a.result(evaluated)
+	}, nil
+}
+
+func (a arithmetic) Explain(ctx e.Context) (e.Trace, error) {
+	evaluated, operands, err := e.ExplainOperands(e.FlattenContext(ctx), a.operands)
+	if err != nil {
+		return e.NewTrace(a.kind, a, nil, err, operands), err
+	}
+	res, err := a.result(evaluated)
+	return e.NewTrace(a.kind, a, res, err, operands), err
+}
+
+func (a arithmetic) Kind() e.Kind {
+	return a.kind
+}
+
+func (a arithmetic) Operator() string {
+	return a.op
+}
+
+func (a arithmetic) Operands() []e.Evaluable {
+	return a.operands
+}
+
+func (a arithmetic) WithOperands(operands []e.Evaluable) e.Evaluable {
+	a.operands = operands
+	return a
+}
+
+func (a arithmetic) Serialize() any {
+	res := []any{a.op}
+	for i := 0; i < len(a.operands); i++ {
+		res = append(res, a.operands[i].Serialize())
+	}
+	return res
+}
+
+// Constant folding, i.e. the expression is simplified into a value if all of its operands are
+// resolved, otherwise the resolved operands are folded into values. An expression failing to
+// evaluate, e.g. a division by zero, is kept to be reported by the evaluation.
+func (a arithmetic) Simplify(ctx e.Context) (any, e.Evaluable) {
+	var flattenContext = e.FlattenContext(ctx)
+
+	values := make([]any, len(a.operands))
+	operands := make([]e.Evaluable, len(a.operands))
+	all := true
+	for i, o := range a.operands {
+		val, eval := o.Simplify(flattenContext)
+		values[i] = val
+		operands[i] = eval
+		if eval != nil {
+			all = false
+			continue
+		}
+		if folded, err := value.New(val); err == nil {
+			operands[i] = folded
+		} else {
+			operands[i] = o
+		}
+	}
+
+	if all {
+		if res, err := a.result(values); err == nil {
+			return res, nil
+		}
+	}
+
+	a.operands = operands
+	return nil, &a
+}
+
+func (a arithmetic) String() string {
+	operands := make([]string, len(a.operands))
+	for i, o := range a.operands {
+		operands[i] = o.String()
+	}
+
+	if a.function {
+		return fmt.Sprintf("(%s %s)", a.operator, strings.Join(operands, ", "))
+	}
+	return fmt.Sprintf("(%s)", strings.Join(operands, fmt.Sprintf(" %s ", a.operator)))
+}
+
+// Create new arithmetic expression rendered with the infix operator, e.g. ({a} + 1).
+func New(kind e.Kind, op string, operator string, operands []e.Evaluable, handler Handler) (e.Evaluable, error) {
+	return arithmetic{kind: kind, op: op, operator: operator, operands: operands, handler: handler}, nil
+}
+
+// Create new arithmetic expression rendered as a function, e.g. (MIN {a}, 1).
+func NewFunction(kind e.Kind, op string, operator string, operands []e.Evaluable, handler Handler) (e.Evaluable, error) {
+	return arithmetic{kind: kind, op: op, operator: operator, operands: operands, handler: handler, function: true}, nil
+}
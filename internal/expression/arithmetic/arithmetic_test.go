@@ -0,0 +1,179 @@
+package arithmetic
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/spaceavocado/goillogical/evaluable"
+	. "github.com/spaceavocado/goillogical/internal/mock"
+	. "github.com/spaceavocado/goillogical/internal/test"
+)
+
+func sum(evaluated []any) (any, error) {
+	return Sum(evaluated[0], evaluated[1])
+}
+
+func TestEvaluate(t *testing.T) {
+	var tests = []struct {
+		operands []Evaluable
+		expected any
+	}{
+		{[]Evaluable{Val(1), Val(2)}, 3},
+		{[]Evaluable{Val(1), Ref("RefA")}, 11},
+		{[]Evaluable{Val(1), Ref("Missing")}, nil},
+	}
+
+	for _, test := range tests {
+		a, _ := New(Add, "+", "+", test.operands, sum)
+		if output, err := a.Evaluate(map[string]any{"RefA": 10}); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.operands, test.expected, output, err)
+		}
+	}
+
+	errs := []struct {
+		operands []Evaluable
+		expected error
+	}{
+		{[]Evaluable{Val(1), Invalid()}, errors.New("invalid")},
+		{[]Evaluable{Val(1), Val("val")}, errors.New("non numeric operand, val")},
+	}
+
+	for _, test := range errs {
+		a, _ := New(Add, "+", "+", test.operands, sum)
+		if _, err := a.Evaluate(map[string]any{}); err == nil || err.Error() != test.expected.Error() {
+			t.Errorf("input (%v): expected %v, got %v", test.operands, test.expected, err)
+		}
+	}
+}
+
+func TestCompile(t *testing.T) {
+	var tests = []struct {
+		operands []Evaluable
+		expected any
+	}{
+		{[]Evaluable{Val(1), Ref("RefA")}, 2},
+		{[]Evaluable{Val(1.5), Ref("RefA")}, 2.5},
+	}
+
+	for _, test := range tests {
+		a, _ := New(Add, "+", "+", test.operands, sum)
+		fn, _ := a.(Compilable).Compile()
+		if output, err := fn(FlattenContext(map[string]any{"RefA": 1})); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.operands, test.expected, output, err)
+		}
+	}
+
+	a, _ := New(Add, "+", "+", []Evaluable{Val(1), Invalid()}, sum)
+	fn, _ := a.(Compilable).Compile()
+	if _, err := fn(map[string]any{}); err == nil || err.Error() != "invalid" {
+		t.Errorf("input (%v): expected %v, got %v", a, "invalid", err)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	var tests = []struct {
+		operands []Evaluable
+		expected string
+	}{
+		{[]Evaluable{Ref("RefA"), Val(1)}, "Add: ({RefA} + 1) => 2\n  Reference: {RefA} => 1, at \"RefA\"\n  Value: 1 => 1"},
+		{[]Evaluable{Val("val"), Val(1)}, "Add: (\"val\" + 1) => error: non numeric operand, val\n  Value: \"val\" => \"val\"\n  Value: 1 => 1"},
+		{[]Evaluable{Invalid(), Val(1)}, "Add: (invalid + 1) => error: invalid\n  Unknown: invalid => error: invalid\n  Value: 1 => skipped"},
+	}
+
+	for _, test := range tests {
+		a, _ := New(Add, "+", "+", test.operands, sum)
+		if output, _ := a.(Explainable).Explain(map[string]any{"RefA": 1}); output.String() != test.expected {
+			t.Errorf("input (%v): expected %v, got %v", test.operands, test.expected, output)
+		}
+	}
+}
+
+func TestOperands(t *testing.T) {
+	a, _ := New(Add, "+", "PLUS", []Evaluable{Ref("RefA"), Val(1)}, sum)
+	n := a.(Node)
+	if n.Kind() != Add || n.Operator() != "+" || len(n.Operands()) != 2 {
+		t.Errorf("input (%v): expected Add node, got %v/%v/%v", a, n.Kind(), n.Operator(), n.Operands())
+	}
+
+	rewritten := n.WithOperands([]Evaluable{Ref("RefB"), Val(1)})
+	if output, _ := rewritten.Evaluate(map[string]any{"RefA": 1, "RefB": 2}); output != 3 || rewritten.String() != "({RefB} PLUS 1)" || a.String() != "({RefA} PLUS 1)" {
+		t.Errorf("input (%v): expected ({RefB} PLUS 1), got %v/%v", a, rewritten, output)
+	}
+}
+
+func TestSerialize(t *testing.T) {
+	var tests = []struct {
+		op       string
+		operands []Evaluable
+		expected any
+	}{
+		{"+", []Evaluable{Val(1), Ref("RefA")}, []any{"+", 1, "$RefA"}},
+		{"ABS", []Evaluable{Val(1)}, []any{"ABS", 1}},
+	}
+
+	for _, test := range tests {
+		a, _ := New(Unknown, test.op, test.op, test.operands, sum)
+		if output := a.Serialize(); Fprint(output) != Fprint(test.expected) {
+			t.Errorf("input (%v, %v): expected %v, got %v", test.op, test.operands, test.expected, output)
+		}
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	ctx := map[string]any{
+		"RefA": 10,
+	}
+
+	add := func(operands ...Evaluable) Evaluable {
+		e, _ := New(Add, "+", "+", operands, sum)
+		return e
+	}
+	div := func(operands ...Evaluable) Evaluable {
+		e, _ := New(Div, "/", "/", operands, func(evaluated []any) (any, error) { return Quotient(evaluated[0], evaluated[1]) })
+		return e
+	}
+
+	tests := []struct {
+		input Evaluable
+		value any
+		e     any
+	}{
+		{add(Val(1), Val(2)), 3, nil},
+		{add(Val(1), Ref("RefA")), 11, nil},
+		{add(Val(1), add(Ref("RefA"), Val(2))), 13, nil},
+		{add(Ref("Missing"), Val(1)), nil, add(Ref("Missing"), Val(1))},
+		{add(Ref("Missing"), add(Ref("RefA"), Val(2))), nil, add(Ref("Missing"), Val(12))},
+		{add(Ref("Missing"), Col(Val(1))), nil, add(Ref("Missing"), Col(Val(1)))},
+		{div(Ref("RefA"), Val(0)), nil, div(Val(10), Val(0))},
+	}
+
+	for _, test := range tests {
+		if value, self := test.input.Simplify(ctx); Fprint(value) != Fprint(test.value) || Fprint(self) != Fprint(test.e) {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v", test.input, test.value, test.e, value, self)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	var tests = []struct {
+		function bool
+		operator string
+		operands []Evaluable
+		expected string
+	}{
+		{false, "-", []Evaluable{Ref("a"), Ref("b")}, "({a} - {b})"},
+		{true, "ABS", []Evaluable{Ref("a")}, "(ABS {a})"},
+		{true, "MIN", []Evaluable{Ref("a"), Val(1), Val(2)}, "(MIN {a}, 1, 2)"},
+	}
+
+	for _, test := range tests {
+		factory := New
+		if test.function {
+			factory = NewFunction
+		}
+		a, _ := factory(Unknown, "Unknown", test.operator, test.operands, sum)
+		if output := a.String(); output != test.expected {
+			t.Errorf("input (%v, %v): expected %v, got %v", test.operator, test.operands, test.expected, output)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package div
+
+import (
+	e "github.com/spaceavocado/goillogical/evaluable"
+	a "github.com/spaceavocado/goillogical/internal/expression/arithmetic"
+)
+
+func handler(evaluated []any) (any, error) {
+	return a.Quotient(evaluated[0], evaluated[1])
+}
+
+func New(operator string, left e.Evaluable, right e.Evaluable) (e.Evaluable, error) {
+	return a.New(e.Div, operator, "/", []e.Evaluable{left, right}, handler)
+}
@@ -0,0 +1,18 @@
+package max
+
+import (
+	e "github.com/spaceavocado/goillogical/evaluable"
+	a "github.com/spaceavocado/goillogical/internal/expression/arithmetic"
+)
+
+func handler(evaluated []any) (any, error) {
+	return a.Maximum(evaluated)
+}
+
+func New(operator string, operands []e.Evaluable) (e.Evaluable, error) {
+	if len(operands) < 2 {
+		return nil, e.NewArityError(e.Arity{Min: 2, Max: -1}, "arithmetic MAX expression must have at least 2 operands")
+	}
+
+	return a.NewFunction(e.Max, operator, "MAX", operands, handler)
+}
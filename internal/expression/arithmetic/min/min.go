@@ -0,0 +1,18 @@
+package min
+
+import (
+	e "github.com/spaceavocado/goillogical/evaluable"
+	a "github.com/spaceavocado/goillogical/internal/expression/arithmetic"
+)
+
+func handler(evaluated []any) (any, error) {
+	return a.Minimum(evaluated)
+}
+
+func New(operator string, operands []e.Evaluable) (e.Evaluable, error) {
+	if len(operands) < 2 {
+		return nil, e.NewArityError(e.Arity{Min: 2, Max: -1}, "arithmetic MIN expression must have at least 2 operands")
+	}
+
+	return a.NewFunction(e.Min, operator, "MIN", operands, handler)
+}
@@ -0,0 +1,60 @@
+package min
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/spaceavocado/goillogical/evaluable"
+	. "github.com/spaceavocado/goillogical/internal/mock"
+	. "github.com/spaceavocado/goillogical/internal/test"
+)
+
+func TestHandler(t *testing.T) {
+	var tests = []struct {
+		operands []Evaluable
+		expected any
+	}{
+		{[]Evaluable{Val(1), Val(2)}, 1},
+		{[]Evaluable{Val(3), Val(-1.5), Val(uint8(2))}, -1.5},
+		{[]Evaluable{Val(1), Ref("Missing")}, nil},
+		{[]Evaluable{Val(10), Val(2), Val(2.0)}, 2},
+	}
+
+	for _, test := range tests {
+		a, _ := New("MIN", test.operands)
+		if output, err := a.Evaluate(map[string]any{}); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.operands, test.expected, output, err)
+		}
+	}
+
+	a, _ := New("MIN", []Evaluable{Val(1), Val("1")})
+	if _, err := a.Evaluate(map[string]any{}); !errors.Is(err, ErrNonNumericOperand) {
+		t.Errorf("input (%v): expected %v, got %v", a, ErrNonNumericOperand, err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("MIN", []Evaluable{Val(1)}); !errors.Is(err, ErrOperatorArity) {
+		t.Errorf("input (%v): expected %v, got %v", []Evaluable{Val(1)}, ErrOperatorArity, err)
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	ctx := map[string]any{"RefA": 10}
+
+	var tests = []struct {
+		operands []Evaluable
+		value    any
+		expected string
+	}{
+		{[]Evaluable{Ref("RefA"), Val(3)}, 3, "null"},
+		{[]Evaluable{Ref("Missing"), Ref("RefA"), Val(3)}, nil, "(MIN {Missing}, 10, 3)"},
+	}
+
+	for _, test := range tests {
+		a, _ := New("MIN", test.operands)
+		if value, self := a.Simplify(ctx); value != test.value || Fprint(self) != test.expected {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v", test.operands, test.value, test.expected, value, self)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package arithmetic
+
+import (
+	"fmt"
+	"math"
+
+	e "github.com/spaceavocado/goillogical/evaluable"
+	num "github.com/spaceavocado/goillogical/internal/number"
+)
+
+// Numeric operand promoted into int64, or float64 if it is a float, or an integer beyond the
+// int64 range.
+type number struct {
+	integer bool
+	i       int64
+	f       float64
+}
+
+func integer(i int64) number {
+	return number{integer: true, i: i}
+}
+
+func float(f float64) number {
+	return number{f: f}
+}
+
+func (n number) float() float64 {
+	if n.integer {
+		return float64(n.i)
+	}
+	return n.f
+}
+
+func (n number) isZero() bool {
+	if n.integer {
+		return n.i == 0
+	}
+	return n.f == 0
+}
+
+// Resulting value of the arithmetic operation, i.e. int, or int64 if it does not fit the int,
+// for integers, and float64 otherwise.
+func (n number) value() any {
+	if !n.integer {
+		return n.f
+	}
+	if int64(int(n.i)) == n.i {
+		return int(n.i)
+	}
+	return n.i
+}
+
+func fromUnsigned(u uint64) number {
+	if u > math.MaxInt64 {
+		return float(float64(u))
+	}
+	return integer(int64(u))
+}
+
+// Promote the operand into a number, see number.Of.
+func toNumber(val any) (number, error) {
+	n, ok := num.Of(val)
+	if !ok {
+		return number{}, fmt.Errorf("%w, %v", e.ErrNonNumericOperand, val)
+	}
+	return fromNumber(n), nil
+}
+
+func fromNumber(n num.Number) number {
+	switch n.Kind {
+	case num.Signed:
+		return integer(n.Int)
+	case num.Unsigned:
+		return fromUnsigned(n.Uint)
+	default:
+		return float(n.Float)
+	}
+}
+
+func toNumbers(left any, right any) (number, number, error) {
+	a, err := toNumber(left)
+	if err != nil {
+		return number{}, number{}, err
+	}
+	b, err := toNumber(right)
+	if err != nil {
+		return number{}, number{}, err
+	}
+	return a, b, nil
+}
+
+// Sum of two numbers of any numeric type, i.e. int8-int64, uint8-uint64, float32, float64 or
+// json.Number. Integers are summed as integers, unless the sum overflows the int64 range.
+func Sum(left any, right any) (any, error) {
+	a, b, err := toNumbers(left, right)
+	if err != nil {
+		return nil, err
+	}
+	if a.integer && b.integer {
+		res := a.i + b.i
+		if (b.i > 0 && res > a.i) || (b.i <= 0 && res <= a.i) {
+			return integer(res).value(), nil
+		}
+	}
+	return float(a.float() + b.float()).value(), nil
+}
+
+// Difference of two numbers of any numeric type, see Sum.
+func Difference(left any, right any) (any, error) {
+	a, b, err := toNumbers(left, right)
+	if err != nil {
+		return nil, err
+	}
+	if a.integer && b.integer {
+		res := a.i - b.i
+		if (b.i > 0 && res < a.i) || (b.i <= 0 && res >= a.i) {
+			return integer(res).value(), nil
+		}
+	}
+	return float(a.float() - b.float()).value(), nil
+}
+
+// Product of two numbers of any numeric type, see Sum.
+func Product(left any, right any) (any, error) {
+	a, b, err := toNumbers(left, right)
+	if err != nil {
+		return nil, err
+	}
+	if a.integer && b.integer {
+		if a.i == 0 || b.i == 0 {
+			return 0, nil
+		}
+		res := a.i * b.i
+		if res/b.i == a.i && !(a.i == -1 && b.i == math.MinInt64) && !(b.i == -1 && a.i == math.MinInt64) {
+			return integer(res).value(), nil
+		}
+	}
+	return float(a.float() * b.float()).value(), nil
+}
+
+// Quotient of two numbers of any numeric type. The quotient of integers is an integer only if
+// the division is exact, e.g. 6 / 3 is 2, while 7 / 2 is 3.5.
+func Quotient(left any, right any) (any, error) {
+	a, b, err := toNumbers(left, right)
+	if err != nil {
+		return nil, err
+	}
+	if b.isZero() {
+		return nil, fmt.Errorf("%w, %v / %v", e.ErrDivisionByZero, left, right)
+	}
+	if a.integer && b.integer && a.i%b.i == 0 && !(a.i == math.MinInt64 && b.i == -1) {
+		return integer(a.i / b.i).value(), nil
+	}
+	return float(a.float() / b.float()).value(), nil
+}
+
+// Remainder of the division of two numbers of any numeric type, having the sign of the
+// dividend, e.g. -7 % 3 is -1.
+func Remainder(left any, right any) (any, error) {
+	a, b, err := toNumbers(left, right)
+	if err != nil {
+		return nil, err
+	}
+	if b.isZero() {
+		return nil, fmt.Errorf("%w, %v %% %v", e.ErrDivisionByZero, left, right)
+	}
+	if a.integer && b.integer {
+		if b.i == -1 {
+			return 0, nil
+		}
+		return integer(a.i % b.i).value(), nil
+	}
+	return float(math.Mod(a.float(), b.float())).value(), nil
+}
+
+// Absolute value of a number of any numeric type.
+func Absolute(val any) (any, error) {
+	n, err := toNumber(val)
+	if err != nil {
+		return nil, err
+	}
+	if !n.integer {
+		return math.Abs(n.f), nil
+	}
+	if n.i == math.MinInt64 {
+		return -float64(n.i), nil
+	}
+	if n.i < 0 {
+		return integer(-n.i).value(), nil
+	}
+	return n.value(), nil
+}
+
+// Select the number of the given numbers by the given order, NaN if any of the numbers is NaN.
+func selectNumber(values []any, order int) (any, error) {
+	var res num.Number
+	for i, val := range values {
+		n, ok := num.Of(val)
+		if !ok {
+			return nil, fmt.Errorf("%w, %v", e.ErrNonNumericOperand, val)
+		}
+		if n.IsNaN() {
+			return math.NaN(), nil
+		}
+		if i == 0 || num.Compare(n, res) == order {
+			res = n
+		}
+	}
+	return fromNumber(res).value(), nil
+}
+
+// The smallest of the numbers of any numeric type.
+func Minimum(values []any) (any, error) {
+	return selectNumber(values, -1)
+}
+
+// The largest of the numbers of any numeric type.
+func Maximum(values []any) (any, error) {
+	return selectNumber(values, 1)
+}
+
+// Number of any numeric type rounded half away from zero to the given number of decimal
+// places, a negative precision rounds to the tens, hundreds, etc., e.g. 1250 rounded to -2
+// decimal places is 1300.
+func Rounded(val any, precision int) (any, error) {
+	n, err := toNumber(val)
+	if err != nil {
+		return nil, err
+	}
+	if n.integer && precision >= 0 {
+		return n.value(), nil
+	}
+
+	var res float64
+	if precision >= 0 {
+		p := math.Pow10(precision)
+		res = math.Round(n.float()*p) / p
+	} else {
+		p := math.Pow10(-precision)
+		res = math.Round(n.float()/p) * p
+	}
+
+	if n.integer && res >= -(1<<63) && res < 1<<63 {
+		return integer(int64(res)).value(), nil
+	}
+	return res, nil
+}
+
+// Convert a number of any numeric type into an integer, a float must have no fractional part.
+func ToInteger(val any) (int, error) {
+	n, err := toNumber(val)
+	if err != nil {
+		return 0, err
+	}
+	if n.integer {
+		return int(n.i), nil
+	}
+	if n.f != math.Trunc(n.f) || math.Abs(n.f) > math.MaxInt32 {
+		return 0, fmt.Errorf("%w, %v is not an integer", e.ErrNonNumericOperand, val)
+	}
+	return int(n.f), nil
+}
@@ -0,0 +1,217 @@
+package arithmetic
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	e "github.com/spaceavocado/goillogical/evaluable"
+)
+
+type binaryTest struct {
+	a        any
+	b        any
+	expected any
+}
+
+func testBinary(t *testing.T, name string, fn func(any, any) (any, error), tests []binaryTest) {
+	for _, test := range tests {
+		if output, err := fn(test.a, test.b); output != test.expected || err != nil {
+			t.Errorf("%s input (%T(%v), %T(%v)): expected %T(%v), got %T(%v)/%v", name, test.a, test.a, test.b, test.b, test.expected, test.expected, output, output, err)
+		}
+	}
+}
+
+func TestSum(t *testing.T) {
+	testBinary(t, "Sum", Sum, []binaryTest{
+		{1, 2, 3},
+		{int8(1), uint64(2), 3},
+		{1, 0.5, 1.5},
+		{float32(0.5), 0.25, 0.75},
+		{json.Number("10"), 5, 15},
+		{json.Number("0.5"), 1, 1.5},
+		{int64(math.MaxInt64), 1, float64(math.MaxInt64) + 1},
+		{int64(math.MinInt64), -1, float64(math.MinInt64) - 1},
+		{uint64(math.MaxUint64), 0, float64(math.MaxUint64)},
+	})
+}
+
+func TestDifference(t *testing.T) {
+	testBinary(t, "Difference", Difference, []binaryTest{
+		{5, 3, 2},
+		{3, 5, -2},
+		{uint8(3), uint8(5), -2},
+		{1, 0.5, 0.5},
+		{int64(math.MinInt64), 1, float64(math.MinInt64) - 1},
+		{int64(math.MaxInt64), -1, float64(math.MaxInt64) + 1},
+		{0, int64(math.MinInt64), -float64(math.MinInt64)},
+	})
+}
+
+func TestProduct(t *testing.T) {
+	testBinary(t, "Product", Product, []binaryTest{
+		{21, 12, 252},
+		{-2, 3, -6},
+		{0, int64(math.MinInt64), 0},
+		{2, 1.5, 3.0},
+		{int64(math.MaxInt64), 2, float64(math.MaxInt64) * 2},
+		{int64(math.MinInt64), -1, -float64(math.MinInt64)},
+		{-1, int64(math.MinInt64), -float64(math.MinInt64)},
+	})
+}
+
+func TestQuotient(t *testing.T) {
+	testBinary(t, "Quotient", Quotient, []binaryTest{
+		{6, 3, 2},
+		{7, 2, 3.5},
+		{-6, 3, -2},
+		{1.5, 0.5, 3.0},
+		{json.Number("1"), 4, 0.25},
+		{int64(math.MinInt64), -1, -float64(math.MinInt64)},
+	})
+}
+
+func TestRemainder(t *testing.T) {
+	testBinary(t, "Remainder", Remainder, []binaryTest{
+		{7, 3, 1},
+		{-7, 3, -1},
+		{7, -3, 1},
+		{int64(math.MinInt64), -1, 0},
+		{7.5, 2, 1.5},
+	})
+}
+
+func TestArithmeticErrors(t *testing.T) {
+	var tests = []struct {
+		fn       func(any, any) (any, error)
+		a        any
+		b        any
+		expected error
+	}{
+		{Sum, "1", 1, e.ErrNonNumericOperand},
+		{Difference, 1, nil, e.ErrNonNumericOperand},
+		{Product, true, 1, e.ErrNonNumericOperand},
+		{Quotient, 1, []any{1}, e.ErrNonNumericOperand},
+		{Remainder, json.Number("val"), 1, e.ErrNonNumericOperand},
+		{Quotient, 1, 0, e.ErrDivisionByZero},
+		{Quotient, 1.5, 0.0, e.ErrDivisionByZero},
+		{Remainder, 1, uint(0), e.ErrDivisionByZero},
+		{Remainder, 1.5, json.Number("0"), e.ErrDivisionByZero},
+	}
+
+	for _, test := range tests {
+		if output, err := test.fn(test.a, test.b); output != nil || !errors.Is(err, test.expected) {
+			t.Errorf("input (%v, %v): expected %v, got %v/%v", test.a, test.b, test.expected, output, err)
+		}
+	}
+
+	if _, err := Quotient(1, 0); err == nil || err.Error() != "division by zero, 1 / 0" {
+		t.Errorf("input (%v, %v): expected %v, got %v", 1, 0, "division by zero, 1 / 0", err)
+	}
+}
+
+func TestAbsolute(t *testing.T) {
+	var tests = []struct {
+		input    any
+		expected any
+	}{
+		{-1, 1},
+		{1, 1},
+		{int8(-5), 5},
+		{-1.5, 1.5},
+		{json.Number("-2"), 2},
+		{int64(math.MinInt64), -float64(math.MinInt64)},
+	}
+
+	for _, test := range tests {
+		if output, err := Absolute(test.input); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	if _, err := Absolute("1"); !errors.Is(err, e.ErrNonNumericOperand) {
+		t.Errorf("input (%v): expected %v, got %v", "1", e.ErrNonNumericOperand, err)
+	}
+}
+
+func TestMinimumMaximum(t *testing.T) {
+	var tests = []struct {
+		input []any
+		min   any
+		max   any
+	}{
+		{[]any{1, 2}, 1, 2},
+		{[]any{3, 1.5, uint8(2)}, 1.5, 3},
+		{[]any{json.Number("10"), -1}, -1, 10},
+		{[]any{2, 2.0}, 2, 2},
+		{[]any{float64(9007199254740992), int64(9007199254740993)}, float64(9007199254740992), 9007199254740993},
+	}
+
+	for _, test := range tests {
+		if output, err := Minimum(test.input); output != test.min || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.min, output, err)
+		}
+		if output, err := Maximum(test.input); output != test.max || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.max, output, err)
+		}
+	}
+
+	if output, err := Minimum([]any{1, math.NaN()}); !math.IsNaN(output.(float64)) || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", []any{1, math.NaN()}, math.NaN(), output, err)
+	}
+	if _, err := Maximum([]any{1, "2"}); !errors.Is(err, e.ErrNonNumericOperand) {
+		t.Errorf("input (%v): expected %v, got %v", []any{1, "2"}, e.ErrNonNumericOperand, err)
+	}
+}
+
+func TestRounded(t *testing.T) {
+	var tests = []struct {
+		input     any
+		precision int
+		expected  any
+	}{
+		{2.5, 0, 3.0},
+		{-2.5, 0, -3.0},
+		{2.4, 0, 2.0},
+		{1.005, 1, 1.0},
+		{83.3333, 2, 83.33},
+		{7, 0, 7},
+		{7, 2, 7},
+		{1250, -2, 1300},
+		{1249.5, -2, 1200.0},
+		{json.Number("2.75"), 1, 2.8},
+	}
+
+	for _, test := range tests {
+		if output, err := Rounded(test.input, test.precision); output != test.expected || err != nil {
+			t.Errorf("input (%v, %v): expected %T(%v), got %T(%v)/%v", test.input, test.precision, test.expected, test.expected, output, output, err)
+		}
+	}
+
+	if _, err := Rounded(nil, 0); !errors.Is(err, e.ErrNonNumericOperand) {
+		t.Errorf("input (%v): expected %v, got %v", nil, e.ErrNonNumericOperand, err)
+	}
+}
+
+func TestToInteger(t *testing.T) {
+	var tests = []struct {
+		input    any
+		expected int
+		ok       bool
+	}{
+		{2, 2, true},
+		{uint8(2), 2, true},
+		{2.0, 2, true},
+		{json.Number("-1"), -1, true},
+		{2.5, 0, false},
+		{math.Inf(1), 0, false},
+		{"2", 0, false},
+	}
+
+	for _, test := range tests {
+		if output, err := ToInteger(test.input); output != test.expected || (err == nil) != test.ok {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v", test.input, test.expected, test.ok, output, err)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package round
+
+import (
+	e "github.com/spaceavocado/goillogical/evaluable"
+	a "github.com/spaceavocado/goillogical/internal/expression/arithmetic"
+)
+
+// The number is rounded to the given number of decimal places, 0 if not given.
+func handler(evaluated []any) (any, error) {
+	precision := 0
+	if len(evaluated) > 1 {
+		p, err := a.ToInteger(evaluated[1])
+		if err != nil {
+			return nil, err
+		}
+		precision = p
+	}
+	return a.Rounded(evaluated[0], precision)
+}
+
+func New(operator string, operands []e.Evaluable) (e.Evaluable, error) {
+	if len(operands) < 1 || len(operands) > 2 {
+		return nil, e.NewArityError(e.Arity{Min: 1, Max: 2}, "arithmetic ROUND expression must have 1 or 2 operands")
+	}
+
+	return a.NewFunction(e.Round, operator, "ROUND", operands, handler)
+}
@@ -0,0 +1,71 @@
+package round
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/spaceavocado/goillogical/evaluable"
+	. "github.com/spaceavocado/goillogical/internal/mock"
+	. "github.com/spaceavocado/goillogical/internal/test"
+)
+
+func TestHandler(t *testing.T) {
+	var tests = []struct {
+		operands []Evaluable
+		expected any
+	}{
+		{[]Evaluable{Val(2.5)}, 3.0},
+		{[]Evaluable{Val(2)}, 2},
+		{[]Evaluable{Val(83.3333), Val(2)}, 83.33},
+		{[]Evaluable{Val(83.3333), Val(2.0)}, 83.33},
+		{[]Evaluable{Val(1250), Val(-2)}, 1300},
+		{[]Evaluable{Val(2.5), Ref("Missing")}, nil},
+	}
+
+	for _, test := range tests {
+		a, _ := New("ROUND", test.operands)
+		if output, err := a.Evaluate(map[string]any{}); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.operands, test.expected, output, err)
+		}
+	}
+
+	var errs = [][]Evaluable{
+		{Val("2.5")},
+		{Val(2.5), Val(1.5)},
+	}
+
+	for _, operands := range errs {
+		a, _ := New("ROUND", operands)
+		if _, err := a.Evaluate(map[string]any{}); !errors.Is(err, ErrNonNumericOperand) {
+			t.Errorf("input (%v): expected %v, got %v", operands, ErrNonNumericOperand, err)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, operands := range [][]Evaluable{{}, {Val(1), Val(2), Val(3)}} {
+		if _, err := New("ROUND", operands); !errors.Is(err, ErrOperatorArity) {
+			t.Errorf("input (%v): expected %v, got %v", operands, ErrOperatorArity, err)
+		}
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	ctx := map[string]any{"RefA": 2.25}
+
+	var tests = []struct {
+		operands []Evaluable
+		value    any
+		expected string
+	}{
+		{[]Evaluable{Ref("RefA"), Val(1)}, 2.3, "null"},
+		{[]Evaluable{Ref("Missing"), Val(1)}, nil, "(ROUND {Missing}, 1)"},
+	}
+
+	for _, test := range tests {
+		a, _ := New("ROUND", test.operands)
+		if value, self := a.Simplify(ctx); value != test.value || Fprint(self) != test.expected {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v", test.operands, test.value, test.expected, value, self)
+		}
+	}
+}
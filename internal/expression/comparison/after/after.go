@@ -0,0 +1,15 @@
+package after
+
+import (
+	e "github.com/spaceavocado/goillogical/evaluable"
+	c "github.com/spaceavocado/goillogical/internal/expression/comparison"
+)
+
+func handler(evaluated []any) bool {
+	res, ok := c.CompareTimes(evaluated[0], evaluated[1])
+	return ok && res > 0
+}
+
+func New(operator string, left e.Evaluable, right e.Evaluable) (e.Evaluable, error) {
+	return c.New(e.After, operator, "<after>", []e.Evaluable{left, right}, handler)
+}
@@ -0,0 +1,65 @@
+package before
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/spaceavocado/goillogical/evaluable"
+	. "github.com/spaceavocado/goillogical/internal/mock"
+	. "github.com/spaceavocado/goillogical/internal/test"
+)
+
+func TestHandler(t *testing.T) {
+	at := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	later := at.Add(time.Hour)
+
+	var tests = []struct {
+		left     Evaluable
+		right    Evaluable
+		expected bool
+	}{
+		// Truthy
+		{Val(at), Val(later), true},
+		{Val(at), Val("2024-01-03"), true},
+		{Val("2024-01-02T15:04:04Z"), Val(at), true},
+		// Falsy
+		{Val(at), Val(at), false},
+		{Val(later), Val(at), false},
+		{Val(at), Val("2024-01-02"), false},
+		// Non times
+		{Val("2024-01-01"), Val("2024-01-02"), false},
+		{Val(at), Val("val"), false},
+		{Val(1), Val(2), false},
+		{Ref("Missing"), Val(at), false},
+		{Col(Val(at)), Val(later), false},
+	}
+
+	for _, test := range tests {
+		c, _ := New("BEFORE", test.left, test.right)
+		if output, err := c.Evaluate(map[string]any{}); output != test.expected || err != nil {
+			t.Errorf("input (%v, %v): expected %v, got %v/%v", test.left.String(), test.right.String(), test.expected, output, err)
+		}
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	ctx := map[string]any{"RefA": time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)}
+
+	var tests = []struct {
+		left     Evaluable
+		right    Evaluable
+		value    any
+		expected string
+	}{
+		{Ref("RefA"), Val("2024-01-03"), true, "null"},
+		{Ref("RefA"), Val("2024-01-01"), false, "null"},
+		{Ref("Missing"), Val("2024-01-03"), nil, `({Missing} <before> "2024-01-03")`},
+	}
+
+	for _, test := range tests {
+		c, _ := New("BEFORE", test.left, test.right)
+		if value, self := c.Simplify(ctx); value != test.value || Fprint(self) != test.expected {
+			t.Errorf("input (%v, %v): expected %v/%v, got %v/%v", test.left, test.right, test.value, test.expected, value, self)
+		}
+	}
+}
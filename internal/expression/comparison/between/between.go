@@ -0,0 +1,35 @@
+package between
+
+import (
+	e "github.com/spaceavocado/goillogical/evaluable"
+	c "github.com/spaceavocado/goillogical/internal/expression/comparison"
+)
+
+func handler(exclusive bool) func([]any) bool {
+	return func(evaluated []any) bool {
+		low, ok := c.Compare(evaluated[0], evaluated[1])
+		if !ok {
+			return false
+		}
+		high, ok := c.Compare(evaluated[0], evaluated[2])
+		if !ok {
+			return false
+		}
+		if exclusive {
+			return low > 0 && high < 0
+		}
+		return low >= 0 && high <= 0
+	}
+}
+
+// Create the expression determining whether the first operand is within the range given by the
+// lower and the upper bounds, inclusive.
+func New(operator string, val e.Evaluable, low e.Evaluable, high e.Evaluable) (e.Evaluable, error) {
+	return c.New(e.Between, operator, "<between>", []e.Evaluable{val, low, high}, handler(false))
+}
+
+// Create the expression determining whether the first operand is within the range given by the
+// lower and the upper bounds, exclusive.
+func NewExclusive(operator string, val e.Evaluable, low e.Evaluable, high e.Evaluable) (e.Evaluable, error) {
+	return c.New(e.BetweenExclusive, operator, "<between exclusive>", []e.Evaluable{val, low, high}, handler(true))
+}
@@ -0,0 +1,110 @@
+package between
+
+import (
+	"testing"
+
+	. "github.com/spaceavocado/goillogical/evaluable"
+	. "github.com/spaceavocado/goillogical/internal/mock"
+	. "github.com/spaceavocado/goillogical/internal/test"
+)
+
+func TestHandler(t *testing.T) {
+	var tests = []struct {
+		val      Evaluable
+		low      Evaluable
+		high     Evaluable
+		expected bool
+	}{
+		// Truthy
+		{Val(5), Val(1), Val(10), true},
+		{Val(1), Val(1), Val(10), true},
+		{Val(10), Val(1), Val(10), true},
+		{Val(1.5), Val(1), Val(uint8(2)), true},
+		{Val("b"), Val("a"), Val("c"), true},
+		{Val("a"), Val("a"), Val("c"), true},
+		// Falsy
+		{Val(0), Val(1), Val(10), false},
+		{Val(11), Val(1), Val(10), false},
+		{Val(5), Val(10), Val(1), false},
+		{Val("d"), Val("a"), Val("c"), false},
+		// Diff types
+		{Val("5"), Val(1), Val(10), false},
+		{Val(5), Val("1"), Val(10), false},
+		{Val(5), Val(1), Val("10"), false},
+		{Val(true), Val(false), Val(true), false},
+		{Ref("Missing"), Val(1), Val(10), false},
+		// Slices
+		{Col(Val(5)), Val(1), Val(10), false},
+	}
+
+	for _, test := range tests {
+		c, _ := New("BETWEEN", test.val, test.low, test.high)
+		if output, err := c.Evaluate(map[string]any{}); output != test.expected || err != nil {
+			t.Errorf("input (%v, %v, %v): expected %v, got %v/%v", test.val, test.low, test.high, test.expected, output, err)
+		}
+	}
+}
+
+func TestExclusive(t *testing.T) {
+	var tests = []struct {
+		val      Evaluable
+		low      Evaluable
+		high     Evaluable
+		expected bool
+	}{
+		// Truthy
+		{Val(5), Val(1), Val(10), true},
+		{Val(1.1), Val(1), Val(10), true},
+		{Val("b"), Val("a"), Val("c"), true},
+		// Falsy
+		{Val(1), Val(1), Val(10), false},
+		{Val(10), Val(1), Val(10), false},
+		{Val("a"), Val("a"), Val("c"), false},
+		{Val(5), Val(5), Val(5), false},
+	}
+
+	for _, test := range tests {
+		c, _ := NewExclusive("BETWEEN EXCLUSIVE", test.val, test.low, test.high)
+		if output, err := c.Evaluate(map[string]any{}); output != test.expected || err != nil {
+			t.Errorf("input (%v, %v, %v): expected %v, got %v/%v", test.val, test.low, test.high, test.expected, output, err)
+		}
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	ctx := map[string]any{"RefA": 5}
+
+	var tests = []struct {
+		operands []Evaluable
+		value    any
+		expected string
+	}{
+		{[]Evaluable{Ref("RefA"), Val(1), Val(10)}, true, "null"},
+		{[]Evaluable{Ref("RefA"), Val(6), Val(10)}, false, "null"},
+		{[]Evaluable{Ref("RefA"), Ref("Missing"), Val(10)}, nil, "({RefA} <between> {Missing}, 10)"},
+		{[]Evaluable{Ref("RefA"), Val(1), Ref("Missing")}, nil, "({RefA} <between> 1, {Missing})"},
+	}
+
+	for _, test := range tests {
+		c, _ := New("BETWEEN", test.operands[0], test.operands[1], test.operands[2])
+		if value, self := c.Simplify(ctx); value != test.value || Fprint(self) != test.expected {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v", test.operands, test.value, test.expected, value, self)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	var tests = []struct {
+		factory  func(string, Evaluable, Evaluable, Evaluable) (Evaluable, error)
+		expected string
+	}{
+		{New, "({RefA} <between> 1, 10)"},
+		{NewExclusive, "({RefA} <between exclusive> 1, 10)"},
+	}
+
+	for _, test := range tests {
+		if c, _ := test.factory("BETWEEN", Ref("RefA"), Val(1), Val(10)); c.String() != test.expected {
+			t.Errorf("input (%v): expected %v, got %v", c, test.expected, c.String())
+		}
+	}
+}
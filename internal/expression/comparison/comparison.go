@@ -1,21 +1,49 @@
 package comparison
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	e "github.com/spaceavocado/goillogical/evaluable"
 )
 
-type Number interface {
-	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~float32 | ~float64
-}
-
 type comparison struct {
-	kind     string
+	kind     e.Kind
+	op       string
 	operator string
 	operands []e.Evaluable
 	handler  func([]any) bool
+	kleene   bool
+}
+
+// Comparison result of the evaluated operands. With the three-valued logic, the comparison of a
+// nil, or an unknown operand, i.e. a reference which could not be found, is unknown, except for
+// the NIL and PRESENT comparisons.
+func (c comparison) result(evaluated []any) any {
+	if !c.kleene {
+		return c.handler(evaluated)
+	}
+	if c.kind == e.Nil || c.kind == e.Present {
+		return e.TruthOf(c.handler(evaluated))
+	}
+
+	operands := make([]any, len(evaluated))
+	for i, val := range evaluated {
+		if val == nil {
+			return e.TruthUnknown
+		}
+		operands[i] = val
+		if t, ok := val.(e.Truth); ok {
+			b, known := t.Bool()
+			if !known {
+				return e.TruthUnknown
+			}
+			operands[i] = b
+		}
+	}
+	return e.TruthOf(c.handler(operands))
 }
 
 func (c comparison) Evaluate(ctx e.Context) (any, error) {
@@ -29,11 +57,72 @@ func (c comparison) Evaluate(ctx e.Context) (any, error) {
 		}
 		evaluated[i] = val
 	}
-	return c.handler(evaluated), nil
+	return c.result(evaluated), nil
+}
+
+func (c comparison) EvaluateContext(ctx context.Context, data e.Context) (any, error) {
+	return e.Guard(ctx, func(ctx context.Context) (any, error) {
+		evaluated, err := e.EvaluateOperands(ctx, c.operands, e.FlattenContext(data))
+		if err != nil {
+			return false, err
+		}
+		return c.result(evaluated), nil
+	})
+}
+
+func (c comparison) Compile() (e.Func, error) {
+	operands, err := e.CompileFuncs(c.operands)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx e.Context) (any, error) {
+		evaluated := make([]any, len(operands))
+		for i, fn := range operands {
+			val, err := fn(ctx)
+			if err != nil {
+				return false, err
+			}
+			evaluated[i] = val
+		}
+		return c.result(evaluated), nil
+	}, nil
+}
+
+func (c comparison) Explain(ctx e.Context) (e.Trace, error) {
+	evaluated, operands, err := e.ExplainOperands(e.FlattenContext(ctx), c.operands)
+	if err != nil {
+		return e.NewTrace(c.kind, c, false, err, operands), err
+	}
+	return e.NewTrace(c.kind, c, c.result(evaluated), nil, operands), nil
+}
+
+func (c comparison) Kind() e.Kind {
+	return c.kind
+}
+
+func (c comparison) Operator() string {
+	return c.op
+}
+
+func (c comparison) Operands() []e.Evaluable {
+	return c.operands
+}
+
+func (c comparison) WithOperands(operands []e.Evaluable) e.Evaluable {
+	c.operands = operands
+	return c
+}
+
+// Get the three-valued form of the comparison, i.e. evaluated into a Truth value, see
+// evaluable.Truth.
+func (c comparison) ThreeValued() e.Evaluable {
+	c.kleene = true
+	return c
 }
 
 func (c comparison) Serialize() any {
-	res := []any{c.kind}
+	res := []any{c.op}
 	for i := 0; i < len(c.operands); i++ {
 		res = append(res, c.operands[i].Serialize())
 	}
@@ -58,7 +147,11 @@ func (c comparison) Simplify(ctx e.Context) (any, e.Evaluable) {
 func (c comparison) String() string {
 	res := fmt.Sprintf("(%s %s", c.operands[0].String(), c.operator)
 	if len(c.operands) > 1 {
-		res += fmt.Sprintf(" %s", c.operands[1].String())
+		rest := make([]string, len(c.operands)-1)
+		for i, o := range c.operands[1:] {
+			rest[i] = o.String()
+		}
+		res += fmt.Sprintf(" %s", strings.Join(rest, ", "))
 	}
 	return res + ")"
 }
@@ -82,6 +175,30 @@ func IsComparable(left any, right any) bool {
 	return true
 }
 
-func New(kind string, op string, operands []e.Evaluable, handler func([]any) bool) (e.Evaluable, error) {
-	return comparison{kind: kind, operator: op, operands: operands, handler: handler}, nil
+// Determine whether the haystack contains the needle, i.e. a string containing the substring, or
+// a slice containing an element equal to the needle, see IsEqual.
+func ContainsValue(haystack any, needle any) bool {
+	if s, ok := haystack.(string); ok {
+		sub, ok := needle.(string)
+		return ok && strings.Contains(s, sub)
+	}
+
+	if haystack == nil || reflect.TypeOf(haystack).Kind() != reflect.Slice {
+		return false
+	}
+	if needle != nil && reflect.TypeOf(needle).Kind() == reflect.Slice {
+		return false
+	}
+
+	v := reflect.ValueOf(haystack)
+	for i := 0; i < v.Len(); i++ {
+		if IsEqual(v.Index(i).Interface(), needle) {
+			return true
+		}
+	}
+	return false
+}
+
+func New(kind e.Kind, op string, operator string, operands []e.Evaluable, handler func([]any) bool) (e.Evaluable, error) {
+	return comparison{kind: kind, op: op, operator: operator, operands: operands, handler: handler}, nil
 }
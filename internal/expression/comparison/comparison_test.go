@@ -21,7 +21,7 @@ func TestEvaluate(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		c, _ := New("Unknown", test.op, test.operands, func(evaluated []any) bool { return evaluated[0] == evaluated[1] })
+		c, _ := New(Unknown, "Unknown", test.op, test.operands, func(evaluated []any) bool { return evaluated[0] == evaluated[1] })
 		if output, err := c.Evaluate(map[string]any{}); output != test.expected || err != nil {
 			t.Errorf("input (%v, %v): expected %v, got %v/%v", test.op, test.operands, test.expected, output, err)
 		}
@@ -36,13 +36,103 @@ func TestEvaluate(t *testing.T) {
 	}
 
 	for _, test := range errs {
-		c, _ := New("Unknown", test.op, test.operands, func(evaluated []any) bool { return evaluated[0] == evaluated[1] })
+		c, _ := New(Unknown, "Unknown", test.op, test.operands, func(evaluated []any) bool { return evaluated[0] == evaluated[1] })
 		if _, err := c.Evaluate(map[string]any{}); err.Error() != test.expected.Error() {
 			t.Errorf("input (%v, %v): expected %v, got %v", test.op, test.operands, test.expected, err)
 		}
 	}
 }
 
+func TestCompile(t *testing.T) {
+	var tests = []struct {
+		operands []Evaluable
+		expected bool
+	}{
+		{[]Evaluable{Val(1), Ref("RefA")}, true},
+		{[]Evaluable{Val(2), Ref("RefA")}, false},
+	}
+
+	for _, test := range tests {
+		c, _ := New(Unknown, "Unknown", "==", test.operands, func(evaluated []any) bool { return evaluated[0] == evaluated[1] })
+		fn, _ := c.(Compilable).Compile()
+		if output, err := fn(FlattenContext(map[string]any{"RefA": 1})); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.operands, test.expected, output, err)
+		}
+	}
+
+	c, _ := New(Unknown, "Unknown", "==", []Evaluable{Val(1), Invalid()}, func(evaluated []any) bool { return true })
+	fn, _ := c.(Compilable).Compile()
+	if _, err := fn(map[string]any{}); err == nil || err.Error() != "invalid" {
+		t.Errorf("input (%v): expected %v, got %v", c, "invalid", err)
+	}
+}
+
+func TestThreeValued(t *testing.T) {
+	var tests = []struct {
+		kind     Kind
+		operands []Evaluable
+		expected Truth
+	}{
+		{Eq, []Evaluable{Val(1), Ref("RefA")}, TruthTrue},
+		{Eq, []Evaluable{Val(2), Ref("RefA")}, TruthFalse},
+		{Eq, []Evaluable{Val(1), Ref("Missing")}, TruthUnknown},
+		{Eq, []Evaluable{Ref("Missing"), Ref("Missing")}, TruthUnknown},
+		{Eq, []Evaluable{Val(true), ValTruth(TruthTrue)}, TruthTrue},
+		{Eq, []Evaluable{Val(true), ValTruth(TruthUnknown)}, TruthUnknown},
+		{Nil, []Evaluable{Ref("Missing"), Ref("Missing")}, TruthTrue},
+		{Present, []Evaluable{Ref("Missing"), Val(1)}, TruthFalse},
+	}
+
+	handler := func(evaluated []any) bool { return evaluated[0] == evaluated[1] }
+	for _, test := range tests {
+		c, _ := New(test.kind, "==", "==", test.operands, handler)
+		c = c.(ThreeValued).ThreeValued()
+		if output, err := c.Evaluate(map[string]any{"RefA": 1}); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.operands, test.expected, output, err)
+		}
+
+		fn, _ := c.(Compilable).Compile()
+		if output, err := fn(FlattenContext(map[string]any{"RefA": 1})); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected compiled %v, got %v/%v", test.operands, test.expected, output, err)
+		}
+	}
+
+	c, _ := New(Eq, "==", "==", []Evaluable{Val(1), Ref("Missing")}, handler)
+	if output, _ := c.Evaluate(map[string]any{}); output != false {
+		t.Errorf("input (%v): expected %v, got %v", c, false, output)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	var tests = []struct {
+		operands []Evaluable
+		expected string
+	}{
+		{[]Evaluable{Ref("RefA"), Val(1)}, "Eq: ({RefA} == 1) => true\n  Reference: {RefA} => 1, at \"RefA\"\n  Value: 1 => 1"},
+		{[]Evaluable{Invalid(), Val(1)}, "Eq: (invalid == 1) => error: invalid\n  Unknown: invalid => error: invalid\n  Value: 1 => skipped"},
+	}
+
+	for _, test := range tests {
+		c, _ := New(Eq, "==", "==", test.operands, func(evaluated []any) bool { return evaluated[0] == evaluated[1] })
+		if output, _ := c.(Explainable).Explain(map[string]any{"RefA": 1}); output.String() != test.expected {
+			t.Errorf("input (%v): expected %v, got %v", test.operands, test.expected, output)
+		}
+	}
+}
+
+func TestOperands(t *testing.T) {
+	c, _ := New(Eq, "==", "IS", []Evaluable{Ref("RefA"), Val(1)}, func(evaluated []any) bool { return evaluated[0] == evaluated[1] })
+	n := c.(Node)
+	if n.Kind() != Eq || n.Operator() != "==" || len(n.Operands()) != 2 {
+		t.Errorf("input (%v): expected Eq node, got %v/%v/%v", c, n.Kind(), n.Operator(), n.Operands())
+	}
+
+	rewritten := n.WithOperands([]Evaluable{Ref("RefB"), Val(1)})
+	if output, _ := rewritten.Evaluate(map[string]any{"RefA": 1, "RefB": 2}); output != false || rewritten.String() != "({RefB} IS 1)" || c.String() != "({RefA} IS 1)" {
+		t.Errorf("input (%v): expected ({RefB} IS 1), got %v/%v", c, rewritten, output)
+	}
+}
+
 func TestSerialize(t *testing.T) {
 	var tests = []struct {
 		op       string
@@ -54,7 +144,7 @@ func TestSerialize(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		c, _ := New(test.op, test.op, test.operands, func(evaluated []any) bool { return false })
+		c, _ := New(Unknown, test.op, test.op, test.operands, func(evaluated []any) bool { return false })
 		if output := c.Serialize(); Fprint(output) != Fprint(test.expected) {
 			t.Errorf("input (%v, %v): expected %v, got %v", test.op, test.operands, test.expected, output)
 		}
@@ -67,7 +157,7 @@ func TestSimplify(t *testing.T) {
 	}
 
 	eq := func(operands ...Evaluable) Evaluable {
-		e, _ := New("Unknown", "==", operands, func(evaluated []any) bool { return evaluated[0] == evaluated[1] })
+		e, _ := New(Unknown, "Unknown", "==", operands, func(evaluated []any) bool { return evaluated[0] == evaluated[1] })
 		return e
 	}
 
@@ -100,16 +190,43 @@ func TestString(t *testing.T) {
 	}{
 		{"==", []Evaluable{Val(1), Val(2)}, "(1 == 2)"},
 		{"<nil>", []Evaluable{Val(1)}, "(1 <nil>)"},
+		{"<between>", []Evaluable{Val(1), Val(0), Val(2)}, "(1 <between> 0, 2)"},
 	}
 
 	for _, test := range tests {
-		c, _ := New("Unknown", test.op, test.operands, func(evaluated []any) bool { return false })
+		c, _ := New(Unknown, "Unknown", test.op, test.operands, func(evaluated []any) bool { return false })
 		if output := c.String(); output != test.expected {
 			t.Errorf("input (%v, %v): expected %v, got %v", test.op, test.operands, test.expected, output)
 		}
 	}
 }
 
+func TestContainsValue(t *testing.T) {
+	var tests = []struct {
+		haystack any
+		needle   any
+		expected bool
+	}{
+		{"hemisphere", "sphere", true},
+		{"hemisphere", "hemp", false},
+		{"1", 1, false},
+		{[]any{1, "a"}, "a", true},
+		{[]int{1, 2}, 2.0, true},
+		{[]int{1, 2}, uint8(2), true},
+		{[]int{1, 2}, "2", false},
+		{[]any{nil}, nil, true},
+		{[]any{[]int{1}}, []int{1}, false},
+		{nil, 1, false},
+		{1, 1, false},
+	}
+
+	for _, test := range tests {
+		if output := ContainsValue(test.haystack, test.needle); output != test.expected {
+			t.Errorf("input (%v, %v): expected %v, got %v", test.haystack, test.needle, test.expected, output)
+		}
+	}
+}
+
 func TestIsComparable(t *testing.T) {
 	var tests = []struct {
 		a        any
@@ -0,0 +1,14 @@
+package contains
+
+import (
+	e "github.com/spaceavocado/goillogical/evaluable"
+	c "github.com/spaceavocado/goillogical/internal/expression/comparison"
+)
+
+func handler(evaluated []any) bool {
+	return c.ContainsValue(evaluated[0], evaluated[1])
+}
+
+func New(operator string, left e.Evaluable, right e.Evaluable) (e.Evaluable, error) {
+	return c.New(e.Contains, operator, "<contains>", []e.Evaluable{left, right}, handler)
+}
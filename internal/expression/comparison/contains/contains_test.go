@@ -0,0 +1,68 @@
+package contains
+
+import (
+	"testing"
+
+	. "github.com/spaceavocado/goillogical/evaluable"
+	. "github.com/spaceavocado/goillogical/internal/mock"
+	. "github.com/spaceavocado/goillogical/internal/test"
+)
+
+func TestHandler(t *testing.T) {
+	var tests = []struct {
+		left     Evaluable
+		right    Evaluable
+		expected bool
+	}{
+		// Truthy
+		{Val("hemisphere"), Val("sphere"), true},
+		{Val("hemisphere"), Val(""), true},
+		{Col(Val(1), Val(2)), Val(2), true},
+		{Col(Val(1), Val(2)), Val(2.0), true},
+		{Col(Val("a"), Val("b")), Val("b"), true},
+		{Col(Val(true)), Val(true), true},
+		// Falsy
+		{Val("hemisphere"), Val("hemp"), false},
+		{Col(Val(1), Val(2)), Val(3), false},
+		{Col(Val("a"), Val("b")), Val("ab"), false},
+		// Diff types
+		{Val("1"), Val(1), false},
+		{Val(1), Val(1), false},
+		{Val(true), Val(true), false},
+		{Col(Val(1)), Val("1"), false},
+		{Ref("Missing"), Val("a"), false},
+		{Col(Val(1)), Ref("Missing"), false},
+		// Slices
+		{Val(1), Col(Val(1)), false},
+		{Col(Val(1)), Col(Val(1)), false},
+	}
+
+	for _, test := range tests {
+		c, _ := New("CONTAINS", test.left, test.right)
+		if output, err := c.Evaluate(map[string]any{}); output != test.expected || err != nil {
+			t.Errorf("input (%v, %v): expected %v, got %v/%v", test.left.String(), test.right.String(), test.expected, output, err)
+		}
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	ctx := map[string]any{"RefA": "hemisphere"}
+
+	var tests = []struct {
+		left     Evaluable
+		right    Evaluable
+		value    any
+		expected string
+	}{
+		{Ref("RefA"), Val("sphere"), true, "null"},
+		{Ref("RefA"), Val("hemp"), false, "null"},
+		{Ref("Missing"), Val("sphere"), nil, `({Missing} <contains> "sphere")`},
+	}
+
+	for _, test := range tests {
+		c, _ := New("CONTAINS", test.left, test.right)
+		if value, self := c.Simplify(ctx); value != test.value || Fprint(self) != test.expected {
+			t.Errorf("input (%v, %v): expected %v/%v, got %v/%v", test.left, test.right, test.value, test.expected, value, self)
+		}
+	}
+}
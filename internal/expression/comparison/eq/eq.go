@@ -6,12 +6,9 @@ import (
 )
 
 func handler(evaluated []any) bool {
-	if !c.IsComparable(evaluated[0], evaluated[1]) {
-		return false
-	}
-	return evaluated[0] == evaluated[1]
+	return c.IsEqual(evaluated[0], evaluated[1])
 }
 
 func New(operator string, left e.Evaluable, right e.Evaluable) (e.Evaluable, error) {
-	return c.New(operator, "==", []e.Evaluable{left, right}, handler)
+	return c.New(e.Eq, operator, "==", []e.Evaluable{left, right}, handler)
 }
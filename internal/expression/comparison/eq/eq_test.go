@@ -1,6 +1,8 @@
 package eq
 
 import (
+	"encoding/json"
+	"math"
 	"testing"
 
 	. "github.com/spaceavocado/goillogical/evaluable"
@@ -19,6 +21,17 @@ func TestHandler(t *testing.T) {
 		{Val("1"), Val("1"), true},
 		{Val(true), Val(true), true},
 		{Val(false), Val(false), true},
+		// Mixed numeric types
+		{Val(1), Val(1.0), true},
+		{Val(int8(1)), Val(uint64(1)), true},
+		{Val(float32(0.5)), Val(0.5), true},
+		{Val(json.Number("10")), Val(10), true},
+		{Val(json.Number("10.0")), Val(uint(10)), true},
+		{Val(int64(9007199254740993)), Val(float64(9007199254740992)), false},
+		{Val(uint64(math.MaxUint64)), Val(float64(math.MaxUint64)), false},
+		{Val(-1), Val(uint64(math.MaxUint64)), false},
+		{Val(math.NaN()), Val(math.NaN()), false},
+		{Val(json.Number("1")), Val("1"), false},
 		// Diff types
 		{Val(1), Val(1.1), false},
 		{Val(1), Val("1"), false},
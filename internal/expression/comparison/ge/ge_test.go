@@ -1,6 +1,8 @@
 package eq
 
 import (
+	"encoding/json"
+	"math"
 	"testing"
 
 	. "github.com/spaceavocado/goillogical/evaluable"
@@ -21,8 +23,15 @@ func TestHandler(t *testing.T) {
 		// Falsy
 		{Val(0), Val(1), false},
 		{Val(1.0), Val(1.1), false},
+		// Mixed numeric types
+		{Val(1.1), Val(1), true},
+		{Val(1), Val(1.0), true},
+		{Val(uint16(1)), Val(int8(1)), true},
+		{Val(json.Number("1")), Val(float32(1)), true},
+		{Val(int64(9007199254740992)), Val(float64(9007199254740993)), true},
+		{Val(-1), Val(uint(0)), false},
 		// Non comparable
-		{Val(1.1), Val(1), false},
+		{Val(math.NaN()), Val(math.NaN()), false},
 		{Val("val"), Val(1), false},
 	}
 
@@ -1,7 +1,10 @@
 package gt
 
 import (
+	"encoding/json"
+	"math"
 	"testing"
+	"time"
 
 	. "github.com/spaceavocado/goillogical/evaluable"
 	. "github.com/spaceavocado/goillogical/internal/mock"
@@ -21,8 +24,20 @@ func TestHandler(t *testing.T) {
 		{Val(float32(1.1)), Val(float32(1.1)), false},
 		{Val(0), Val(1), false},
 		{Val(1.0), Val(1.1), false},
+		// Mixed numeric types
+		{Val(1.1), Val(1), true},
+		{Val(uint8(2)), Val(int64(1)), true},
+		{Val(json.Number("10.5")), Val(10), true},
+		{Val(int64(9007199254740993)), Val(float64(9007199254740992)), true},
+		{Val(uint64(math.MaxUint64)), Val(int64(math.MaxInt64)), true},
+		{Val(-1), Val(uint(0)), false},
+		{Val(1), Val(1.0), false},
+		// Times
+		{Val(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)), Val(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)), true},
+		{Val(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)), Val("2024-01-01"), true},
+		{Val("2024-01-01"), Val(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)), false},
 		// Non comparable
-		{Val(1.1), Val(1), false},
+		{Val(json.Number("val")), Val(1), false},
 		{Val("val"), Val(1), false},
 	}
 
@@ -1,6 +1,7 @@
 package in
 
 import (
+	"encoding/json"
 	"testing"
 
 	. "github.com/spaceavocado/goillogical/evaluable"
@@ -19,12 +20,17 @@ func TestHandler(t *testing.T) {
 		{Val("1"), Col(Val("1")), true},
 		{Val(true), Col(Val(true)), true},
 		{Val(1.1), Col(Val(1.1)), true},
+		{Val(1), Col(Val(2.0), Val(1.0)), true},
+		{Val(json.Number("1")), Col(Val(uint(1))), true},
 		// Falsy
 		{Val(1), Col(Val(2)), false},
 		{Col(Val(2)), Val(1), false},
 		{Val(1), Val(1), false},
 		{Col(Val(1)), Col(Val(1)), false},
 		{Val(1), Col(Val("1")), false},
+		{Ref("Missing"), Col(Val(1)), false},
+		{Col(Val(1)), Ref("Missing"), false},
+		{Ref("Missing"), Ref("Missing"), false},
 	}
 
 	for _, test := range tests {
@@ -1,6 +1,8 @@
 package le
 
 import (
+	"encoding/json"
+	"math"
 	"testing"
 
 	. "github.com/spaceavocado/goillogical/evaluable"
@@ -21,8 +23,13 @@ func TestHandler(t *testing.T) {
 		// Falsy
 		{Val(1), Val(0), false},
 		{Val(1.1), Val(1.0), false},
-		// Non comparable
+		// Mixed numeric types
+		{Val(1), Val(1.0), true},
+		{Val(uint32(1)), Val(int64(2)), true},
+		{Val(json.Number("18446744073709551615")), Val(float64(math.MaxUint64)), true},
 		{Val(1.1), Val(1), false},
+		// Non comparable
+		{Val("1"), Val(1), false},
 		{Val("val"), Val(1), false},
 	}
 
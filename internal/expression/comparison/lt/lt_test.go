@@ -1,6 +1,8 @@
 package lt
 
 import (
+	"encoding/json"
+	"math"
 	"testing"
 
 	. "github.com/spaceavocado/goillogical/evaluable"
@@ -21,8 +23,15 @@ func TestHandler(t *testing.T) {
 		{Val(float32(1.1)), Val(float32(1.1)), false},
 		{Val(1), Val(0), false},
 		{Val(1.1), Val(1.0), false},
-		// Non comparable
+		// Mixed numeric types
+		{Val(1), Val(1.1), true},
+		{Val(-1), Val(uint(0)), true},
+		{Val(int32(1)), Val(json.Number("1.5")), true},
+		{Val(uint64(math.MaxUint64 - 1)), Val(uint64(math.MaxUint64)), true},
+		{Val(int64(math.MaxInt64)), Val(float64(math.MaxInt64)), true},
 		{Val(1.1), Val(1), false},
+		// Non comparable
+		{Val(1), Val(math.NaN()), false},
 		{Val("val"), Val(1), false},
 	}
 
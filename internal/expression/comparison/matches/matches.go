@@ -0,0 +1,88 @@
+package matches
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"sync/atomic"
+
+	e "github.com/spaceavocado/goillogical/evaluable"
+	c "github.com/spaceavocado/goillogical/internal/expression/comparison"
+)
+
+// Maximal number of cached patterns, the patterns beyond the limit are compiled on every
+// evaluation.
+const maxCachedPatterns = 1024
+
+const insensitiveFlag = "(?i)"
+
+// Compiled patterns given by the references, an invalid pattern is cached as nil.
+var cache sync.Map
+var cached atomic.Int64
+
+func compileCached(pattern string) *regexp.Regexp {
+	if rx, ok := cache.Load(pattern); ok {
+		return rx.(*regexp.Regexp)
+	}
+
+	rx, err := regexp.Compile(pattern)
+	if err != nil {
+		rx = nil
+	}
+	if cached.Load() < maxCachedPatterns {
+		if _, loaded := cache.LoadOrStore(pattern, rx); !loaded {
+			cached.Add(1)
+		}
+	}
+	return rx
+}
+
+// Create the handler, the static pattern is compiled upfront, any other pattern is compiled on
+// demand.
+func handler(flag string, static *regexp.Regexp, pattern string) func([]any) bool {
+	return func(evaluated []any) bool {
+		subject, ok := evaluated[0].(string)
+		if !ok {
+			return false
+		}
+		p, ok := evaluated[1].(string)
+		if !ok {
+			return false
+		}
+
+		rx := static
+		if rx == nil || p != pattern {
+			rx = compileCached(flag + p)
+		}
+		return rx != nil && rx.MatchString(subject)
+	}
+}
+
+func create(kind e.Kind, flag string, operator string, display string, left e.Evaluable, right e.Evaluable) (e.Evaluable, error) {
+	var static *regexp.Regexp
+	var pattern string
+	if e.KindOf(right) == e.Value {
+		val, _ := right.Evaluate(nil)
+		if p, ok := val.(string); ok {
+			rx, err := regexp.Compile(flag + p)
+			if err != nil {
+				return nil, e.NewParseError(e.ErrInvalidPattern, fmt.Sprintf("invalid pattern \"%s\", %v", p, err))
+			}
+			static, pattern = rx, p
+		}
+	}
+
+	return c.New(kind, operator, display, []e.Evaluable{left, right}, handler(flag, static, pattern))
+}
+
+// Create the expression matching the left operand against the regular expression pattern given
+// by the right operand. A static pattern is compiled once, an invalid static pattern is reported
+// as ErrInvalidPattern error.
+func New(operator string, left e.Evaluable, right e.Evaluable) (e.Evaluable, error) {
+	return create(e.Matches, "", operator, "<matches>", left, right)
+}
+
+// Create the case-insensitive variant of the matches expression, see New.
+func NewInsensitive(operator string, left e.Evaluable, right e.Evaluable) (e.Evaluable, error) {
+	return create(e.IMatches, insensitiveFlag, operator, "<imatches>", left, right)
+}
@@ -0,0 +1,98 @@
+package matches
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/spaceavocado/goillogical/evaluable"
+	. "github.com/spaceavocado/goillogical/internal/mock"
+)
+
+func TestHandler(t *testing.T) {
+	var tests = []struct {
+		factory  func(string, Evaluable, Evaluable) (Evaluable, error)
+		left     Evaluable
+		right    Evaluable
+		expected bool
+	}{
+		// Truthy
+		{New, Val("peter@example.com"), Val(`@example\.com$`), true},
+		{New, Val("SKU-1234"), Val(`^SKU-\d{4}$`), true},
+		{New, Val("peter@example.com"), Ref("RefA"), true},
+		{NewInsensitive, Val("PETER@EXAMPLE.COM"), Val(`@example\.com$`), true},
+		{NewInsensitive, Val("PETER@EXAMPLE.COM"), Ref("RefA"), true},
+		// Falsy
+		{New, Val("PETER@EXAMPLE.COM"), Val(`@example\.com$`), false},
+		{New, Val("PETER@EXAMPLE.COM"), Ref("RefA"), false},
+		{New, Val("SKU-123"), Val(`^SKU-\d{4}$`), false},
+		{New, Val("peter"), Ref("RefB"), false},
+		{New, Val("peter"), Ref("Missing"), false},
+		// Diff types
+		{New, Val(1), Val(`\d`), false},
+		{New, Val(true), Val(`true`), false},
+		{New, Val("1"), Val(1), false},
+		{New, Ref("Missing"), Val(`.*`), false},
+		// Slices
+		{New, Col(Val("a")), Val(`a`), false},
+		{New, Val("a"), Col(Val(`a`)), false},
+	}
+
+	ctx := map[string]any{"RefA": `@example\.com$`, "RefB": `(`}
+	for _, test := range tests {
+		c, _ := test.factory("MATCHES", test.left, test.right)
+		if output, err := c.Evaluate(ctx); output != test.expected || err != nil {
+			t.Errorf("input (%v, %v): expected %v, got %v/%v", test.left.String(), test.right.String(), test.expected, output, err)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	var errs = []struct {
+		factory  func(string, Evaluable, Evaluable) (Evaluable, error)
+		right    Evaluable
+		expected error
+	}{
+		{New, Val(`(`), errors.New("invalid pattern \"(\", error parsing regexp: missing closing ): `(`")},
+		{NewInsensitive, Val(`[a-`), errors.New("invalid pattern \"[a-\", error parsing regexp: missing closing ]: `[a-`")},
+	}
+
+	for _, test := range errs {
+		if _, err := test.factory("MATCHES", Val("a"), test.right); err == nil || err.Error() != test.expected.Error() || !errors.Is(err, ErrInvalidPattern) {
+			t.Errorf("input (%v): expected %v, got %v", test.right, test.expected, err)
+		}
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	c, _ := New("MATCHES", Val("abc"), Val(`^a`))
+	rewritten := c.(Node).WithOperands([]Evaluable{Val("abc"), Val(`^b`)})
+
+	if output, err := rewritten.Evaluate(map[string]any{}); output != false || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", rewritten, false, output, err)
+	}
+}
+
+func TestString(t *testing.T) {
+	var tests = []struct {
+		factory  func(string, Evaluable, Evaluable) (Evaluable, error)
+		expected string
+	}{
+		{New, `({RefA} <matches> "^a")`},
+		{NewInsensitive, `({RefA} <imatches> "^a")`},
+	}
+
+	for _, test := range tests {
+		if c, _ := test.factory("MATCHES", Ref("RefA"), Val(`^a`)); c.String() != test.expected {
+			t.Errorf("input (%v): expected %v, got %v", c, test.expected, c.String())
+		}
+	}
+}
+
+func TestCompileCached(t *testing.T) {
+	if rx := compileCached(`^cached$`); rx == nil || rx != compileCached(`^cached$`) {
+		t.Errorf("input (%v): expected cached pattern, got %v", `^cached$`, rx)
+	}
+	if rx := compileCached(`(`); rx != nil {
+		t.Errorf("input (%v): expected nil, got %v", `(`, rx)
+	}
+}
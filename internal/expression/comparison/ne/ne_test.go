@@ -1,6 +1,7 @@
 package ne
 
 import (
+	"encoding/json"
 	"testing"
 
 	. "github.com/spaceavocado/goillogical/evaluable"
@@ -22,6 +23,11 @@ func TestHandler(t *testing.T) {
 		{Val("1"), Val("1"), false},
 		{Val(true), Val(false), true},
 		{Val(true), Val(true), false},
+		// Mixed numeric types
+		{Val(1), Val(1.0), false},
+		{Val(uint8(1)), Val(int64(1)), false},
+		{Val(json.Number("10")), Val(10.0), false},
+		{Val(int64(9007199254740993)), Val(float64(9007199254740992)), true},
 		// Diff types
 		{Val(1), Val(1.1), true},
 		{Val(1), Val("1"), true},
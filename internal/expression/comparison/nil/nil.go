@@ -10,5 +10,5 @@ func handler(evaluated []any) bool {
 }
 
 func New(operator string, eval e.Evaluable) (e.Evaluable, error) {
-	return c.New(operator, "<is nil>", []e.Evaluable{eval}, handler)
+	return c.New(e.Nil, operator, "<is nil>", []e.Evaluable{eval}, handler)
 }
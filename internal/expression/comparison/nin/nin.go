@@ -7,7 +7,12 @@ import (
 	c "github.com/spaceavocado/goillogical/internal/expression/comparison"
 )
 
+// A nil operand, e.g. a missing reference, is not in any collection, see IN.
 func handler(evaluated []any) bool {
+	if evaluated[0] == nil || evaluated[1] == nil {
+		return true
+	}
+
 	t1s := reflect.TypeOf(evaluated[0]).Kind() == reflect.Slice
 	t2s := reflect.TypeOf(evaluated[1]).Kind() == reflect.Slice
 
@@ -27,7 +32,7 @@ func handler(evaluated []any) bool {
 
 	for i := 0; i < haystack.Len(); i++ {
 		p := haystack.Index(i).Interface()
-		if c.IsComparable(p, needle) && haystack.Index(i).Interface() == needle {
+		if c.IsEqual(p, needle) {
 			return false
 		}
 	}
@@ -36,5 +41,5 @@ func handler(evaluated []any) bool {
 }
 
 func New(operator string, left e.Evaluable, right e.Evaluable) (e.Evaluable, error) {
-	return c.New(operator, "<not in>", []e.Evaluable{left, right}, handler)
+	return c.New(e.Nin, operator, "<not in>", []e.Evaluable{left, right}, handler)
 }
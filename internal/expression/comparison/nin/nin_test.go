@@ -22,9 +22,12 @@ func TestHandler(t *testing.T) {
 		{Val(1), Val(1), true},
 		{Col(Val(1)), Col(Val(1)), true},
 		{Val(1), Col(Val("1")), true},
+		{Ref("Missing"), Col(Val(1)), true},
+		{Col(Val(1)), Ref("Missing"), true},
 		// Falsy
 		{Val(1), Col(Val(1)), false},
 		{Col(Val(1)), Val(1), false},
+		{Val(1.0), Col(Val(int8(1))), false},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,14 @@
+package notcontains
+
+import (
+	e "github.com/spaceavocado/goillogical/evaluable"
+	c "github.com/spaceavocado/goillogical/internal/expression/comparison"
+)
+
+func handler(evaluated []any) bool {
+	return !c.ContainsValue(evaluated[0], evaluated[1])
+}
+
+func New(operator string, left e.Evaluable, right e.Evaluable) (e.Evaluable, error) {
+	return c.New(e.NotContains, operator, "<not contains>", []e.Evaluable{left, right}, handler)
+}
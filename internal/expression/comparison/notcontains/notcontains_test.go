@@ -0,0 +1,40 @@
+package notcontains
+
+import (
+	"testing"
+
+	. "github.com/spaceavocado/goillogical/evaluable"
+	. "github.com/spaceavocado/goillogical/internal/mock"
+)
+
+func TestHandler(t *testing.T) {
+	var tests = []struct {
+		left     Evaluable
+		right    Evaluable
+		expected bool
+	}{
+		// Truthy
+		{Val("hemisphere"), Val("hemp"), true},
+		{Col(Val(1), Val(2)), Val(3), true},
+		{Val(1), Val(1), true},
+		{Col(Val(1)), Col(Val(1)), true},
+		// Falsy
+		{Val("hemisphere"), Val("sphere"), false},
+		{Col(Val(1), Val(2)), Val(2), false},
+		{Col(Val("a"), Val("b")), Val("b"), false},
+	}
+
+	for _, test := range tests {
+		c, _ := New("NOT CONTAINS", test.left, test.right)
+		if output, err := c.Evaluate(map[string]any{}); output != test.expected || err != nil {
+			t.Errorf("input (%v, %v): expected %v, got %v/%v", test.left.String(), test.right.String(), test.expected, output, err)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	c, _ := New("NOT CONTAINS", Ref("RefA"), Val("a"))
+	if expected := `({RefA} <not contains> "a")`; c.String() != expected {
+		t.Errorf("input (%v): expected %v, got %v", c, expected, c.String())
+	}
+}
@@ -0,0 +1,69 @@
+package comparison
+
+import (
+	"strings"
+
+	number "github.com/spaceavocado/goillogical/internal/number"
+)
+
+// Compare two numeric values of any numeric type, i.e. int8-int64, uint8-uint64, float32,
+// float64 or json.Number, by their mathematical value. Returns -1, 0 or 1 if the left value is
+// less than, equal to or greater than the right value, and false if any of the values is not a
+// number or is NaN.
+func CompareNumbers(left any, right any) (int, bool) {
+	a, ok := number.Of(left)
+	if !ok || a.IsNaN() {
+		return 0, false
+	}
+	b, ok := number.Of(right)
+	if !ok || b.IsNaN() {
+		return 0, false
+	}
+	return number.Compare(a, b), true
+}
+
+// Determine whether two values are equal, numeric values of any numeric type are compared by
+// their mathematical value, time values by their instant, see CompareTimes, any other values
+// must be comparable and of the same kind.
+func IsEqual(left any, right any) bool {
+	if isTime(left) || isTime(right) {
+		res, ok := CompareTimes(left, right)
+		return ok && res == 0
+	}
+	if res, ok := CompareNumbers(left, right); ok {
+		return res == 0
+	}
+	if _, ok := number.Of(left); ok {
+		return false
+	}
+	if _, ok := number.Of(right); ok {
+		return false
+	}
+	return IsComparable(left, right) && left == right
+}
+
+// Compare two ordered values, i.e. numbers of any numeric type, see CompareNumbers, or times, see
+// CompareTimes. Returns -1, 0 or 1 if the left value is less than, equal to or greater than the
+// right value, and false if the values could not be ordered.
+func CompareOrdered(left any, right any) (int, bool) {
+	if isTime(left) || isTime(right) {
+		return CompareTimes(left, right)
+	}
+	return CompareNumbers(left, right)
+}
+
+// Compare two ordered values, i.e. numbers or times, see CompareOrdered, or strings compared
+// lexicographically. Returns -1, 0 or 1 if the left value is less than, equal to or greater than
+// the right value, and false if the values could not be ordered.
+func Compare(left any, right any) (int, bool) {
+	if isTime(left) || isTime(right) {
+		return CompareTimes(left, right)
+	}
+	if a, ok := left.(string); ok {
+		if b, ok := right.(string); ok {
+			return strings.Compare(a, b), true
+		}
+		return 0, false
+	}
+	return CompareNumbers(left, right)
+}
@@ -0,0 +1,127 @@
+package comparison
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCompareNumbers(t *testing.T) {
+	var tests = []struct {
+		a        any
+		b        any
+		expected int
+		ok       bool
+	}{
+		// Same types
+		{1, 2, -1, true},
+		{2, 2, 0, true},
+		{1.5, 1.25, 1, true},
+		{uint(1), uint(2), -1, true},
+		// Signed and unsigned
+		{int8(-1), uint8(0), -1, true},
+		{int16(1), uint16(1), 0, true},
+		{uint32(2), int32(1), 1, true},
+		{int64(math.MaxInt64), uint64(math.MaxUint64), -1, true},
+		{uint64(math.MaxUint64), int64(-1), 1, true},
+		// Integers and floats
+		{1, 1.0, 0, true},
+		{1, 1.5, -1, true},
+		{-1, -1.5, 1, true},
+		{1.5, 1, 1, true},
+		{-1.5, -1, -1, true},
+		{float32(1.5), uint(1), 1, true},
+		{uint(0), -0.5, 1, true},
+		{int64(9007199254740993), float64(9007199254740992), 1, true},
+		{float64(9007199254740992), int64(9007199254740993), -1, true},
+		{int64(math.MaxInt64), float64(math.MaxInt64), -1, true},
+		{int64(math.MinInt64), float64(math.MinInt64), 0, true},
+		{int64(math.MinInt64), -math.MaxFloat64, 1, true},
+		{uint64(math.MaxUint64), float64(math.MaxUint64), -1, true},
+		{uint64(1 << 63), float64(1 << 63), 0, true},
+		{1, math.Inf(1), -1, true},
+		{uint(1), math.Inf(-1), 1, true},
+		// JSON numbers
+		{json.Number("10"), 10, 0, true},
+		{json.Number("10.5"), 10, 1, true},
+		{json.Number("18446744073709551615"), uint64(math.MaxUint64), 0, true},
+		{json.Number("-9223372036854775808"), int64(math.MinInt64), 0, true},
+		{json.Number("1e3"), 1000, 0, true},
+		// Non numbers
+		{json.Number("val"), 1, 0, false},
+		{"1", 1, 0, false},
+		{1, true, 0, false},
+		{nil, 1, 0, false},
+		{math.NaN(), 1, 0, false},
+		{1, math.NaN(), 0, false},
+	}
+
+	for _, test := range tests {
+		if output, ok := CompareNumbers(test.a, test.b); output != test.expected || ok != test.ok {
+			t.Errorf("input (%T(%v), %T(%v)): expected %v/%v, got %v/%v", test.a, test.a, test.b, test.b, test.expected, test.ok, output, ok)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	var tests = []struct {
+		a        any
+		b        any
+		expected int
+		ok       bool
+	}{
+		{1, 2.5, -1, true},
+		{uint8(3), 2, 1, true},
+		{"a", "b", -1, true},
+		{"b", "b", 0, true},
+		{"b", "a", 1, true},
+		{"1", 1, 0, false},
+		{1, "1", 0, false},
+		{true, false, 0, false},
+		{nil, nil, 0, false},
+		// Times
+		{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), "2024-01-03", -1, true},
+		{"2024-01-03T00:00:00Z", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), 1, true},
+		{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), 1, 0, false},
+	}
+
+	for _, test := range tests {
+		if output, ok := Compare(test.a, test.b); output != test.expected || ok != test.ok {
+			t.Errorf("input (%v, %v): expected %v/%v, got %v/%v", test.a, test.b, test.expected, test.ok, output, ok)
+		}
+	}
+}
+
+func TestIsEqual(t *testing.T) {
+	var tests = []struct {
+		a        any
+		b        any
+		expected bool
+	}{
+		{1, 1, true},
+		{1, 1.0, true},
+		{int8(1), uint64(1), true},
+		{json.Number("1"), float32(1), true},
+		{1, 2, false},
+		{math.NaN(), math.NaN(), false},
+		{json.Number("1"), "1", false},
+		{"1", json.Number("1"), false},
+		{"1", "1", true},
+		{true, true, true},
+		{1, true, false},
+		{nil, nil, true},
+		{nil, 1, false},
+		{[]any{1}, []any{1}, false},
+		{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 19, 0, 0, 0, time.FixedZone("EST", -5*60*60)), true},
+		{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), "2024-01-02T00:00:00Z", true},
+		{"2024-01-02", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), "2024-01-03", false},
+	}
+
+	for _, test := range tests {
+		if output := IsEqual(test.a, test.b); output != test.expected {
+			t.Errorf("input (%T(%v), %T(%v)): expected %v, got %v", test.a, test.a, test.b, test.b, test.expected, output)
+		}
+	}
+}
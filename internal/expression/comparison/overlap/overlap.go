@@ -7,7 +7,12 @@ import (
 	c "github.com/spaceavocado/goillogical/internal/expression/comparison"
 )
 
+// A nil operand, e.g. a missing reference, does not overlap any collection.
 func handler(evaluated []any) bool {
+	if evaluated[0] == nil || evaluated[1] == nil {
+		return false
+	}
+
 	t1s := reflect.TypeOf(evaluated[0]).Kind() == reflect.Slice
 	t2s := reflect.TypeOf(evaluated[1]).Kind() == reflect.Slice
 
@@ -22,7 +27,7 @@ func handler(evaluated []any) bool {
 		p1 := left.Index(i).Interface()
 		for j := 0; j < right.Len(); j++ {
 			p2 := right.Index(j).Interface()
-			if c.IsComparable(p1, p2) && p1 == p2 {
+			if c.IsEqual(p1, p2) {
 				return true
 			}
 		}
@@ -32,5 +37,5 @@ func handler(evaluated []any) bool {
 }
 
 func New(operator string, left e.Evaluable, right e.Evaluable) (e.Evaluable, error) {
-	return c.New(operator, "<overlaps>", []e.Evaluable{left, right}, handler)
+	return c.New(e.Overlap, operator, "<overlaps>", []e.Evaluable{left, right}, handler)
 }
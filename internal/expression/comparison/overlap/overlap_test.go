@@ -20,11 +20,14 @@ func TestHandler(t *testing.T) {
 		{Col(Val("1")), Col(Val("1")), true},
 		{Col(Val(true)), Col(Val(true)), true},
 		{Col(Val(1.1)), Col(Val(1.1)), true},
+		{Col(Val(1), Val(2)), Col(Val(2.0)), true},
 		// Falsy
 		{Val(1), Col(Val(1)), false},
 		{Col(Val(1)), Val(1), false},
 		{Val(1), Val(1), false},
 		{Col(Val(1)), Col(Val(2)), false},
+		{Ref("Missing"), Col(Val(1)), false},
+		{Col(Val(1)), Ref("Missing"), false},
 	}
 
 	for _, test := range tests {
@@ -34,5 +34,5 @@ func handler(evaluated []any) bool {
 }
 
 func New(operator string, left e.Evaluable, right e.Evaluable) (e.Evaluable, error) {
-	return c.New(operator, "<prefixes>", []e.Evaluable{left, right}, handler)
+	return c.New(e.Prefix, operator, "<prefixes>", []e.Evaluable{left, right}, handler)
 }
@@ -10,5 +10,5 @@ func handler(evaluated []any) bool {
 }
 
 func New(operator string, eval e.Evaluable) (e.Evaluable, error) {
-	return c.New(operator, "<is present>", []e.Evaluable{eval}, handler)
+	return c.New(e.Present, operator, "<is present>", []e.Evaluable{eval}, handler)
 }
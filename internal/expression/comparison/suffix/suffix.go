@@ -34,5 +34,5 @@ func handler(evaluated []any) bool {
 }
 
 func New(operator string, left e.Evaluable, right e.Evaluable) (e.Evaluable, error) {
-	return c.New(operator, "<with suffix>", []e.Evaluable{left, right}, handler)
+	return c.New(e.Suffix, operator, "<with suffix>", []e.Evaluable{left, right}, handler)
 }
@@ -0,0 +1,52 @@
+package comparison
+
+import (
+	"time"
+)
+
+// Layouts used to parse a string compared with a time.
+var timeLayouts = []string{time.RFC3339Nano, time.DateOnly}
+
+func toTime(val any, loc *time.Location) (time.Time, bool) {
+	switch typed := val.(type) {
+	case time.Time:
+		return typed, true
+	case string:
+		for _, layout := range timeLayouts {
+			if t, err := time.ParseInLocation(layout, typed, loc); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// Compare two time values, a string compared with a time is parsed as an RFC 3339 date-time, or
+// date in the location of the time. Returns -1, 0 or 1 if the left value is before, equal to or
+// after the right value, and false if any of the values is not a time.
+func CompareTimes(left any, right any) (int, bool) {
+	var loc *time.Location
+	switch {
+	case isTime(left):
+		loc = left.(time.Time).Location()
+	case isTime(right):
+		loc = right.(time.Time).Location()
+	default:
+		return 0, false
+	}
+
+	a, ok := toTime(left, loc)
+	if !ok {
+		return 0, false
+	}
+	b, ok := toTime(right, loc)
+	if !ok {
+		return 0, false
+	}
+	return a.Compare(b), true
+}
+
+func isTime(val any) bool {
+	_, ok := val.(time.Time)
+	return ok
+}
@@ -0,0 +1,41 @@
+package comparison
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompareTimes(t *testing.T) {
+	at := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	est := time.FixedZone("EST", -5*60*60)
+
+	var tests = []struct {
+		a        any
+		b        any
+		expected int
+		ok       bool
+	}{
+		{at, at, 0, true},
+		{at, at.Add(time.Second), -1, true},
+		{at.Add(time.Second), at, 1, true},
+		{at, at.In(est), 0, true},
+		// Strings
+		{at, "2024-01-02T15:04:05Z", 0, true},
+		{at, "2024-01-02T10:04:05-05:00", 0, true},
+		{"2024-01-02T15:04:06Z", at, 1, true},
+		{at, "2024-01-02", 1, true},
+		{time.Date(2024, 1, 2, 0, 0, 0, 0, est), "2024-01-02", 0, true},
+		// Non times
+		{at, "val", 0, false},
+		{at, 1, 0, false},
+		{"2024-01-02", "2024-01-02", 0, false},
+		{nil, at, 0, false},
+		{nil, nil, 0, false},
+	}
+
+	for _, test := range tests {
+		if output, ok := CompareTimes(test.a, test.b); output != test.expected || ok != test.ok {
+			t.Errorf("input (%v, %v): expected %v/%v, got %v/%v", test.a, test.b, test.expected, test.ok, output, ok)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package custom
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	e "github.com/spaceavocado/goillogical/evaluable"
+)
+
+// Custom operator evaluation handler, given the evaluated operands.
+type Handler func([]any) (any, error)
+
+// Custom operator simplification handler, given the simplified operands and the flags whether
+// the given operand has been resolved. Returns the simplified value and whether the expression
+// has been simplified into the value.
+type Simplify func(operands []any, resolved []bool) (any, bool)
+
+// Custom operator definition.
+type Operator struct {
+	Arity    e.Arity
+	Handler  Handler
+	Simplify Simplify
+}
+
+type custom struct {
+	operator string
+	operands []e.Evaluable
+	handler  Handler
+	simplify Simplify
+}
+
+func (c custom) Evaluate(ctx e.Context) (any, error) {
+	var flattenContext = e.FlattenContext(ctx)
+
+	evaluated := make([]any, len(c.operands))
+	for i, o := range c.operands {
+		val, err := o.Evaluate(flattenContext)
+		if err != nil {
+			return nil, err
+		}
+		evaluated[i] = val
+	}
+	return c.handler(evaluated)
+}
+
+func (c custom) EvaluateContext(ctx context.Context, data e.Context) (any, error) {
+	return e.Guard(ctx, func(ctx context.Context) (any, error) {
+		evaluated, err := e.EvaluateOperands(ctx, c.operands, e.FlattenContext(data))
+		if err != nil {
+			return nil, err
+		}
+		return c.handler(evaluated)
+	})
+}
+
+func (c custom) Compile() (e.Func, error) {
+	operands, err := e.CompileFuncs(c.operands)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx e.Context) (any, error) {
+		evaluated := make([]any, len(operands))
+		for i, fn := range operands {
+			val, err := fn(ctx)
+			if err != nil {
+				return nil, err
+			}
+			evaluated[i] = val
+		}
+		return c.handler(evaluated)
+	}, nil
+}
+
+func (c custom) Explain(ctx e.Context) (e.Trace, error) {
+	evaluated, operands, err := e.ExplainOperands(e.FlattenContext(ctx), c.operands)
+	if err != nil {
+		return e.NewTrace(e.Custom, c, nil, err, operands), err
+	}
+	res, err := c.handler(evaluated)
+	return e.NewTrace(e.Custom, c, res, err, operands), err
+}
+
+func (c custom) Kind() e.Kind {
+	return e.Custom
+}
+
+func (c custom) Operator() string {
+	return c.operator
+}
+
+func (c custom) Operands() []e.Evaluable {
+	return c.operands
+}
+
+func (c custom) WithOperands(operands []e.Evaluable) e.Evaluable {
+	c.operands = operands
+	return c
+}
+
+func (c custom) Serialize() any {
+	res := []any{c.operator}
+	for i := 0; i < len(c.operands); i++ {
+		res = append(res, c.operands[i].Serialize())
+	}
+	return res
+}
+
+func (c custom) Simplify(ctx e.Context) (any, e.Evaluable) {
+	var flattenContext = e.FlattenContext(ctx)
+
+	values := make([]any, len(c.operands))
+	resolved := make([]bool, len(c.operands))
+	all := true
+	for i, o := range c.operands {
+		val, eval := o.Simplify(flattenContext)
+		values[i] = val
+		resolved[i] = eval == nil
+		all = all && resolved[i]
+	}
+
+	if c.simplify != nil {
+		if val, ok := c.simplify(values, resolved); ok {
+			return val, nil
+		}
+		return nil, &c
+	}
+
+	if !all {
+		return nil, &c
+	}
+
+	res, err := c.handler(values)
+	if err != nil {
+		return nil, &c
+	}
+	return res, nil
+}
+
+func (c custom) String() string {
+	if len(c.operands) == 0 {
+		return fmt.Sprintf("(%s)", c.operator)
+	}
+
+	res := fmt.Sprintf("(%s %s", c.operands[0].String(), c.operator)
+	if len(c.operands) > 1 {
+		rest := make([]string, len(c.operands)-1)
+		for i, o := range c.operands[1:] {
+			rest[i] = o.String()
+		}
+		res += fmt.Sprintf(" %s", strings.Join(rest, ", "))
+	}
+	return res + ")"
+}
+
+func New(operator string, operands []e.Evaluable, handler Handler, simplify Simplify) (e.Evaluable, error) {
+	return custom{operator, operands, handler, simplify}, nil
+}
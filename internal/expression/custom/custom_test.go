@@ -0,0 +1,184 @@
+package custom
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/spaceavocado/goillogical/evaluable"
+	. "github.com/spaceavocado/goillogical/internal/mock"
+	. "github.com/spaceavocado/goillogical/internal/test"
+)
+
+func sum(evaluated []any) (any, error) {
+	res := 0
+	for _, v := range evaluated {
+		i, ok := v.(int)
+		if !ok {
+			return nil, errors.New("not an integer")
+		}
+		res += i
+	}
+	return res, nil
+}
+
+func TestEvaluate(t *testing.T) {
+	var tests = []struct {
+		operands []Evaluable
+		expected any
+	}{
+		{[]Evaluable{Val(1)}, 1},
+		{[]Evaluable{Val(1), Val(2), Ref("RefA")}, 6},
+	}
+
+	for _, test := range tests {
+		c, _ := New("SUM", test.operands, sum, nil)
+		if output, err := c.Evaluate(map[string]any{"RefA": 3}); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.operands, test.expected, output, err)
+		}
+	}
+
+	errs := []struct {
+		operands []Evaluable
+		expected error
+	}{
+		{[]Evaluable{Val(1), Invalid()}, errors.New("invalid")},
+		{[]Evaluable{Val(1), Val("1")}, errors.New("not an integer")},
+	}
+
+	for _, test := range errs {
+		c, _ := New("SUM", test.operands, sum, nil)
+		if _, err := c.Evaluate(map[string]any{}); err == nil || err.Error() != test.expected.Error() {
+			t.Errorf("input (%v): expected %v, got %v", test.operands, test.expected, err)
+		}
+	}
+}
+
+func TestCompile(t *testing.T) {
+	var tests = []struct {
+		operands []Evaluable
+		expected any
+	}{
+		{[]Evaluable{Val(1), Ref("RefA")}, 4},
+		{[]Evaluable{Val(1), Val(2), Val(3)}, 6},
+	}
+
+	for _, test := range tests {
+		c, _ := New("SUM", test.operands, sum, nil)
+		fn, _ := c.(Compilable).Compile()
+		if output, err := fn(FlattenContext(map[string]any{"RefA": 3})); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.operands, test.expected, output, err)
+		}
+	}
+
+	c, _ := New("SUM", []Evaluable{Val(1), Invalid()}, sum, nil)
+	fn, _ := c.(Compilable).Compile()
+	if _, err := fn(map[string]any{}); err == nil || err.Error() != "invalid" {
+		t.Errorf("input (%v): expected %v, got %v", c, "invalid", err)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	var tests = []struct {
+		operands []Evaluable
+		expected string
+	}{
+		{[]Evaluable{Val(1), Ref("RefA")}, "Custom: (1 SUM {RefA}) => 4\n  Value: 1 => 1\n  Reference: {RefA} => 3, at \"RefA\""},
+		{[]Evaluable{Val(1), Val("1")}, "Custom: (1 SUM \"1\") => error: not an integer\n  Value: 1 => 1\n  Value: \"1\" => \"1\""},
+		{[]Evaluable{Invalid(), Val(1)}, "Custom: (invalid SUM 1) => error: invalid\n  Unknown: invalid => error: invalid\n  Value: 1 => skipped"},
+	}
+
+	for _, test := range tests {
+		c, _ := New("SUM", test.operands, sum, nil)
+		if output, _ := c.(Explainable).Explain(map[string]any{"RefA": 3}); output.String() != test.expected {
+			t.Errorf("input (%v): expected %v, got %v", test.operands, test.expected, output)
+		}
+	}
+}
+
+func TestOperands(t *testing.T) {
+	c, _ := New("SUM", []Evaluable{Val(1), Val(2)}, sum, nil)
+	n := c.(Node)
+	if n.Kind() != Custom || n.Operator() != "SUM" || len(n.Operands()) != 2 {
+		t.Errorf("input (%v): expected Custom node, got %v/%v/%v", c, n.Kind(), n.Operator(), n.Operands())
+	}
+
+	rewritten := n.WithOperands([]Evaluable{Val(3), Val(4)})
+	if output, _ := rewritten.Evaluate(map[string]any{}); output != 7 || c.String() != "(1 SUM 2)" {
+		t.Errorf("input (%v): expected 7, got %v/%v", c, rewritten, output)
+	}
+}
+
+func TestSerialize(t *testing.T) {
+	var tests = []struct {
+		operands []Evaluable
+		expected any
+	}{
+		{[]Evaluable{Val(1)}, []any{"SUM", 1}},
+		{[]Evaluable{Val(1), Ref("RefA")}, []any{"SUM", 1, "$RefA"}},
+	}
+
+	for _, test := range tests {
+		c, _ := New("SUM", test.operands, sum, nil)
+		if output := c.Serialize(); Fprint(output) != Fprint(test.expected) {
+			t.Errorf("input (%v): expected %v, got %v", test.operands, test.expected, output)
+		}
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	ctx := map[string]any{
+		"RefA": 3,
+	}
+
+	custom := func(simplify Simplify, operands ...Evaluable) Evaluable {
+		e, _ := New("SUM", operands, sum, simplify)
+		return e
+	}
+
+	// Resolved only if the missing operand is the first one.
+	first := func(operands []any, resolved []bool) (any, bool) {
+		if !resolved[0] {
+			return -1, true
+		}
+		return nil, false
+	}
+
+	tests := []struct {
+		simplify Simplify
+		input    []Evaluable
+		value    any
+		e        any
+	}{
+		{nil, []Evaluable{Val(1), Ref("RefA")}, 4, nil},
+		{nil, []Evaluable{Val(1), Ref("Missing")}, nil, custom(nil, Val(1), Ref("Missing"))},
+		{nil, []Evaluable{Val(1), Val("1")}, nil, custom(nil, Val(1), Val("1"))},
+		{first, []Evaluable{Ref("Missing"), Val(1)}, -1, nil},
+		{first, []Evaluable{Val(1), Ref("Missing")}, nil, custom(nil, Val(1), Ref("Missing"))},
+	}
+
+	for _, test := range tests {
+		e := custom(test.simplify, test.input...)
+		if value, self := e.Simplify(ctx); Fprint(value) != Fprint(test.value) || Fprint(self) != Fprint(test.e) {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v", test.input, test.value, test.e, value, self)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	var tests = []struct {
+		operands []Evaluable
+		expected string
+	}{
+		{[]Evaluable{}, "(SUM)"},
+		{[]Evaluable{Val(1)}, "(1 SUM)"},
+		{[]Evaluable{Val(1), Ref("RefA")}, "(1 SUM {RefA})"},
+		{[]Evaluable{Val(1), Val(2), Val(3)}, "(1 SUM 2, 3)"},
+	}
+
+	for _, test := range tests {
+		c, _ := New("SUM", test.operands, sum, nil)
+		if output := c.String(); output != test.expected {
+			t.Errorf("input (%v): expected %v, got %v", test.operands, test.expected, output)
+		}
+	}
+}
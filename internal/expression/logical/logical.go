@@ -1,29 +1,93 @@
 package logical
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
 	e "github.com/spaceavocado/goillogical/evaluable"
 )
 
-type Handler func(e.Context, []e.Evaluable) (bool, error)
+// Logical operator evaluation handler, the operands are evaluated with the three-valued logic,
+// which is equal to the boolean logic for the boolean operands.
+type Handler func(e.Context, []e.Evaluable) (e.Truth, error)
 type Simplify func(string, e.Context, []e.Evaluable) (any, e.Evaluable)
 
 type logical struct {
-	kind     string
+	kind     e.Kind
+	op       string
 	operator string
 	operands []e.Evaluable
 	handler  Handler
 	simplify Simplify
+	kleene   bool
+}
+
+// Evaluation result, a boolean value unless evaluated with the three-valued logic, or the result
+// is unknown, i.e. an operand evaluated with the three-valued logic is unknown.
+func (l logical) result(res e.Truth, err error) (any, error) {
+	if err != nil {
+		return false, err
+	}
+	if b, ok := res.Bool(); ok && !l.kleene {
+		return b, nil
+	}
+	return res, nil
 }
 
 func (l logical) Evaluate(ctx e.Context) (any, error) {
-	return l.handler(ctx, l.operands)
+	return l.result(l.handler(ctx, l.operands))
+}
+
+func (l logical) EvaluateContext(ctx context.Context, data e.Context) (any, error) {
+	return e.Guard(ctx, func(ctx context.Context) (any, error) {
+		return l.result(l.handler(data, e.BindOperands(ctx, l.operands)))
+	})
+}
+
+func (l logical) Compile() (e.Func, error) {
+	operands, err := e.CompileOperands(l.operands)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx e.Context) (any, error) {
+		return l.result(l.handler(ctx, operands))
+	}, nil
+}
+
+func (l logical) Explain(ctx e.Context) (e.Trace, error) {
+	operands, traces := e.TraceOperands(l.operands)
+	res, err := l.result(l.handler(ctx, operands))
+	return e.NewTrace(l.kind, l, res, err, traces()), err
+}
+
+func (l logical) Kind() e.Kind {
+	return l.kind
+}
+
+func (l logical) Operator() string {
+	return l.op
+}
+
+func (l logical) Operands() []e.Evaluable {
+	return l.operands
+}
+
+func (l logical) WithOperands(operands []e.Evaluable) e.Evaluable {
+	l.operands = operands
+	return l
+}
+
+// Get the three-valued form of the logical expression, i.e. evaluated into a Truth value, see
+// evaluable.Truth.
+func (l logical) ThreeValued() e.Evaluable {
+	l.kleene = true
+	return l
 }
 
 func (l logical) Serialize() any {
-	res := []any{l.kind}
+	res := []any{l.op}
 	for i := 0; i < len(l.operands); i++ {
 		res = append(res, l.operands[i].Serialize())
 	}
@@ -31,10 +95,14 @@ func (l logical) Serialize() any {
 }
 
 func (l logical) Simplify(ctx e.Context) (any, e.Evaluable) {
-	return l.simplify(l.kind, ctx, l.operands)
+	return l.simplify(l.op, ctx, l.operands)
 }
 
 func (l logical) String() string {
+	if len(l.operands) == 1 {
+		return fmt.Sprintf("(%s %s)", l.operator, l.operands[0].String())
+	}
+
 	res := "("
 	for i := 0; i < len(l.operands); i++ {
 		res += l.operands[i].String()
@@ -45,19 +113,19 @@ func (l logical) String() string {
 	return res + ")"
 }
 
-func Evaluate(ctx e.Context, o e.Evaluable) (bool, error) {
+// Evaluate the operand into a truth value, the operand must be evaluated into a boolean, or a
+// Truth value.
+func Evaluate(ctx e.Context, o e.Evaluable) (e.Truth, error) {
 	res, err := o.Evaluate(ctx)
 	if err != nil {
-		return false, err
+		return e.TruthFalse, err
 	}
-	switch typed := res.(type) {
-	case bool:
-		return typed, nil
-	default:
-		return false, errors.New("invalid evaluated operand, must be boolean value")
+	if t, ok := e.ToTruth(res); ok {
+		return t, nil
 	}
+	return e.TruthFalse, errors.New("invalid evaluated operand, must be boolean value")
 }
 
-func New(kind string, op string, operands []e.Evaluable, handler Handler, simplify Simplify) (e.Evaluable, error) {
-	return logical{kind, op, operands, handler, simplify}, nil
+func New(kind e.Kind, op string, operator string, operands []e.Evaluable, handler Handler, simplify Simplify) (e.Evaluable, error) {
+	return logical{kind, op, operator, operands, handler, simplify, false}, nil
 }
@@ -20,13 +20,13 @@ func TestEvaluate(t *testing.T) {
 		{"AND", []Evaluable{Val(false), Val(false)}, false},
 	}
 
-	handler := func(ctx Context, operands []Evaluable) (bool, error) {
+	handler := func(ctx Context, operands []Evaluable) (Truth, error) {
 		return Evaluate(ctx, operands[0])
 	}
 	simplify := func(string, Context, []Evaluable) (any, Evaluable) { return nil, nil }
 
 	for _, test := range tests {
-		l, _ := New("Unknown", test.op, test.operands, handler, simplify)
+		l, _ := New(Unknown, "Unknown", test.op, test.operands, handler, simplify)
 		if output, err := l.Evaluate(map[string]any{}); output != test.expected || err != nil {
 			t.Errorf("input (%v): expected %v, got %v/%v", test.operands, test.expected, output, err)
 		}
@@ -46,6 +46,115 @@ func TestEvaluate(t *testing.T) {
 	}
 }
 
+func TestThreeValued(t *testing.T) {
+	var tests = []struct {
+		operand  Evaluable
+		kleene   bool
+		expected any
+	}{
+		{Val(true), false, true},
+		{ValTruth(TruthFalse), false, false},
+		{ValTruth(TruthUnknown), false, TruthUnknown},
+		{Val(true), true, TruthTrue},
+		{ValTruth(TruthUnknown), true, TruthUnknown},
+	}
+
+	handler := func(ctx Context, operands []Evaluable) (Truth, error) {
+		return Evaluate(ctx, operands[0])
+	}
+	simplify := func(string, Context, []Evaluable) (any, Evaluable) { return nil, nil }
+
+	for _, test := range tests {
+		l, _ := New(And, "AND", "AND", []Evaluable{test.operand}, handler, simplify)
+		if test.kleene {
+			l = l.(ThreeValued).ThreeValued()
+		}
+		if output, err := l.Evaluate(map[string]any{}); output != test.expected || err != nil {
+			t.Errorf("input (%v, %v): expected %v, got %v/%v", test.operand, test.kleene, test.expected, output, err)
+		}
+	}
+}
+
+func TestCompile(t *testing.T) {
+	var tests = []struct {
+		operands []Evaluable
+		expected any
+	}{
+		{[]Evaluable{Val(true), Ref("RefA")}, true},
+		{[]Evaluable{Ref("RefB"), Val(true)}, false},
+	}
+
+	handler := func(ctx Context, operands []Evaluable) (Truth, error) {
+		for _, o := range operands {
+			if res, err := Evaluate(ctx, o); err != nil || res != TruthTrue {
+				return TruthFalse, err
+			}
+		}
+		return TruthTrue, nil
+	}
+	simplify := func(string, Context, []Evaluable) (any, Evaluable) { return nil, nil }
+
+	for _, test := range tests {
+		l, _ := New(Unknown, "Unknown", "AND", test.operands, handler, simplify)
+		fn, _ := l.(Compilable).Compile()
+		if output, err := fn(FlattenContext(map[string]any{"RefA": true, "RefB": false})); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.operands, test.expected, output, err)
+		}
+	}
+
+	l, _ := New(Unknown, "Unknown", "AND", []Evaluable{Val(true), Invalid()}, handler, simplify)
+	fn, _ := l.(Compilable).Compile()
+	if _, err := fn(map[string]any{}); err == nil || err.Error() != "invalid" {
+		t.Errorf("input (%v): expected %v, got %v", l, "invalid", err)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	var tests = []struct {
+		operands []Evaluable
+		expected string
+	}{
+		{[]Evaluable{Val(true), Ref("RefA")}, "And: (true AND {RefA}) => true\n  Value: true => true\n  Reference: {RefA} => true, at \"RefA\""},
+		{[]Evaluable{Val(false), Ref("RefA")}, "And: (false AND {RefA}) => false\n  Value: false => false\n  Reference: {RefA} => skipped"},
+		{[]Evaluable{Invalid(), Val(true)}, "And: (invalid AND true) => error: invalid\n  Unknown: invalid => error: invalid\n  Value: true => skipped"},
+	}
+
+	handler := func(ctx Context, operands []Evaluable) (Truth, error) {
+		for _, o := range operands {
+			if res, err := Evaluate(ctx, o); err != nil || res != TruthTrue {
+				return TruthFalse, err
+			}
+		}
+		return TruthTrue, nil
+	}
+	simplify := func(string, Context, []Evaluable) (any, Evaluable) { return nil, nil }
+
+	for _, test := range tests {
+		l, _ := New(And, "AND", "AND", test.operands, handler, simplify)
+		if output, _ := l.(Explainable).Explain(map[string]any{"RefA": true}); output.String() != test.expected {
+			t.Errorf("input (%v): expected %v, got %v", test.operands, test.expected, output)
+		}
+	}
+}
+
+func TestOperands(t *testing.T) {
+	handler := func(ctx Context, operands []Evaluable) (Truth, error) {
+		return Evaluate(ctx, operands[0])
+	}
+	simplify := func(string, Context, []Evaluable) (any, Evaluable) { return nil, nil }
+
+	l, _ := New(And, "AND", "AND", []Evaluable{Val(true), Val(false)}, handler, simplify)
+	n := l.(Node)
+	if n.Kind() != And || n.Operator() != "AND" || len(n.Operands()) != 2 {
+		t.Errorf("input (%v): expected And node, got %v/%v/%v", l, n.Kind(), n.Operator(), n.Operands())
+	}
+
+	rewritten := n.WithOperands([]Evaluable{Val(false), Val(true)})
+	if output, _ := rewritten.Evaluate(map[string]any{}); output != false || rewritten.String() != "(false AND true)" || l.String() != "(true AND false)" {
+		t.Errorf("input (%v): expected (false AND true), got %v/%v", l, rewritten, output)
+	}
+}
+
 func TestSimplify(t *testing.T) {
 	var tests = []struct {
 		op       string
@@ -55,13 +164,13 @@ func TestSimplify(t *testing.T) {
 		{"AND", []Evaluable{Val(true), Val(true)}, true},
 	}
 
-	handler := func(ctx Context, operands []Evaluable) (bool, error) {
-		return true, nil
+	handler := func(ctx Context, operands []Evaluable) (Truth, error) {
+		return TruthTrue, nil
 	}
 	simplify := func(string, Context, []Evaluable) (any, Evaluable) { return true, nil }
 
 	for _, test := range tests {
-		l, _ := New("Unknown", test.op, test.operands, handler, simplify)
+		l, _ := New(Unknown, "Unknown", test.op, test.operands, handler, simplify)
 		if output, err := l.Simplify(map[string]any{}); output != test.expected || err != nil {
 			t.Errorf("input (%v): expected %v, got %v/%v", test.operands, test.expected, output, err)
 		}
@@ -79,7 +188,7 @@ func TestSerialize(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		l, _ := New(test.op, test.op, test.operands, func(Context, []Evaluable) (bool, error) { return false, nil }, func(string, Context, []Evaluable) (any, Evaluable) { return nil, nil })
+		l, _ := New(Unknown, test.op, test.op, test.operands, func(Context, []Evaluable) (Truth, error) { return TruthFalse, nil }, func(string, Context, []Evaluable) (any, Evaluable) { return nil, nil })
 		if output := l.Serialize(); Fprint(output) != Fprint(test.expected) {
 			t.Errorf("input (%v, %v): expected %v, got %v", test.op, test.operands, test.expected, output)
 		}
@@ -94,10 +203,11 @@ func TestString(t *testing.T) {
 	}{
 		{"AND", []Evaluable{Val("e1"), Val("e2")}, "(\"e1\" AND \"e2\")"},
 		{"AND", []Evaluable{Val("e1"), Val("e2"), Val("e1")}, "(\"e1\" AND \"e2\" AND \"e1\")"},
+		{"NOT", []Evaluable{Val(true)}, "(NOT true)"},
 	}
 
 	for _, test := range tests {
-		c, _ := New("Unknown", test.op, test.operands, func(ctx Context, evaluated []Evaluable) (bool, error) { return false, nil }, func(string, Context, []Evaluable) (any, Evaluable) { return nil, nil })
+		c, _ := New(Unknown, "Unknown", test.op, test.operands, func(ctx Context, evaluated []Evaluable) (Truth, error) { return TruthFalse, nil }, func(string, Context, []Evaluable) (any, Evaluable) { return nil, nil })
 		if output := c.String(); output != test.expected {
 			t.Errorf("input (%v, %v): expected %v, got %v", test.op, test.operands, test.expected, output)
 		}
@@ -1,26 +1,26 @@
 package nor
 
 import (
-	"errors"
-
 	e "github.com/spaceavocado/goillogical/evaluable"
 	l "github.com/spaceavocado/goillogical/internal/expression/logical"
 	not "github.com/spaceavocado/goillogical/internal/expression/logical/not"
 )
 
-func handler(ctx e.Context, operands []e.Evaluable) (bool, error) {
+func handler(ctx e.Context, operands []e.Evaluable) (e.Truth, error) {
 	var flattenContext = e.FlattenContext(ctx)
 
+	res := e.TruthTrue
 	for _, o := range operands {
-		res, err := l.Evaluate(flattenContext, o)
+		t, err := l.Evaluate(flattenContext, o)
 		if err != nil {
-			return false, err
+			return e.TruthFalse, err
 		}
-		if res {
-			return false, nil
+		if t == e.TruthTrue {
+			return e.TruthFalse, nil
 		}
+		res = res.And(t.Not())
 	}
-	return true, nil
+	return res, nil
 }
 
 func simplify(operator string, ctx e.Context, operands []e.Evaluable, notOp string) (any, e.Evaluable) {
@@ -55,10 +55,10 @@ func simplify(operator string, ctx e.Context, operands []e.Evaluable, notOp stri
 // not reference needed
 func New(operator string, operands []e.Evaluable, notOp string, norOp string) (e.Evaluable, error) {
 	if len(operands) < 2 {
-		return nil, errors.New("logical NOR expression must have at least 2 operands")
+		return nil, e.NewArityError(e.Arity{Min: 2, Max: -1}, "logical NOR expression must have at least 2 operands")
 	}
 
-	return l.New(operator, "NOR", operands, handler, func(operator string, ctx map[string]any, operands []e.Evaluable) (any, e.Evaluable) {
+	return l.New(e.Nor, operator, "NOR", operands, handler, func(operator string, ctx map[string]any, operands []e.Evaluable) (any, e.Evaluable) {
 		return simplify(operator, ctx, operands, notOp)
 	})
 }
@@ -5,15 +5,15 @@ import (
 	l "github.com/spaceavocado/goillogical/internal/expression/logical"
 )
 
-func handler(ctx e.Context, operands []e.Evaluable) (bool, error) {
+func handler(ctx e.Context, operands []e.Evaluable) (e.Truth, error) {
 	var flattenContext = e.FlattenContext(ctx)
 
 	res, err := l.Evaluate(flattenContext, operands[0])
 	if err != nil {
-		return false, err
+		return e.TruthFalse, err
 	}
 
-	return !res, nil
+	return res.Not(), nil
 }
 
 func simplify(operator string, ctx e.Context, operands []e.Evaluable) (any, e.Evaluable) {
@@ -33,5 +33,5 @@ func simplify(operator string, ctx e.Context, operands []e.Evaluable) (any, e.Ev
 }
 
 func New(operator string, operand e.Evaluable) (e.Evaluable, error) {
-	return l.New(operator, "NOT", []e.Evaluable{operand}, handler, simplify)
+	return l.New(e.Not, operator, "NOT", []e.Evaluable{operand}, handler, simplify)
 }
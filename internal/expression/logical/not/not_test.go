@@ -55,6 +55,24 @@ func TestHandler(t *testing.T) {
 	}
 }
 
+func TestThreeValued(t *testing.T) {
+	var tests = []struct {
+		operands []Evaluable
+		expected Truth
+	}{
+		{[]Evaluable{ValTruth(TruthUnknown)}, TruthUnknown},
+		{[]Evaluable{ValTruth(TruthTrue)}, TruthFalse},
+		{[]Evaluable{Val(false)}, TruthTrue},
+	}
+
+	for _, test := range tests {
+		l, _ := New("NOT", test.operands[0])
+		if output, err := l.(ThreeValued).ThreeValued().Evaluate(map[string]any{}); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.operands, test.expected, output, err)
+		}
+	}
+}
+
 func TestSimplify(t *testing.T) {
 	ctx := map[string]any{
 		"RefA": true,
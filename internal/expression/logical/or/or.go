@@ -1,25 +1,25 @@
 package or
 
 import (
-	"errors"
-
 	e "github.com/spaceavocado/goillogical/evaluable"
 	l "github.com/spaceavocado/goillogical/internal/expression/logical"
 )
 
-func handler(ctx e.Context, operands []e.Evaluable) (bool, error) {
+func handler(ctx e.Context, operands []e.Evaluable) (e.Truth, error) {
 	var flattenContext = e.FlattenContext(ctx)
 
+	res := e.TruthFalse
 	for _, o := range operands {
-		res, err := l.Evaluate(flattenContext, o)
+		t, err := l.Evaluate(flattenContext, o)
 		if err != nil {
-			return false, err
+			return e.TruthFalse, err
 		}
-		if res {
-			return true, nil
+		if t == e.TruthTrue {
+			return e.TruthTrue, nil
 		}
+		res = res.Or(t)
 	}
-	return false, nil
+	return res, nil
 }
 
 func simplify(operator string, ctx e.Context, operands []e.Evaluable) (any, e.Evaluable) {
@@ -52,10 +52,10 @@ func simplify(operator string, ctx e.Context, operands []e.Evaluable) (any, e.Ev
 
 func New(operator string, operands []e.Evaluable, notOp string, norOp string) (e.Evaluable, error) {
 	if len(operands) < 2 {
-		return nil, errors.New("logical OR expression must have at least 2 operands")
+		return nil, e.NewArityError(e.Arity{Min: 2, Max: -1}, "logical OR expression must have at least 2 operands")
 	}
 
-	return l.New(operator, "OR", operands, handler, func(operator string, ctx map[string]any, operands []e.Evaluable) (any, e.Evaluable) {
+	return l.New(e.Or, operator, "OR", operands, handler, func(operator string, ctx map[string]any, operands []e.Evaluable) (any, e.Evaluable) {
 		return simplify(operator, ctx, operands)
 	})
 }
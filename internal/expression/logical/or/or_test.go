@@ -38,7 +38,7 @@ func TestHandler(t *testing.T) {
 
 	for _, test := range errs {
 
-		if _, err := New("OR", test.operands, "NOT", "NOR"); err.Error() != test.expected.Error() {
+		if _, err := New("OR", test.operands, "NOT", "NOR"); err.Error() != test.expected.Error() || !errors.Is(err, ErrOperatorArity) {
 			t.Errorf("input (%v): expected %v, got %v", test.operands, test.expected, err)
 		}
 	}
@@ -58,6 +58,25 @@ func TestHandler(t *testing.T) {
 	}
 }
 
+func TestThreeValued(t *testing.T) {
+	var tests = []struct {
+		operands []Evaluable
+		expected Truth
+	}{
+		{[]Evaluable{Val(false), ValTruth(TruthUnknown)}, TruthUnknown},
+		{[]Evaluable{ValTruth(TruthUnknown), Val(true)}, TruthTrue},
+		{[]Evaluable{Val(false), ValTruth(TruthFalse)}, TruthFalse},
+		{[]Evaluable{ValTruth(TruthUnknown), ValTruth(TruthUnknown)}, TruthUnknown},
+	}
+
+	for _, test := range tests {
+		l, _ := New("OR", test.operands, "NOT", "NOR")
+		if output, err := l.(ThreeValued).ThreeValued().Evaluate(map[string]any{}); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.operands, test.expected, output, err)
+		}
+	}
+}
+
 func TestSimplify(t *testing.T) {
 	ctx := map[string]any{
 		"RefA": true,
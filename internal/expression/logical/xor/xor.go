@@ -1,38 +1,40 @@
 package xor
 
 import (
-	"errors"
-
 	e "github.com/spaceavocado/goillogical/evaluable"
 	l "github.com/spaceavocado/goillogical/internal/expression/logical"
 	nor "github.com/spaceavocado/goillogical/internal/expression/logical/nor"
 	not "github.com/spaceavocado/goillogical/internal/expression/logical/not"
 )
 
-func handler(ctx e.Context, operands []e.Evaluable) (bool, error) {
+// Exactly one of the operands is true, unknown if any of the operands is unknown, unless more
+// than one of the operands is true.
+func handler(ctx e.Context, operands []e.Evaluable) (e.Truth, error) {
 	var flattenContext = e.FlattenContext(ctx)
-	var xor bool
+	truthy, unknown := 0, false
 
-	for i, o := range operands {
+	for _, o := range operands {
 		res, err := l.Evaluate(flattenContext, o)
 		if err != nil {
-			return false, err
+			return e.TruthFalse, err
 		}
 
-		if i == 0 {
-			xor = res
-			continue
+		switch res {
+		case e.TruthTrue:
+			truthy++
+		case e.TruthUnknown:
+			unknown = true
 		}
 
-		if xor && res {
-			return false, nil
+		if truthy > 1 {
+			return e.TruthFalse, nil
 		}
+	}
 
-		if res {
-			xor = true
-		}
+	if unknown {
+		return e.TruthUnknown, nil
 	}
-	return xor, nil
+	return e.TruthOf(truthy == 1), nil
 }
 
 func simplify(operator string, ctx e.Context, operands []e.Evaluable, notOp string, norOp string) (any, e.Evaluable) {
@@ -78,10 +80,10 @@ func simplify(operator string, ctx e.Context, operands []e.Evaluable, notOp stri
 
 func New(operator string, operands []e.Evaluable, notOp string, norOp string) (e.Evaluable, error) {
 	if len(operands) < 2 {
-		return nil, errors.New("logical XOR expression must have at least 2 operands")
+		return nil, e.NewArityError(e.Arity{Min: 2, Max: -1}, "logical XOR expression must have at least 2 operands")
 	}
 
-	return l.New(operator, "XOR", operands, handler, func(operator string, ctx map[string]any, operands []e.Evaluable) (any, e.Evaluable) {
+	return l.New(e.Xor, operator, "XOR", operands, handler, func(operator string, ctx map[string]any, operands []e.Evaluable) (any, e.Evaluable) {
 		return simplify(operator, ctx, operands, notOp, norOp)
 	})
 }
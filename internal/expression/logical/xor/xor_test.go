@@ -48,7 +48,7 @@ func TestHandler(t *testing.T) {
 
 	for _, test := range errs {
 
-		if _, err := New("XOR", test.operands, "NOT", "NOR"); err.Error() != test.expected.Error() {
+		if _, err := New("XOR", test.operands, "NOT", "NOR"); err.Error() != test.expected.Error() || !errors.Is(err, ErrOperatorArity) {
 			t.Errorf("input (%v): expected %v, got %v", test.operands, test.expected, err)
 		}
 	}
@@ -68,6 +68,26 @@ func TestHandler(t *testing.T) {
 	}
 }
 
+func TestThreeValued(t *testing.T) {
+	var tests = []struct {
+		operands []Evaluable
+		expected Truth
+	}{
+		{[]Evaluable{Val(true), ValTruth(TruthUnknown)}, TruthUnknown},
+		{[]Evaluable{Val(false), ValTruth(TruthUnknown)}, TruthUnknown},
+		{[]Evaluable{Val(true), ValTruth(TruthUnknown), Val(true)}, TruthFalse},
+		{[]Evaluable{Val(true), ValTruth(TruthFalse)}, TruthTrue},
+		{[]Evaluable{ValTruth(TruthTrue), ValTruth(TruthTrue)}, TruthFalse},
+	}
+
+	for _, test := range tests {
+		l, _ := New("XOR", test.operands, "NOT", "NOR")
+		if output, err := l.(ThreeValued).ThreeValued().Evaluate(map[string]any{}); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.operands, test.expected, output, err)
+		}
+	}
+}
+
 func TestSimplify(t *testing.T) {
 	ctx := map[string]any{
 		"RefA": true,
@@ -0,0 +1,24 @@
+package all
+
+import (
+	e "github.com/spaceavocado/goillogical/evaluable"
+	q "github.com/spaceavocado/goillogical/internal/expression/quantifier"
+)
+
+// An empty collection is matched, i.e. vacuous truth.
+func handler(matched int, total int) any {
+	return matched == total
+}
+
+// Short-circuited by the first element which is not matching.
+func short(res bool) (any, bool) {
+	return false, !res
+}
+
+func New(operator string, alias string, operands []e.Evaluable) (e.Evaluable, error) {
+	if len(operands) != 2 {
+		return nil, e.NewArityError(e.Arity{Min: 2, Max: 2}, "quantifier ALL expression must have 2 operands")
+	}
+
+	return q.New(e.All, operator, "<all>", alias, operands, handler, short)
+}
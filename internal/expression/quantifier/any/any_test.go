@@ -0,0 +1,50 @@
+package any
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/spaceavocado/goillogical/evaluable"
+	eq "github.com/spaceavocado/goillogical/internal/expression/comparison/eq"
+	. "github.com/spaceavocado/goillogical/internal/mock"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := map[string]any{
+		"items": []any{1, 2, 2},
+	}
+	matches := func(val any) Evaluable {
+		return ExpBinary("==", eq.New, Ref("item"), Val(val))
+	}
+
+	var tests = []struct {
+		operands []Evaluable
+		expected any
+	}{
+		{[]Evaluable{Ref("items"), matches(2)}, true},
+		{[]Evaluable{Ref("items"), matches(3)}, false},
+		{[]Evaluable{Ref("missing"), matches(1)}, false},
+		{[]Evaluable{Col(Val(3), Val(1)), matches(1)}, true},
+	}
+
+	for _, test := range tests {
+		q, _ := New("ANY", "item", test.operands)
+		if output, err := q.Evaluate(ctx); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.operands, test.expected, output, err)
+		}
+	}
+
+	errs := []struct {
+		operands []Evaluable
+		expected error
+	}{
+		{[]Evaluable{}, errors.New("quantifier ANY expression must have 2 operands")},
+		{[]Evaluable{Ref("items"), matches(1), Val(1)}, errors.New("quantifier ANY expression must have 2 operands")},
+	}
+
+	for _, test := range errs {
+		if _, err := New("ANY", "item", test.operands); err == nil || err.Error() != test.expected.Error() || !errors.Is(err, ErrOperatorArity) {
+			t.Errorf("input (%v): expected %v, got %v", test.operands, test.expected, err)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package count
+
+import (
+	e "github.com/spaceavocado/goillogical/evaluable"
+	q "github.com/spaceavocado/goillogical/internal/expression/quantifier"
+)
+
+// Number of the matching elements, each element is matched if the predicate is not given.
+func handler(matched int, total int) any {
+	return matched
+}
+
+// Never short-circuited.
+func short(bool) (any, bool) {
+	return nil, false
+}
+
+func New(operator string, alias string, operands []e.Evaluable) (e.Evaluable, error) {
+	if len(operands) < 1 || len(operands) > 2 {
+		return nil, e.NewArityError(e.Arity{Min: 1, Max: 2}, "quantifier COUNT expression must have 1 or 2 operands")
+	}
+
+	return q.New(e.Count, operator, "<count>", alias, operands, handler, short)
+}
@@ -0,0 +1,51 @@
+package count
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/spaceavocado/goillogical/evaluable"
+	eq "github.com/spaceavocado/goillogical/internal/expression/comparison/eq"
+	. "github.com/spaceavocado/goillogical/internal/mock"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := map[string]any{
+		"items": []any{1, 2, 2},
+	}
+	matches := func(val any) Evaluable {
+		return ExpBinary("==", eq.New, Ref("item"), Val(val))
+	}
+
+	var tests = []struct {
+		operands []Evaluable
+		expected any
+	}{
+		{[]Evaluable{Ref("items")}, 3},
+		{[]Evaluable{Ref("items"), matches(2)}, 2},
+		{[]Evaluable{Ref("items"), matches(3)}, 0},
+		{[]Evaluable{Ref("missing")}, 0},
+		{[]Evaluable{Col(Val(3), Val(1)), matches(1)}, 1},
+	}
+
+	for _, test := range tests {
+		q, _ := New("COUNT", "item", test.operands)
+		if output, err := q.Evaluate(ctx); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.operands, test.expected, output, err)
+		}
+	}
+
+	errs := []struct {
+		operands []Evaluable
+		expected error
+	}{
+		{[]Evaluable{}, errors.New("quantifier COUNT expression must have 1 or 2 operands")},
+		{[]Evaluable{Ref("items"), matches(1), Val(1)}, errors.New("quantifier COUNT expression must have 1 or 2 operands")},
+	}
+
+	for _, test := range errs {
+		if _, err := New("COUNT", "item", test.operands); err == nil || err.Error() != test.expected.Error() || !errors.Is(err, ErrOperatorArity) {
+			t.Errorf("input (%v): expected %v, got %v", test.operands, test.expected, err)
+		}
+	}
+}
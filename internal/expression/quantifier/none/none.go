@@ -0,0 +1,23 @@
+package none
+
+import (
+	e "github.com/spaceavocado/goillogical/evaluable"
+	q "github.com/spaceavocado/goillogical/internal/expression/quantifier"
+)
+
+func handler(matched int, total int) any {
+	return matched == 0
+}
+
+// Short-circuited by the first matching element.
+func short(res bool) (any, bool) {
+	return false, res
+}
+
+func New(operator string, alias string, operands []e.Evaluable) (e.Evaluable, error) {
+	if len(operands) != 2 {
+		return nil, e.NewArityError(e.Arity{Min: 2, Max: 2}, "quantifier NONE expression must have 2 operands")
+	}
+
+	return q.New(e.None, operator, "<none>", alias, operands, handler, short)
+}
@@ -0,0 +1,346 @@
+package quantifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	e "github.com/spaceavocado/goillogical/evaluable"
+)
+
+// Quantifier result, given the number of the elements matching the predicate and the number of
+// all the elements.
+type Handler func(matched int, total int) any
+
+// Short-circuit result of the quantifier, given the predicate result of an element, returns
+// false if the remaining elements must be evaluated.
+type Short func(bool) (any, bool)
+
+// Reference operand able to resolve its path, i.e. the collection is looked up element by
+// element, rather than evaluated as a whole.
+type pathResolver interface {
+	ResolvePath(e.Context) (string, bool, error)
+}
+
+type quantifier struct {
+	kind     e.Kind
+	op       string
+	operator string
+	alias    string
+	operands []e.Evaluable
+	handler  Handler
+	short    Short
+	kleene   bool
+}
+
+// Collection operand.
+func (q quantifier) collection() e.Evaluable {
+	return q.operands[0]
+}
+
+// Predicate operand, nil if not given, i.e. each element is matched.
+func (q quantifier) predicate() e.Evaluable {
+	if len(q.operands) < 2 {
+		return nil
+	}
+	return q.operands[1]
+}
+
+// Get the scoped data context of each element of the collection, a collection which could not
+// be found is considered empty. A reference to an array is scoped element by element, while a
+// reference selecting multiple values, e.g. "$items[*].tags[0]", is scoped by the values. The
+// number of the elements is checked against the evaluation budget carried by the guard context,
+// see evaluable.GuardSize.
+func (q quantifier) scopes(guard context.Context, ctx e.Context, collection e.Func) ([]e.Context, error) {
+	if r, ok := q.collection().(pathResolver); ok {
+		path, found, err := r.ResolvePath(ctx)
+		if err != nil || !found {
+			return nil, err
+		}
+		n, found, err := e.Length(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			if err := e.GuardSize(guard, n); err != nil {
+				return nil, err
+			}
+			return elementScopes(ctx, q.alias, path, n), nil
+		}
+		val, err := collection(ctx)
+		if _, ok := val.([]any); !ok || err != nil {
+			return nil, err
+		}
+		return valueScopes(guard, ctx, q.alias, val)
+	}
+
+	val, err := collection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return valueScopes(guard, ctx, q.alias, val)
+}
+
+func elementScopes(ctx e.Context, alias string, path string, n int) []e.Context {
+	res := make([]e.Context, n)
+	for i := range res {
+		res[i] = e.ScopeContext(ctx, alias, fmt.Sprintf("%s[%d]", path, i))
+	}
+	return res
+}
+
+func valueScopes(guard context.Context, ctx e.Context, alias string, val any) ([]e.Context, error) {
+	if val == nil {
+		return nil, nil
+	}
+	items, ok := val.([]any)
+	if !ok {
+		return nil, errors.New("invalid evaluated collection, must be a collection")
+	}
+	if err := e.GuardSize(guard, len(items)); err != nil {
+		return nil, err
+	}
+
+	res := make([]e.Context, len(items))
+	for i, item := range items {
+		res[i] = e.ScopeValue(ctx, alias, item)
+	}
+	return res, nil
+}
+
+func (q quantifier) evaluate(guard context.Context, ctx e.Context, collection e.Func, predicate e.Func) (any, error) {
+	scopes, err := q.scopes(guard, ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	matched, unknown := 0, 0
+	for _, scope := range scopes {
+		res := e.TruthTrue
+		if predicate != nil {
+			if res, err = toTruth(predicate(scope)); err != nil {
+				return nil, err
+			}
+		}
+		if res == e.TruthUnknown {
+			unknown++
+			continue
+		}
+		if val, ok := q.short(res == e.TruthTrue); ok {
+			return q.result(val), nil
+		}
+		if res == e.TruthTrue {
+			matched++
+		}
+	}
+	return q.result(q.outcome(matched, unknown, len(scopes))), nil
+}
+
+// Quantifier result given the number of the matched, and the unknown elements, i.e. the elements
+// of which the predicate is evaluated as unknown with the three-valued logic. The boolean result
+// is unknown if it would differ depending on the unknown elements, the non-boolean result counts
+// only the matched elements.
+func (q quantifier) outcome(matched int, unknown int, total int) any {
+	res := q.handler(matched, total)
+	if _, ok := res.(bool); ok && unknown > 0 && res != q.handler(matched+unknown, total) {
+		return e.TruthUnknown
+	}
+	return res
+}
+
+// Evaluation result, the boolean result is a Truth value if evaluated with the three-valued
+// logic.
+func (q quantifier) result(res any) any {
+	if b, ok := res.(bool); ok && q.kleene {
+		return e.TruthOf(b)
+	}
+	return res
+}
+
+func (q quantifier) Evaluate(ctx e.Context) (any, error) {
+	var predicate e.Func
+	if p := q.predicate(); p != nil {
+		predicate = p.Evaluate
+	}
+	return q.evaluate(context.Background(), e.FlattenContext(ctx), q.collection().Evaluate, predicate)
+}
+
+func (q quantifier) EvaluateContext(ctx context.Context, data e.Context) (any, error) {
+	return e.Guard(ctx, func(ctx context.Context) (any, error) {
+		evaluate := func(operand e.Evaluable) e.Func {
+			return func(data e.Context) (any, error) {
+				return operand.EvaluateContext(ctx, data)
+			}
+		}
+
+		var predicate e.Func
+		if p := q.predicate(); p != nil {
+			predicate = evaluate(p)
+		}
+		return q.evaluate(ctx, e.FlattenContext(data), evaluate(q.collection()), predicate)
+	})
+}
+
+func (q quantifier) Compile() (e.Func, error) {
+	collection, err := e.Compile(q.collection())
+	if err != nil {
+		return nil, err
+	}
+
+	var predicate e.Func
+	if p := q.predicate(); p != nil {
+		if predicate, err = e.Compile(p); err != nil {
+			return nil, err
+		}
+	}
+
+	return func(ctx e.Context) (any, error) {
+		return q.evaluate(context.Background(), ctx, collection, predicate)
+	}, nil
+}
+
+// Explain the collection, and the predicate of each of the elements, the elements following the
+// short-circuiting are not traced.
+func (q quantifier) Explain(ctx e.Context) (e.Trace, error) {
+	var flattenContext = e.FlattenContext(ctx)
+
+	trace, err := e.Explain(q.collection(), flattenContext)
+	traces := []e.Trace{trace}
+	if err != nil {
+		return e.NewTrace(q.kind, q, nil, err, traces), err
+	}
+
+	var predicate e.Func
+	if p := q.predicate(); p != nil {
+		predicate = func(scope e.Context) (any, error) {
+			trace, err := e.Explain(p, scope)
+			traces = append(traces, trace)
+			return trace.Value, err
+		}
+	}
+	res, err := q.evaluate(context.Background(), flattenContext, q.collection().Evaluate, predicate)
+	return e.NewTrace(q.kind, q, res, err, traces), err
+}
+
+func (q quantifier) Kind() e.Kind {
+	return q.kind
+}
+
+func (q quantifier) Operator() string {
+	return q.op
+}
+
+func (q quantifier) Operands() []e.Evaluable {
+	return q.operands
+}
+
+func (q quantifier) WithOperands(operands []e.Evaluable) e.Evaluable {
+	q.operands = operands
+	return q
+}
+
+// Get the three-valued form of the quantifier, i.e. evaluated into a Truth value, see
+// evaluable.Truth.
+func (q quantifier) ThreeValued() e.Evaluable {
+	q.kleene = true
+	return q
+}
+
+// Get the alias the elements of the collection are bound to.
+func (q quantifier) Alias() string {
+	return q.alias
+}
+
+func (q quantifier) Serialize() any {
+	res := []any{q.op}
+	for _, o := range q.operands {
+		res = append(res, o.Serialize())
+	}
+	return res
+}
+
+// Get the scoped data context of each element of the collection, false if the collection is not
+// known in the given context.
+func (q quantifier) simplifyScopes(ctx e.Context) ([]e.Context, bool) {
+	if r, ok := q.collection().(pathResolver); ok {
+		path, found, err := r.ResolvePath(ctx)
+		if err != nil || !found {
+			return nil, false
+		}
+		n, found, err := e.Length(ctx, path)
+		if err != nil {
+			return nil, false
+		}
+		if found {
+			return elementScopes(ctx, q.alias, path, n), true
+		}
+	}
+
+	val, self := q.collection().Simplify(ctx)
+	if self != nil {
+		return nil, false
+	}
+	scopes, err := valueScopes(context.Background(), ctx, q.alias, val)
+	return scopes, err == nil
+}
+
+// Fold the quantifier if the collection, and the predicate of each of the elements, are known,
+// or the quantifier is short-circuited by a known element.
+func (q quantifier) Simplify(ctx e.Context) (any, e.Evaluable) {
+	if ctx == nil {
+		return nil, &q
+	}
+
+	scopes, ok := q.simplifyScopes(e.FlattenContext(ctx))
+	if !ok {
+		return nil, &q
+	}
+
+	predicate := q.predicate()
+	matched := 0
+	known := true
+	for _, scope := range scopes {
+		res := true
+		if predicate != nil {
+			val, _ := predicate.Simplify(scope)
+			if res, ok = val.(bool); !ok {
+				known = false
+				continue
+			}
+		}
+		if val, ok := q.short(res); ok {
+			return val, nil
+		}
+		if res {
+			matched++
+		}
+	}
+
+	if !known {
+		return nil, &q
+	}
+	return q.handler(matched, len(scopes)), nil
+}
+
+func (q quantifier) String() string {
+	if q.predicate() == nil {
+		return fmt.Sprintf("(%s %s)", q.collection().String(), q.operator)
+	}
+	return fmt.Sprintf("(%s %s %s)", q.collection().String(), q.operator, q.predicate().String())
+}
+
+func toTruth(val any, err error) (e.Truth, error) {
+	if err != nil {
+		return e.TruthFalse, err
+	}
+	if t, ok := e.ToTruth(val); ok {
+		return t, nil
+	}
+	return e.TruthFalse, errors.New("invalid evaluated operand, must be boolean value")
+}
+
+// Create a quantifier expression, the elements of the collection are bound to the alias while
+// evaluating the predicate, see evaluable.ScopeContext.
+func New(kind e.Kind, op string, operator string, alias string, operands []e.Evaluable, handler Handler, short Short) (e.Evaluable, error) {
+	return quantifier{kind, op, operator, alias, operands, handler, short, false}, nil
+}
@@ -0,0 +1,298 @@
+package quantifier
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/spaceavocado/goillogical/evaluable"
+	eq "github.com/spaceavocado/goillogical/internal/expression/comparison/eq"
+	gt "github.com/spaceavocado/goillogical/internal/expression/comparison/gt"
+	. "github.com/spaceavocado/goillogical/internal/mock"
+	. "github.com/spaceavocado/goillogical/internal/test"
+)
+
+func some(matched int, total int) any {
+	return matched > 0
+}
+
+func first(res bool) (any, bool) {
+	return true, res
+}
+
+func count(matched int, total int) any {
+	return matched
+}
+
+func never(bool) (any, bool) {
+	return nil, false
+}
+
+func exp(alias string, operands ...Evaluable) Evaluable {
+	e, _ := New(Any, "ANY", "<any>", alias, operands, some, first)
+	return e
+}
+
+func counter(alias string, operands ...Evaluable) Evaluable {
+	e, _ := New(Count, "COUNT", "<count>", alias, operands, count, never)
+	return e
+}
+
+var ctx = map[string]any{
+	"items": []any{
+		map[string]any{"price": 5, "tags": []any{"a"}},
+		map[string]any{"price": 50, "tags": []any{"a", "b"}},
+	},
+	"limit": 10,
+}
+
+func TestEvaluate(t *testing.T) {
+	var tests = []struct {
+		input    Evaluable
+		expected any
+	}{
+		{exp("", Ref("items"), ExpBinary(">", gt.New, Ref(".price"), Val(40))), true},
+		{exp("", Ref("items"), ExpBinary(">", gt.New, Ref(".price"), Val(50))), false},
+		{exp("", Ref("items"), ExpBinary(">", gt.New, Ref(".price"), Ref("limit"))), true},
+		{exp("item", Ref("items"), ExpBinary(">", gt.New, Ref("item.price"), Val(40))), true},
+		{exp("item", Ref("items"), ExpBinary(">", gt.New, Ref("item.price"), Val(50))), false},
+		{exp("", Ref("missing"), ExpBinary(">", gt.New, Ref(".price"), Val(40))), false},
+		{exp("", Ref("items[1].tags"), ExpBinary("==", eq.New, Ref("."), Val("b"))), true},
+		{exp("", Col(Val(1), Val(2)), ExpBinary("==", eq.New, Ref("."), Val(2))), true},
+		{exp("", Col(Val(1), Val(2)), ExpBinary("==", eq.New, Ref("."), Val(3))), false},
+		// Selected values
+		{exp("", Ref("items[*].price"), ExpBinary(">", gt.New, Ref("."), Val(40))), true},
+		{exp("", Ref("items[*].tags[-1]"), ExpBinary("==", eq.New, Ref("."), Val("a"))), true},
+		{counter("", Ref("items[*].tags[*]")), 3},
+		{counter("", Ref("missing[*]")), 0},
+		// Nested quantifiers
+		{exp("item", Ref("items"), exp("tag", Ref("item.tags"), ExpBinary("==", eq.New, Ref("tag"), Val("b")))), true},
+		{exp("item", Ref("items"), exp("tag", Ref("item.tags"), ExpBinary("==", eq.New, Ref("tag"), Val("c")))), false},
+		// Count
+		{counter("", Ref("items")), 2},
+		{counter("", Ref("items"), ExpBinary(">", gt.New, Ref(".price"), Val(1))), 2},
+		{counter("", Ref("items"), ExpBinary(">", gt.New, Ref(".price"), Val(10))), 1},
+		{counter("", Ref("missing")), 0},
+		{counter("", Col(Val(1), Val(2), Val(3))), 3},
+	}
+
+	for _, test := range tests {
+		if output, err := test.input.Evaluate(ctx); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	// Nested data context resolver
+	resolver := NewMapResolver(ctx)
+	for _, test := range tests {
+		if output, err := EvaluateResolver(test.input, resolver); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	errs := []struct {
+		input    Evaluable
+		expected error
+	}{
+		{exp("", Ref("items"), Ref(".price")), errors.New("invalid evaluated operand, must be boolean value")},
+		{exp("", Ref("items"), Invalid()), errors.New("invalid")},
+		{exp("", Invalid(), Val(true)), errors.New("invalid")},
+		{exp("", Val(1), Val(true)), errors.New("invalid evaluated collection, must be a collection")},
+	}
+
+	for _, test := range errs {
+		if _, err := test.input.Evaluate(ctx); err == nil || err.Error() != test.expected.Error() {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, err)
+		}
+	}
+}
+
+func TestThreeValued(t *testing.T) {
+	every := func(matched int, total int) any { return matched == total }
+	never := func(bool) (any, bool) { return nil, false }
+	kleene := func(q Evaluable) Evaluable { return q.(ThreeValued).ThreeValued() }
+	pred := func(ref string) Evaluable {
+		return kleene(ExpBinary(">", gt.New, Ref(ref), Val(1)))
+	}
+
+	ctx := map[string]any{
+		"items": []any{
+			map[string]any{"price": 5},
+			map[string]any{"stock": 1},
+		},
+	}
+
+	anyOf, _ := New(Any, "ANY", "<any>", "", []Evaluable{Ref("items"), pred(".price")}, some, first)
+	allOf, _ := New(All, "ALL", "<all>", "", []Evaluable{Ref("items"), pred(".price")}, every, never)
+	counted, _ := New(Count, "COUNT", "<count>", "", []Evaluable{Ref("items"), pred(".price")}, count, never)
+	unknown, _ := New(Any, "ANY", "<any>", "", []Evaluable{Ref("items"), pred(".stock")}, some, first)
+
+	var tests = []struct {
+		input    Evaluable
+		expected any
+	}{
+		// Short-circuited by a known element
+		{kleene(anyOf), TruthTrue},
+		// Depending on the unknown element
+		{kleene(allOf), TruthUnknown},
+		{kleene(unknown), TruthUnknown},
+		{unknown, TruthUnknown},
+		// Only the matched elements are counted
+		{kleene(counted), 1},
+	}
+
+	for _, test := range tests {
+		if output, err := test.input.Evaluate(ctx); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+}
+
+func TestCompile(t *testing.T) {
+	var tests = []struct {
+		input    Evaluable
+		expected any
+	}{
+		{exp("", Ref("items"), ExpBinary(">", gt.New, Ref(".price"), Val(40))), true},
+		{exp("", Col(Val(1), Val(2)), ExpBinary("==", eq.New, Ref("."), Val(3))), false},
+		{counter("", Ref("items")), 2},
+	}
+
+	for _, test := range tests {
+		fn, _ := test.input.(Compilable).Compile()
+		if output, err := fn(FlattenContext(ctx)); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+}
+
+func TestExplain(t *testing.T) {
+	var tests = []struct {
+		input    Evaluable
+		expected string
+	}{
+		{
+			exp("", Ref("items"), ExpBinary(">", gt.New, Ref(".price"), Val(1))),
+			"Any: ({items} <any> ({.price} > 1)) => true\n" +
+				"  Reference: {items} => <nil>, at \"items\"\n" +
+				"  Gt: ({.price} > 1) => true\n" +
+				"    Reference: {.price} => 5, at \".price\"\n" +
+				"    Value: 1 => 1",
+		},
+		{
+			counter("", Ref("items")),
+			"Count: ({items} <count>) => 2\n" +
+				"  Reference: {items} => <nil>, at \"items\"",
+		},
+		{
+			exp("", Ref("items"), Ref(".price")),
+			"Any: ({items} <any> {.price}) => error: invalid evaluated operand, must be boolean value\n" +
+				"  Reference: {items} => <nil>, at \"items\"\n" +
+				"  Reference: {.price} => 5, at \".price\"",
+		},
+		{
+			exp("", Invalid(), Val(true)),
+			"Any: (invalid <any> true) => error: invalid\n" +
+				"  Unknown: invalid => error: invalid",
+		},
+	}
+
+	for _, test := range tests {
+		if output, _ := test.input.(Explainable).Explain(ctx); output.String() != test.expected {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, output)
+		}
+	}
+}
+
+func TestOperands(t *testing.T) {
+	q := exp("item", Ref("items"), ExpBinary(">", gt.New, Ref("item.price"), Val(40)))
+	n := q.(Node)
+	if n.Kind() != Any || n.Operator() != "ANY" || len(n.Operands()) != 2 || q.(interface{ Alias() string }).Alias() != "item" {
+		t.Errorf("input (%v): expected Any node, got %v/%v/%v", q, n.Kind(), n.Operator(), n.Operands())
+	}
+
+	rewritten := n.WithOperands([]Evaluable{Ref("items"), ExpBinary(">", gt.New, Ref("item.price"), Val(50))})
+	if output, _ := rewritten.Evaluate(ctx); output != false || rewritten.String() != "({items} <any> ({item.price} > 50))" {
+		t.Errorf("input (%v): expected ({items} <any> ({item.price} > 50)), got %v/%v", q, rewritten, output)
+	}
+}
+
+func TestSerialize(t *testing.T) {
+	var tests = []struct {
+		input    Evaluable
+		expected any
+	}{
+		{exp("", Ref("items"), ExpBinary(">", gt.New, Ref(".price"), Val(40))), []any{"ANY", "$items", []any{">", "$.price", 40}}},
+		{counter("", Ref("items")), []any{"COUNT", "$items"}},
+	}
+
+	for _, test := range tests {
+		if output := test.input.Serialize(); Fprint(output) != Fprint(test.expected) {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, output)
+		}
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	ctx := map[string]any{
+		"items": []any{
+			map[string]any{"price": 5},
+			map[string]any{"price": 50, "stock": 1},
+		},
+		"limit": 10,
+	}
+
+	var tests = []struct {
+		input Evaluable
+		value any
+		e     any
+	}{
+		{exp("", Ref("items"), ExpBinary(">", gt.New, Ref(".price"), Val(40))), true, nil},
+		{exp("", Ref("items"), ExpBinary(">", gt.New, Ref(".price"), Ref("limit"))), true, nil},
+		{exp("", Ref("items"), ExpBinary(">", gt.New, Ref(".price"), Val(50))), false, nil},
+		{exp("", Col(Val(1), Val(2)), ExpBinary("==", eq.New, Ref("."), Val(2))), true, nil},
+		{counter("", Ref("items")), 2, nil},
+		{counter("", Ref("items[*].price"), ExpBinary(">", gt.New, Ref("."), Val(40))), 1, nil},
+		{counter("", Ref("missing[*]")), nil, counter("", Ref("missing[*]"))},
+		// Short-circuited by a known element.
+		{exp("", Ref("items"), ExpBinary(">", gt.New, Ref(".stock"), Val(0))), true, nil},
+		// Unknown element
+		{
+			exp("", Ref("items"), ExpBinary(">", gt.New, Ref(".stock"), Val(1))), nil,
+			exp("", Ref("items"), ExpBinary(">", gt.New, Ref(".stock"), Val(1))),
+		},
+		// Unknown free reference
+		{
+			exp("", Ref("items"), ExpBinary(">", gt.New, Ref(".price"), Ref("Missing"))), nil,
+			exp("", Ref("items"), ExpBinary(">", gt.New, Ref(".price"), Ref("Missing"))),
+		},
+		// Unknown collection
+		{counter("", Ref("Missing")), nil, counter("", Ref("Missing"))},
+		{counter("", Col(Ref("Missing"))), nil, counter("", Col(Ref("Missing")))},
+	}
+
+	for _, test := range tests {
+		if value, self := test.input.Simplify(ctx); Fprint(value) != Fprint(test.value) || Fprint(self) != Fprint(test.e) {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v", test.input, test.value, test.e, value, self)
+		}
+	}
+
+	if value, self := counter("", Ref("items")).Simplify(nil); value != nil || self == nil {
+		t.Errorf("input (%v): expected unresolved, got %v/%v", nil, value, self)
+	}
+}
+
+func TestString(t *testing.T) {
+	var tests = []struct {
+		input    Evaluable
+		expected string
+	}{
+		{exp("", Ref("items"), ExpBinary(">", gt.New, Ref(".price"), Val(40))), "({items} <any> ({.price} > 40))"},
+		{counter("", Ref("items")), "({items} <count>)"},
+	}
+
+	for _, test := range tests {
+		if output := test.input.String(); output != test.expected {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, output)
+		}
+	}
+}
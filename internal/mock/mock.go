@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"context"
 	"errors"
 	"regexp"
 
@@ -17,10 +18,11 @@ type eval struct {
 	string    func() string
 }
 
-func (e eval) Evaluate(ctx e.Context) (any, error)       { return e.evaluate(ctx) }
-func (e eval) Serialize() any                            { return e.serialize() }
-func (e eval) Simplify(ctx e.Context) (any, e.Evaluable) { return e.simplify(ctx) }
-func (e eval) String() string                            { return e.string() }
+func (e eval) Evaluate(ctx e.Context) (any, error)                           { return e.evaluate(ctx) }
+func (e eval) EvaluateContext(_ context.Context, ctx e.Context) (any, error) { return e.evaluate(ctx) }
+func (e eval) Serialize() any                                                { return e.serialize() }
+func (e eval) Simplify(ctx e.Context) (any, e.Evaluable)                     { return e.simplify(ctx) }
+func (e eval) String() string                                                { return e.string() }
 
 func Invalid() e.Evaluable {
 	return eval{
@@ -36,13 +38,24 @@ func Val(val any) e.Evaluable {
 	return e
 }
 
+// Evaluable evaluated into the given truth value, see evaluable.Truth.
+func ValTruth(t e.Truth) e.Evaluable {
+	return eval{
+		evaluate:  func(ctx e.Context) (any, error) { return t, nil },
+		serialize: func() any { return t },
+		simplify:  func(ctx e.Context) (any, e.Evaluable) { return t, nil },
+		string:    func() string { return t.String() },
+	}
+}
+
 func Ref(val string) e.Evaluable {
 	serOpts := reference.DefaultSerializeOptions()
 	simOpts := reference.SimplifyOptions{
 		IgnoredPaths:   []string{"ignored"},
 		IgnoredPathsRx: []regexp.Regexp{},
 	}
-	e, _ := reference.New(val, &serOpts, &simOpts)
+	timeOpts := reference.DefaultTimeOptions()
+	e, _ := reference.New(val, &serOpts, &simOpts, &timeOpts)
 	return e
 }
 
@@ -62,7 +75,22 @@ func ExpBinary(op string, factory func(string, e.Evaluable, e.Evaluable) (e.Eval
 	return e
 }
 
+func ExpTernary(op string, factory func(string, e.Evaluable, e.Evaluable, e.Evaluable) (e.Evaluable, error), a, b, c e.Evaluable) e.Evaluable {
+	e, _ := factory(op, a, b, c)
+	return e
+}
+
+func ExpVariadic(op string, factory func(string, []e.Evaluable) (e.Evaluable, error), operands ...e.Evaluable) e.Evaluable {
+	e, _ := factory(op, operands)
+	return e
+}
+
 func ExpMany(op string, factory func(string, []e.Evaluable, string, string) (e.Evaluable, error), operands ...e.Evaluable) e.Evaluable {
 	e, _ := factory(op, operands, "", "")
 	return e
 }
+
+func ExpQuantifier(op string, alias string, factory func(string, string, []e.Evaluable) (e.Evaluable, error), operands ...e.Evaluable) e.Evaluable {
+	e, _ := factory(op, alias, operands)
+	return e
+}
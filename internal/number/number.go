@@ -0,0 +1,164 @@
+// Number is defined in this package as a numeric value of any numeric type, i.e. int8-int64,
+// uint8-uint64, float32, float64 or json.Number, promoted into the widest type of its kind, so
+// that the numbers of different types could be compared, or computed, by their mathematical value.
+package number
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+type Kind int
+
+const (
+	Signed Kind = iota
+	Unsigned
+	Float
+)
+
+// Numeric value promoted into the widest type of its kind, i.e. int64, uint64 or float64.
+type Number struct {
+	Kind  Kind
+	Int   int64
+	Uint  uint64
+	Float float64
+}
+
+// 2^63 and 2^64, the exclusive upper bounds of int64 and uint64 exactly representable as float64.
+const maxInt64Float = float64(1 << 63)
+const maxUint64Float = float64(1<<63) * 2
+
+func fromJSONNumber(n json.Number) (Number, bool) {
+	if i, err := n.Int64(); err == nil {
+		return Number{Kind: Signed, Int: i}, true
+	}
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		return Number{Kind: Unsigned, Uint: u}, true
+	}
+	if f, err := n.Float64(); err == nil {
+		return Number{Kind: Float, Float: f}, true
+	}
+	return Number{}, false
+}
+
+// Promote a value of any numeric type into a number, false if the value is not a number.
+func Of(val any) (Number, bool) {
+	switch typed := val.(type) {
+	case int:
+		return Number{Kind: Signed, Int: int64(typed)}, true
+	case int8:
+		return Number{Kind: Signed, Int: int64(typed)}, true
+	case int16:
+		return Number{Kind: Signed, Int: int64(typed)}, true
+	case int32:
+		return Number{Kind: Signed, Int: int64(typed)}, true
+	case int64:
+		return Number{Kind: Signed, Int: typed}, true
+	case uint:
+		return Number{Kind: Unsigned, Uint: uint64(typed)}, true
+	case uint8:
+		return Number{Kind: Unsigned, Uint: uint64(typed)}, true
+	case uint16:
+		return Number{Kind: Unsigned, Uint: uint64(typed)}, true
+	case uint32:
+		return Number{Kind: Unsigned, Uint: uint64(typed)}, true
+	case uint64:
+		return Number{Kind: Unsigned, Uint: typed}, true
+	case float32:
+		return Number{Kind: Float, Float: float64(typed)}, true
+	case float64:
+		return Number{Kind: Float, Float: typed}, true
+	case json.Number:
+		return fromJSONNumber(typed)
+	default:
+		return Number{}, false
+	}
+}
+
+func (n Number) IsNaN() bool {
+	return n.Kind == Float && math.IsNaN(n.Float)
+}
+
+func cmp[T int64 | uint64 | float64](a T, b T) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+// Compare a signed integer with a float without converting the integer into a float, which
+// would lose precision beyond 2^53.
+func cmpSignedFloat(a int64, b float64) int {
+	if b >= maxInt64Float {
+		return -1
+	}
+	if b < -maxInt64Float {
+		return 1
+	}
+	t := math.Trunc(b)
+	if res := cmp(a, int64(t)); res != 0 {
+		return res
+	}
+	return cmp(0, b-t)
+}
+
+// Compare an unsigned integer with a float without converting the integer into a float, which
+// would lose precision beyond 2^53.
+func cmpUnsignedFloat(a uint64, b float64) int {
+	if b < 0 {
+		return 1
+	}
+	if b >= maxUint64Float {
+		return -1
+	}
+	t := math.Trunc(b)
+	if res := cmp(a, uint64(t)); res != 0 {
+		return res
+	}
+	return cmp(0, b-t)
+}
+
+func cmpSignedUnsigned(a int64, b uint64) int {
+	if a < 0 {
+		return -1
+	}
+	return cmp(uint64(a), b)
+}
+
+// Compare two numbers by their mathematical value. Returns -1, 0 or 1 if the left number is less
+// than, equal to or greater than the right number, the numbers must not be NaN.
+func Compare(a Number, b Number) int {
+	switch a.Kind {
+	case Signed:
+		switch b.Kind {
+		case Signed:
+			return cmp(a.Int, b.Int)
+		case Unsigned:
+			return cmpSignedUnsigned(a.Int, b.Uint)
+		default:
+			return cmpSignedFloat(a.Int, b.Float)
+		}
+	case Unsigned:
+		switch b.Kind {
+		case Signed:
+			return -cmpSignedUnsigned(b.Int, a.Uint)
+		case Unsigned:
+			return cmp(a.Uint, b.Uint)
+		default:
+			return cmpUnsignedFloat(a.Uint, b.Float)
+		}
+	default:
+		switch b.Kind {
+		case Signed:
+			return -cmpSignedFloat(b.Int, a.Float)
+		case Unsigned:
+			return -cmpUnsignedFloat(b.Uint, a.Float)
+		default:
+			return cmp(a.Float, b.Float)
+		}
+	}
+}
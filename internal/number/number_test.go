@@ -0,0 +1,63 @@
+package number
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestOf(t *testing.T) {
+	var tests = []struct {
+		input    any
+		expected Number
+		ok       bool
+	}{
+		{int8(-1), Number{Kind: Signed, Int: -1}, true},
+		{int64(math.MinInt64), Number{Kind: Signed, Int: math.MinInt64}, true},
+		{uint8(1), Number{Kind: Unsigned, Uint: 1}, true},
+		{uint64(math.MaxUint64), Number{Kind: Unsigned, Uint: math.MaxUint64}, true},
+		{float32(0.5), Number{Kind: Float, Float: 0.5}, true},
+		{1.5, Number{Kind: Float, Float: 1.5}, true},
+		{json.Number("-1"), Number{Kind: Signed, Int: -1}, true},
+		{json.Number("18446744073709551615"), Number{Kind: Unsigned, Uint: math.MaxUint64}, true},
+		{json.Number("1e3"), Number{Kind: Float, Float: 1000}, true},
+		{json.Number("val"), Number{}, false},
+		{"1", Number{}, false},
+		{nil, Number{}, false},
+	}
+
+	for _, test := range tests {
+		if output, ok := Of(test.input); output != test.expected || ok != test.ok {
+			t.Errorf("input (%T(%v)): expected %v/%v, got %v/%v", test.input, test.input, test.expected, test.ok, output, ok)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	var tests = []struct {
+		a        any
+		b        any
+		expected int
+	}{
+		{1, 2, -1},
+		{uint(2), int8(-1), 1},
+		{int64(9007199254740993), float64(9007199254740992), 1},
+		{uint64(math.MaxUint64), float64(math.MaxUint64), -1},
+		{json.Number("10"), 10.0, 0},
+	}
+
+	for _, test := range tests {
+		a, _ := Of(test.a)
+		b, _ := Of(test.b)
+		if output := Compare(a, b); output != test.expected {
+			t.Errorf("input (%T(%v), %T(%v)): expected %v, got %v", test.a, test.a, test.b, test.b, test.expected, output)
+		}
+		if output := Compare(b, a); output != -test.expected {
+			t.Errorf("input (%T(%v), %T(%v)): expected %v, got %v", test.b, test.b, test.a, test.a, -test.expected, output)
+		}
+	}
+
+	if !(Number{Kind: Float, Float: math.NaN()}).IsNaN() || (Number{Kind: Signed}).IsNaN() {
+		t.Errorf("expected NaN only for the NaN float")
+	}
+}
@@ -1,7 +1,7 @@
 package collection
 
 import (
-	"errors"
+	"context"
 	"fmt"
 
 	e "github.com/spaceavocado/goillogical/evaluable"
@@ -38,6 +38,53 @@ func (c collection) Evaluate(ctx e.Context) (any, error) {
 	return res, nil
 }
 
+func (c collection) EvaluateContext(ctx context.Context, data e.Context) (any, error) {
+	return e.Guard(ctx, func(ctx context.Context) (any, error) {
+		return e.EvaluateOperands(ctx, c.items, e.FlattenContext(data))
+	})
+}
+
+func (c collection) Compile() (e.Func, error) {
+	items, err := e.CompileFuncs(c.items)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx e.Context) (any, error) {
+		res := make([]any, len(items))
+		for i, fn := range items {
+			val, err := fn(ctx)
+			if err != nil {
+				return nil, err
+			}
+			res[i] = val
+		}
+		return res, nil
+	}, nil
+}
+
+func (c collection) Explain(ctx e.Context) (e.Trace, error) {
+	values, operands, err := e.ExplainOperands(e.FlattenContext(ctx), c.items)
+	return e.NewTrace(e.Collection, c, values, err, operands), err
+}
+
+func (c collection) Kind() e.Kind {
+	return e.Collection
+}
+
+func (c collection) Operator() string {
+	return ""
+}
+
+func (c collection) Operands() []e.Evaluable {
+	return c.items
+}
+
+func (c collection) WithOperands(items []e.Evaluable) e.Evaluable {
+	c.items = items
+	return c
+}
+
 func (c collection) Serialize() any {
 	head := c.items[0].Serialize()
 	if shouldBeEscaped(head, c.opts) {
@@ -98,7 +145,7 @@ func escapeOperator(input string, opts *SerializeOptions) string {
 
 func New(items []e.Evaluable, opts *SerializeOptions) (e.Evaluable, error) {
 	if len(items) == 0 {
-		return nil, errors.New("collection operand must have at least 1 item")
+		return nil, e.NewParseError(e.ErrInvalidOperand, "collection operand must have at least 1 item")
 	}
 
 	return collection{items, opts}, nil
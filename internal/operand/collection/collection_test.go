@@ -23,7 +23,8 @@ func ref(val string) Evaluable {
 		IgnoredPaths:   []string{"ignored"},
 		IgnoredPathsRx: []regexp.Regexp{},
 	}
-	e, _ := reference.New(val, &serOpts, &simOpts)
+	timeOpts := reference.DefaultTimeOptions()
+	e, _ := reference.New(val, &serOpts, &simOpts, &timeOpts)
 	return e
 }
 
@@ -60,7 +61,7 @@ func TestEvaluate(t *testing.T) {
 		input    []Evaluable
 		expected error
 	}{
-		{[]Evaluable{ref("RefA.(Float)")}, errors.New("invalid conversion from from \"A\" (string) to float")},
+		{[]Evaluable{ref("RefA.(Float)")}, errors.New("invalid conversion from \"A\" (string) to float")},
 	}
 
 	for _, test := range errs {
@@ -78,12 +79,75 @@ func TestEvaluate(t *testing.T) {
 	}
 
 	for _, test := range errs {
-		if _, err := New(test.input, &opts); err.Error() != test.expected.Error() {
+		if _, err := New(test.input, &opts); err.Error() != test.expected.Error() || !errors.Is(err, ErrInvalidOperand) {
 			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, err)
 		}
 	}
 }
 
+func TestCompile(t *testing.T) {
+	opts := DefaultSerializeOptions()
+	ctx := FlattenContext(map[string]any{
+		"RefA": "A",
+	})
+	tests := []struct {
+		input    []Evaluable
+		expected any
+	}{
+		{[]Evaluable{val(1), ref("RefA")}, []any{1, "A"}},
+		{[]Evaluable{expBinary(eq.New, val(1), val(1)), ref("RefB")}, []any{true, nil}},
+	}
+
+	for _, test := range tests {
+		eval, _ := New(test.input, &opts)
+		fn, _ := eval.(Compilable).Compile()
+		if output, err := fn(ctx); Fprint(output) != Fprint(test.expected) || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	eval, _ := New([]Evaluable{ref("RefA.(Float)")}, &opts)
+	fn, _ := eval.(Compilable).Compile()
+	expected := errors.New("invalid conversion from \"A\" (string) to float")
+	if _, err := fn(ctx); err == nil || err.Error() != expected.Error() {
+		t.Errorf("input (%v): expected %v, got %v", eval, expected, err)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	opts := DefaultSerializeOptions()
+	ctx := FlattenContext(map[string]any{
+		"RefA": "A",
+	})
+	tests := []struct {
+		input    []Evaluable
+		expected string
+	}{
+		{[]Evaluable{val(1), ref("RefA")}, "Collection: [1, {RefA}] => [1 A]\n  Value: 1 => 1\n  Reference: {RefA} => \"A\", at \"RefA\""},
+		{[]Evaluable{ref("RefA.(Float)"), val(1)}, "Collection: [{RefA.(Float)}, 1] => error: invalid conversion from \"A\" (string) to float\n  Reference: {RefA.(Float)} => error: invalid conversion from \"A\" (string) to float, at \"RefA\"\n  Value: 1 => skipped"},
+	}
+
+	for _, test := range tests {
+		eval, _ := New(test.input, &opts)
+		if output, _ := eval.(Explainable).Explain(ctx); output.String() != test.expected {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, output)
+		}
+	}
+}
+
+func TestOperands(t *testing.T) {
+	opts := DefaultSerializeOptions()
+	eval, _ := New([]Evaluable{val(1), ref("RefA")}, &opts)
+	n := eval.(Node)
+	if n.Kind() != Collection || n.Operator() != "" || len(n.Operands()) != 2 {
+		t.Errorf("input (%v): expected Collection node, got %v/%v/%v", eval, n.Kind(), n.Operator(), n.Operands())
+	}
+
+	if rewritten := n.WithOperands([]Evaluable{val(2)}); rewritten.String() != "[2]" || eval.String() != "[1, {RefA}]" {
+		t.Errorf("input (%v): expected [2], got %v", eval, rewritten)
+	}
+}
+
 func TestSerialize(t *testing.T) {
 	opts := SerializeOptions{
 		EscapedOperators: map[string]bool{"==": true},
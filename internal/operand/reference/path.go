@@ -0,0 +1,263 @@
+package reference
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	e "github.com/spaceavocado/goillogical/evaluable"
+)
+
+type selectorKind int
+
+const (
+	// Literal part of the path, e.g. "address.city".
+	literal selectorKind = iota
+	// Each element of an array, i.e. "[*]".
+	wildcard
+	// Element of an array indexed from the end, e.g. "[-1]".
+	negativeIndex
+	// Elements of an array within the range, e.g. "[0:3]", "[1:]" or "[:-1]".
+	slice
+	// Each descendant of the given name at any depth, e.g. "..name".
+	descent
+)
+
+const NEGATIVE_INDEX_RX string = `^-[1-9]\d*$`
+const SLICE_RX string = `^(-?\d+)?:(-?\d+)?$`
+
+var negativeIndexRx = regexp.MustCompile(NEGATIVE_INDEX_RX)
+var sliceRx = regexp.MustCompile(SLICE_RX)
+
+type selector struct {
+	kind    selectorKind
+	literal string
+	index   int
+	start   *int
+	end     *int
+}
+
+// Parsed reference path, i.e. a sequence of the literal parts and the selectors.
+//
+// Example:
+//
+//	"items[*].tags[-1]" => [literal "items", wildcard, literal ".tags", negative index -1]
+type selectorPath []selector
+
+// Parse the path into the selector path, returns false if the path has no selectors, i.e. it is
+// looked up as it is.
+func parsePath(path string) (selectorPath, bool) {
+	res := selectorPath{}
+	selectors := false
+	var sb strings.Builder
+
+	flush := func() {
+		if sb.Len() > 0 {
+			res = append(res, selector{kind: literal, literal: sb.String()})
+			sb.Reset()
+		}
+	}
+
+	for i := 0; i < len(path); {
+		if strings.HasPrefix(path[i:], "..") {
+			end := i + 2
+			for end < len(path) && path[end] != '.' && path[end] != '[' {
+				end++
+			}
+			if end > i+2 {
+				flush()
+				res = append(res, selector{kind: descent, literal: path[i+2 : end]})
+				selectors = true
+				i = end
+				continue
+			}
+		}
+
+		if path[i] == '[' {
+			if end := strings.IndexByte(path[i:], ']'); end > 0 {
+				if sel, ok := parseBracket(path[i+1 : i+end]); ok {
+					flush()
+					res = append(res, sel)
+					selectors = true
+					i += end + 1
+					continue
+				}
+			}
+		}
+
+		sb.WriteByte(path[i])
+		i++
+	}
+	flush()
+
+	return res, selectors
+}
+
+// Whether the path has any selectors, i.e. wildcards, negative indexes, slices or recursive
+// descents, resolved into the concrete paths only in the evaluation context.
+func HasSelectors(path string) bool {
+	_, ok := parsePath(path)
+	return ok
+}
+
+// Parse the bracket selector, returns false if the bracket is a plain index, or key.
+func parseBracket(content string) (selector, bool) {
+	switch {
+	case content == "*":
+		return selector{kind: wildcard}, true
+	case negativeIndexRx.MatchString(content):
+		i, _ := strconv.Atoi(content)
+		return selector{kind: negativeIndex, index: i}, true
+	case sliceRx.MatchString(content):
+		m := sliceRx.FindStringSubmatch(content)
+		return selector{kind: slice, start: toBound(m[1]), end: toBound(m[2])}, true
+	default:
+		return selector{}, false
+	}
+}
+
+func toBound(s string) *int {
+	if s == "" {
+		return nil
+	}
+	i, _ := strconv.Atoi(s)
+	return &i
+}
+
+// Whether the path selects multiple values, i.e. it is evaluated into a slice.
+func (p selectorPath) multiple() bool {
+	for _, sel := range p {
+		if sel.kind == wildcard || sel.kind == slice || sel.kind == descent {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve the selectors into the concrete paths, e.g. "items[*]" => ["items[0]", "items[1]"].
+func (p selectorPath) paths(ctx e.Context) ([]string, error) {
+	paths := []string{""}
+	for _, sel := range p {
+		next := []string{}
+		for _, path := range paths {
+			selected, err := sel.selectPaths(ctx, path)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, selected...)
+		}
+		paths = next
+	}
+	return paths, nil
+}
+
+func (s selector) selectPaths(ctx e.Context, path string) ([]string, error) {
+	switch s.kind {
+	case literal:
+		return []string{path + s.literal}, nil
+	case descent:
+		return descendants(ctx, path, s.literal)
+	}
+
+	n, _, err := e.Length(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := 0, n
+	switch s.kind {
+	case negativeIndex:
+		start = n + s.index
+		end = start + 1
+	case slice:
+		start, end = bound(s.start, 0, n), bound(s.end, n, n)
+	}
+
+	res := []string{}
+	for i := max(start, 0); i < min(end, n); i++ {
+		res = append(res, fmt.Sprintf("%s[%d]", path, i))
+	}
+	return res, nil
+}
+
+// Slice bound, negative bounds are counted from the end.
+func bound(b *int, fallback int, n int) int {
+	if b == nil {
+		return fallback
+	}
+	if *b < 0 {
+		return *b + n
+	}
+	return *b
+}
+
+// Paths of the descendants of the given name at any depth of the path, in the natural order of
+// the paths, see evaluable.ComparePaths.
+func descendants(ctx e.Context, path string, name string) ([]string, error) {
+	paths, err := e.Paths(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []string{}
+	seen := map[string]bool{}
+	for _, p := range paths {
+		rest := p[len(path):]
+		for i := 0; i <= len(rest)-len(name); i++ {
+			if !strings.HasPrefix(rest[i:], name) {
+				continue
+			}
+			starts := (i == 0 && path == "") || (i > 0 && rest[i-1] == '.')
+			end := i + len(name)
+			ends := end == len(rest) || rest[end] == '.' || rest[end] == '['
+			if !starts || !ends {
+				continue
+			}
+			if candidate := path + rest[:end]; !seen[candidate] {
+				seen[candidate] = true
+				res = append(res, candidate)
+			}
+		}
+	}
+	return res, nil
+}
+
+// Lookup the path in the context, a path with the selectors, i.e. wildcards, slices and recursive
+// descents, is evaluated into a slice of the selected values, the paths which could not be found
+// are omitted. Such a path is found if at least one value is selected.
+func (p selectorPath) lookup(ctx e.Context) (bool, any, error) {
+	paths, err := p.paths(ctx)
+	if err != nil {
+		return false, nil, err
+	}
+
+	if !p.multiple() {
+		if len(paths) == 0 {
+			return false, nil, nil
+		}
+		val, found, err := e.Lookup(ctx, paths[0])
+		return found, val, err
+	}
+
+	res := []any{}
+	for _, path := range paths {
+		val, found, err := e.Lookup(ctx, path)
+		if err != nil {
+			return false, nil, err
+		}
+		if found {
+			res = append(res, val)
+		}
+	}
+	return len(res) > 0, res, nil
+}
+
+// Lookup the path in the context, see selectorPath.lookup.
+func lookupPath(ctx e.Context, path string) (bool, any, error) {
+	if p, ok := parsePath(path); ok {
+		return p.lookup(ctx)
+	}
+	val, found, err := e.Lookup(ctx, path)
+	return found, val, err
+}
@@ -0,0 +1,138 @@
+package reference
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/spaceavocado/goillogical/evaluable"
+	. "github.com/spaceavocado/goillogical/internal/test"
+)
+
+func TestParsePath(t *testing.T) {
+	one, three, minusOne := 1, 3, -1
+
+	var tests = []struct {
+		input    string
+		expected selectorPath
+		ok       bool
+	}{
+		{"a", selectorPath{{kind: literal, literal: "a"}}, false},
+		{"a[0].b", selectorPath{{kind: literal, literal: "a[0].b"}}, false},
+		{"a[x]", selectorPath{{kind: literal, literal: "a[x]"}}, false},
+		{"a[-0]", selectorPath{{kind: literal, literal: "a[-0]"}}, false},
+		{"a..", selectorPath{{kind: literal, literal: "a.."}}, false},
+		{"a[*]", selectorPath{{kind: literal, literal: "a"}, {kind: wildcard}}, true},
+		{"a[*].b", selectorPath{{kind: literal, literal: "a"}, {kind: wildcard}, {kind: literal, literal: ".b"}}, true},
+		{"a[-1]", selectorPath{{kind: literal, literal: "a"}, {kind: negativeIndex, index: -1}}, true},
+		{"a[1:3]", selectorPath{{kind: literal, literal: "a"}, {kind: slice, start: &one, end: &three}}, true},
+		{"a[:-1]", selectorPath{{kind: literal, literal: "a"}, {kind: slice, end: &minusOne}}, true},
+		{"a[1:]", selectorPath{{kind: literal, literal: "a"}, {kind: slice, start: &one}}, true},
+		{"a..b", selectorPath{{kind: literal, literal: "a"}, {kind: descent, literal: "b"}}, true},
+		{"..b[*]", selectorPath{{kind: descent, literal: "b"}, {kind: wildcard}}, true},
+	}
+
+	for _, test := range tests {
+		if output, ok := parsePath(test.input); ok != test.ok || !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v", test.input, test.expected, test.ok, output, ok)
+		}
+		if output := HasSelectors(test.input); output != test.ok {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.ok, output)
+		}
+	}
+}
+
+func TestLookupPath(t *testing.T) {
+	ctx := map[string]any{
+		"items": []any{
+			map[string]any{"name": "a", "price": 10, "tags": []any{"x", "y"}},
+			map[string]any{"name": "b", "price": 20, "tags": []any{"z"}},
+			map[string]any{"name": "c", "price": 30},
+		},
+		"matrix": []any{[]any{1, 2}, []any{3}},
+		"nested": map[string]any{"name": "d", "deep": map[string]any{"name": "e"}},
+		"index":  -1,
+		"start":  1,
+	}
+
+	var tests = []struct {
+		input    string
+		found    bool
+		expected any
+	}{
+		{"items[0].name", true, "a"},
+		{"items[*].name", true, []any{"a", "b", "c"}},
+		{"items[*].tags[0]", true, []any{"x", "z"}},
+		{"items[*].tags[*]", true, []any{"x", "y", "z"}},
+		{"items[*].missing", false, []any{}},
+		{"missing[*]", false, []any{}},
+		{"items[-1].name", true, "c"},
+		{"items[-3].name", true, "a"},
+		{"items[-4].name", false, nil},
+		{"items[0].tags[-1]", true, "y"},
+		{"items[0:2].name", true, []any{"a", "b"}},
+		{"items[1:].name", true, []any{"b", "c"}},
+		{"items[:-1].name", true, []any{"a", "b"}},
+		{"items[-2:].price", true, []any{20, 30}},
+		{"items[5:].price", false, []any{}},
+		{"matrix[*][*]", true, []any{1, 2, 3}},
+		{"matrix[-1][0]", true, 3},
+		{"..name", true, []any{"a", "b", "c", "e", "d"}},
+		{"nested..name", true, []any{"e", "d"}},
+		{"items..tags[0]", true, []any{"x", "z"}},
+		{"items[{index}].name", true, "c"},
+		{"items[{start}:].name", true, []any{"b", "c"}},
+	}
+
+	for _, test := range tests {
+		lookup := func(c Context) (any, error) {
+			found, _, value, err := contextLookup(c, test.input)
+			if found != test.found || Fprint(value) != Fprint(test.expected) || err != nil {
+				t.Errorf("input (%v): expected %v/%v, got %v/%v/%v", test.input, test.found, test.expected, found, value, err)
+			}
+			return nil, nil
+		}
+		lookup(FlattenContext(ctx))
+		RunResolver(lookup, NewMapResolver(ctx))
+	}
+}
+
+func TestEvaluatePath(t *testing.T) {
+	ctx := map[string]any{
+		"items": []any{
+			map[string]any{"price": "10"},
+			map[string]any{"price": "20.5"},
+		},
+	}
+
+	var tests = []struct {
+		addr     string
+		expected any
+	}{
+		{"items[*].price", []any{"10", "20.5"}},
+		{"items[*].price.(Number)", []any{10, 20.5}},
+		{"items[-1].price.(Number)", 20.5},
+		{"missing[*]", []any{}},
+		{"missing[-1]", nil},
+	}
+
+	for _, test := range tests {
+		if output, err := ref(test.addr).Evaluate(ctx); Fprint(output) != Fprint(test.expected) || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.addr, test.expected, output, err)
+		}
+
+		fn, _ := ref(test.addr).(Compilable).Compile()
+		if output, err := fn(FlattenContext(ctx)); Fprint(output) != Fprint(test.expected) || err != nil {
+			t.Errorf("input (%v): expected compiled %v, got %v/%v", test.addr, test.expected, output, err)
+		}
+	}
+
+	if value, self := ref("items[*].price").Simplify(ctx); Fprint(value) != Fprint([]any{"10", "20.5"}) || self != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", "items[*].price", []any{"10", "20.5"}, value, self)
+	}
+	if value, self := ref("missing[*]").Simplify(ctx); value != nil || self == nil {
+		t.Errorf("input (%v): expected unresolved, got %v/%v", "missing[*]", value, self)
+	}
+	if _, err := ref("items[*].price.(Boolean)").Evaluate(ctx); err == nil {
+		t.Errorf("input (%v): expected error, got nil", "items[*].price.(Boolean)")
+	}
+}
@@ -1,11 +1,15 @@
 package reference
 
 import (
+	"context"
 	"errors"
+	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	e "github.com/spaceavocado/goillogical/evaluable"
+	number "github.com/spaceavocado/goillogical/internal/number"
 
 	"fmt"
 	"regexp"
@@ -27,6 +31,9 @@ type SimplifyOptions struct {
 	IgnoredPaths []string
 	// Reference paths which should be ignored while simplification is applied. Matching regular expression patterns.
 	IgnoredPathsRx []regexp.Regexp
+	// Use the default values of the references which could not be found, e.g. "$points|0",
+	// otherwise such references are left unresolved.
+	UseDefaults bool
 }
 
 func DefaultSerializeOptions() SerializeOptions {
@@ -53,61 +60,284 @@ const (
 	Float       DataType = "Float"
 	String      DataType = "String"
 	Boolean     DataType = "Boolean"
+	Date        DataType = "Date"
+	DateTime    DataType = "DateTime"
 )
 
 const NESTED_REFERENCE_RX string = `{([^{}]+)}`
-const DATA_TYPE_RX string = `^.+\.\(([A-Z][a-z]+)\)$`
-const DATA_TYPE_TRIM_RX string = `.\(([A-Z][a-z]+)\)$`
+const DATA_TYPE_RX string = `^.+\.\(([A-Z][A-Za-z]+)\)$`
+const DATA_TYPE_TRIM_RX string = `.\(([A-Z][A-Za-z]+)\)$`
 const FLOAT_TRIM_RX string = ""
 const FLOAT_RX string = `^\d+\.\d+$`
 const INT_RX string = `^0$|^[1-9]\d*$`
+const DEFAULT_INT_RX string = `^-?(0|[1-9]\d*)$`
+const DEFAULT_FLOAT_RX string = `^-?\d+\.\d+$`
+
+var nestedReferenceRx = regexp.MustCompile(NESTED_REFERENCE_RX)
+var dataTypeRx = regexp.MustCompile(DATA_TYPE_RX)
+var dataTypeTrimRx = regexp.MustCompile(DATA_TYPE_TRIM_RX)
+var floatRx = regexp.MustCompile(FLOAT_RX)
+var intRx = regexp.MustCompile(INT_RX)
+var defaultIntRx = regexp.MustCompile(DEFAULT_INT_RX)
+var defaultFloatRx = regexp.MustCompile(DEFAULT_FLOAT_RX)
+
+// Default value of a reference, used if the referenced value could not be found.
+type defaultValue struct {
+	raw   string
+	value any
+}
 
 type reference struct {
-	addr    string
-	path    string
-	dt      DataType
-	serOpts *SerializeOptions
-	simOpts *SimplifyOptions
+	addr     string
+	path     string
+	dt       DataType
+	def      *defaultValue
+	serOpts  *SerializeOptions
+	simOpts  *SimplifyOptions
+	timeOpts *TimeOptions
+}
+
+// Resolve the reference in the given context, the NOW reference is resolved by the clock, i.e. it
+// shadows the NOW key of the context.
+func (r reference) lookup(ctx e.Context) (bool, string, any, error) {
+	if r.path == NOW {
+		val, err := caster(r.dt, r.timeOpts)(r.timeOpts.Clock())
+		return true, r.path, val, err
+	}
+	return evaluate(ctx, r.path, r.dt, r.timeOpts)
+}
+
+// Resolve the reference in the given context, a nil value is replaced by the default value.
+func (r reference) evaluate(ctx e.Context) (bool, string, any, error) {
+	found, path, val, err := r.lookup(ctx)
+	if err == nil && val == nil && r.def != nil {
+		val, err = caster(r.dt, r.timeOpts)(r.def.value)
+	}
+	return found, path, val, err
 }
 
 func (r reference) Evaluate(ctx e.Context) (any, error) {
-	if ctx == nil {
+	if ctx == nil && r.path != NOW && r.def == nil {
 		return nil, nil
 	}
 
-	_, _, res, err := evaluate(e.FlattenContext(ctx), r.path, r.dt)
+	_, _, res, err := r.evaluate(e.FlattenContext(ctx))
+	return res, err
+}
+
+func (r reference) EvaluateContext(ctx context.Context, data e.Context) (any, error) {
+	return e.Guard(ctx, func(context.Context) (any, error) {
+		return r.Evaluate(data)
+	})
+}
+
+// Compile the reference, i.e. the cast function is resolved upfront, and the nested references
+// are pre-parsed into a template, or the path selectors into a selector path.
+func (r reference) Compile() (e.Func, error) {
+	cast := caster(r.dt, r.timeOpts)
+	path := r.path
+	def := r.def
+
+	if path == NOW {
+		clock := r.timeOpts.Clock
+		return func(e.Context) (any, error) {
+			return cast(clock())
+		}, nil
+	}
+
+	lookup := func(ctx e.Context) (bool, any, error) {
+		found, _, val, err := contextLookup(ctx, path)
+		return found, val, err
+	}
+	if t, ok := parseTemplate(path); ok {
+		lookup = t.lookup
+		if p, ok := parsePath(path); ok && len(t) == 1 && t[0].nested == nil {
+			lookup = p.lookup
+		}
+	}
+
+	return func(ctx e.Context) (any, error) {
+		_, value, err := lookup(ctx)
+		if err == nil && value == nil && def != nil {
+			return cast(def.value)
+		}
+		if err != nil || value == nil {
+			return nil, err
+		}
+		return castValue(cast, value)
+	}, nil
+}
+
+func (r reference) Explain(ctx e.Context) (e.Trace, error) {
+	_, path, val, err := r.evaluate(ctx)
+	res := e.NewTrace(e.Reference, r, val, err, nil)
+	res.Path = path
 	return res, err
 }
 
+func (r reference) Kind() e.Kind {
+	return e.Reference
+}
+
+func (r reference) Operator() string {
+	return ""
+}
+
+func (r reference) Operands() []e.Evaluable {
+	return nil
+}
+
+func (r reference) WithOperands([]e.Evaluable) e.Evaluable {
+	return r
+}
+
+// Get the reference path, without the data type casting, e.g. "address.{segment}".
+func (r reference) Path() string {
+	return r.path
+}
+
+// Get the data type the referenced value is cast to, Undefined if not cast.
+func (r reference) DataType() DataType {
+	return r.dt
+}
+
+// Get the default value used if the referenced value could not be found, false if not given.
+func (r reference) Default() (any, bool) {
+	if r.def == nil {
+		return nil, false
+	}
+	return r.def.value, true
+}
+
+// Resolve the reference path in the given context, i.e. the path after the nested references
+// interpolation, e.g. "address.{segment}" => "address.city". Returns false if any of the nested
+// references could not be found.
+func (r reference) ResolvePath(ctx e.Context) (string, bool, error) {
+	if ctx == nil {
+		return r.path, false, nil
+	}
+
+	found, path, err := resolvePath(e.FlattenContext(ctx), r.path)
+	return path, found, err
+}
+
 func (r reference) Serialize() any {
 	path := r.path
 
 	if r.dt != Undefined {
 		path = fmt.Sprintf("%s.(%s)", r.path, r.dt)
 	}
+	if r.def != nil {
+		path = fmt.Sprintf("%s|%s", path, r.def.raw)
+	}
 
 	return r.serOpts.To(path)
 }
 
+// Simplify the reference into its value if found in the given context, a reference which could
+// not be found is simplified into its default value only if configured, see
+// SimplifyOptions.UseDefaults.
 func (r reference) Simplify(ctx e.Context) (any, e.Evaluable) {
-	if ctx == nil {
+	if ctx == nil && r.path != NOW && (r.def == nil || !r.simOpts.UseDefaults) {
 		return nil, &r
 	}
 
-	found, path, res, _ := evaluate(e.FlattenContext(ctx), r.path, r.dt)
-	if found && !isIgnoredPath(path, r.simOpts) {
+	found, path, res, err := r.lookup(e.FlattenContext(ctx))
+	if isIgnoredPath(path, r.simOpts) {
+		return nil, &r
+	}
+	if (found || r.simOpts.UseDefaults) && res == nil && err == nil && r.def != nil {
+		if res, err = caster(r.dt, r.timeOpts)(r.def.value); err == nil {
+			return res, nil
+		}
+	}
+	if found {
 		return res, nil
 	}
 	return nil, &r
 }
 
 func (r reference) String() string {
-	return fmt.Sprintf("{%s}", r.addr)
+	return fmt.Sprintf("{%s}", escapeAddr(r.addr))
+}
+
+// Escape the address of the string representation, i.e. the backslashes, and the braces which
+// would either close the reference early, or never be closed, e.g. "a}b" => "a\}b". The braces of
+// the nested references are kept as they are, e.g. "a.{b}".
+func escapeAddr(addr string) string {
+	escaped := make([]bool, len(addr))
+	open := []int{}
+	for i := 0; i < len(addr); i++ {
+		switch addr[i] {
+		case '\\':
+			escaped[i] = true
+		case '{':
+			open = append(open, i)
+		case '}':
+			if len(open) == 0 {
+				escaped[i] = true
+				continue
+			}
+			open = open[:len(open)-1]
+		}
+	}
+	for _, i := range open {
+		escaped[i] = true
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(addr); i++ {
+		if escaped[i] {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(addr[i])
+	}
+	return sb.String()
+}
+
+// Split the address into the reference and the default value, i.e. the first "|" outside of the
+// nested references, e.g. "country|\"CA\"" => "country", "\"CA\"".
+func splitDefault(addr string) (string, string, bool) {
+	depth := 0
+	for i := 0; i < len(addr); i++ {
+		switch addr[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case '|':
+			if depth == 0 {
+				return addr[:i], addr[i+1:], true
+			}
+		}
+	}
+	return addr, "", false
+}
+
+// Parse the default value literal, i.e. a number, a quoted string or a boolean.
+func parseDefault(raw string) (*defaultValue, error) {
+	switch {
+	case raw == "true" || raw == "false":
+		return &defaultValue{raw, raw == "true"}, nil
+	case defaultIntRx.MatchString(raw):
+		val, err := strconv.Atoi(raw)
+		if err == nil {
+			return &defaultValue{raw, val}, nil
+		}
+	case defaultFloatRx.MatchString(raw):
+		val, _ := strconv.ParseFloat(raw, 64)
+		return &defaultValue{raw, val}, nil
+	case len(raw) > 1 && raw[0] == '"':
+		val, err := strconv.Unquote(raw)
+		if err == nil {
+			return &defaultValue{raw, val}, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid \"%s\" default value", raw)
 }
 
 func getDataType(path string) (DataType, error) {
-	re := regexp.MustCompile(DATA_TYPE_RX)
-	matches := re.FindStringSubmatch(path)
+	matches := dataTypeRx.FindStringSubmatch(path)
 	if len(matches) > 1 {
 		switch matches[1] {
 		case "Number":
@@ -120,6 +350,10 @@ func getDataType(path string) (DataType, error) {
 			return String, nil
 		case "Boolean":
 			return Boolean, nil
+		case "Date":
+			return Date, nil
+		case "DateTime":
+			return DateTime, nil
 		default:
 			return Unsupported, fmt.Errorf("unsupported \"%s\" type casting", matches[1])
 		}
@@ -144,25 +378,21 @@ func isIgnoredPath(path string, opts *SimplifyOptions) bool {
 }
 
 func trimDataType(path string) string {
-	re := regexp.MustCompile(DATA_TYPE_TRIM_RX)
-	return re.ReplaceAllString(path, "")
+	return dataTypeTrimRx.ReplaceAllString(path, "")
 }
 
 func toNumber(val any) (any, error) {
-	reFloat := regexp.MustCompile(FLOAT_RX)
-	reInt := regexp.MustCompile(INT_RX)
-
 	fromString := func(val string) (any, error) {
-		if reFloat.MatchString(val) {
+		if floatRx.MatchString(val) {
 			result, _ := strconv.ParseFloat(val, 64)
 			return result, nil
 		}
-		if reInt.MatchString(val) {
+		if intRx.MatchString(val) {
 			result, _ := strconv.Atoi(val)
 			return result, nil
 		}
 
-		return 0, fmt.Errorf("invalid conversion from from \"%s\" (string) to number", val)
+		return 0, fmt.Errorf("invalid conversion from \"%s\" (string) to number", val)
 	}
 
 	switch typed := val.(type) {
@@ -175,8 +405,31 @@ func toNumber(val any) (any, error) {
 			return 1, nil
 		}
 		return 0, nil
+	}
+
+	n, ok := number.Of(val)
+	if !ok {
+		return 0, fmt.Errorf("invalid conversion from \"%v\" to number", val)
+	}
+	switch {
+	case n.Kind == number.Signed && n.Int >= math.MinInt && n.Int <= math.MaxInt:
+		return int(n.Int), nil
+	case n.Kind == number.Unsigned && n.Uint <= math.MaxInt:
+		return int(n.Uint), nil
+	default:
+		return toFloat64(n), nil
+	}
+}
+
+// Float value of the number of any numeric type.
+func toFloat64(n number.Number) float64 {
+	switch n.Kind {
+	case number.Signed:
+		return float64(n.Int)
+	case number.Unsigned:
+		return float64(n.Uint)
 	default:
-		return 0, fmt.Errorf("invalid conversion from from \"%v\" to number", val)
+		return n.Float
 	}
 }
 
@@ -191,7 +444,7 @@ func toInteger(val any) (any, error) {
 	case string:
 		res, err := strconv.ParseFloat(val.(string), 64)
 		if err != nil {
-			return 0, fmt.Errorf("invalid conversion from from \"%v\" (string) to integer", val)
+			return 0, fmt.Errorf("invalid conversion from \"%v\" (string) to integer", val)
 		}
 		return int(res), nil
 	case bool:
@@ -199,8 +452,21 @@ func toInteger(val any) (any, error) {
 			return 1, nil
 		}
 		return 0, nil
+	}
+
+	n, ok := number.Of(val)
+	if !ok {
+		return 0, fmt.Errorf("invalid conversion from \"%v\" to integer", val)
+	}
+	switch {
+	case n.Kind == number.Signed && n.Int >= math.MinInt && n.Int <= math.MaxInt:
+		return int(n.Int), nil
+	case n.Kind == number.Unsigned && n.Uint <= math.MaxInt:
+		return int(n.Uint), nil
+	case n.Kind == number.Float && n.Float >= math.MinInt && n.Float < math.MaxInt:
+		return int(n.Float), nil
 	default:
-		return 0, fmt.Errorf("invalid conversion from from \"%v\" to integer", val)
+		return 0, fmt.Errorf("invalid conversion from \"%v\" to integer, out of range", val)
 	}
 }
 
@@ -213,12 +479,18 @@ func toFloat(val any) (any, error) {
 	case string:
 		res, err := strconv.ParseFloat(val.(string), 64)
 		if err != nil {
-			return 0, fmt.Errorf("invalid conversion from from \"%v\" (string) to float", val)
+			return 0, fmt.Errorf("invalid conversion from \"%v\" (string) to float", val)
 		}
 		return res, nil
-	default:
-		return 0, fmt.Errorf("invalid conversion from from \"%v\" to float", val)
+	case bool:
+		return 0, fmt.Errorf("invalid conversion from \"%v\" to float", val)
 	}
+
+	n, ok := number.Of(val)
+	if !ok {
+		return 0, fmt.Errorf("invalid conversion from \"%v\" to float", val)
+	}
+	return toFloat64(n), nil
 }
 
 func toString(val any) string {
@@ -229,6 +501,8 @@ func toString(val any) string {
 		return fmt.Sprintf("%f", val)
 	case string:
 		return fmt.Sprintf("%s", val)
+	case time.Time:
+		return val.(time.Time).Format(time.RFC3339Nano)
 	default:
 		return fmt.Sprintf("%v", val)
 	}
@@ -243,7 +517,7 @@ func toBoolean(val any) (bool, error) {
 		if typed == 0 {
 			return false, nil
 		}
-		return false, fmt.Errorf("invalid conversion from from \"%d\" to boolean", val)
+		return false, fmt.Errorf("invalid conversion from \"%d\" to boolean", val)
 	case string:
 		term := strings.TrimSpace(strings.ToLower(val.(string)))
 		if term == "true" || term == "1" {
@@ -252,68 +526,123 @@ func toBoolean(val any) (bool, error) {
 		if term == "false" || term == "0" {
 			return false, nil
 		}
-		return false, fmt.Errorf("invalid conversion from from \"%s\" to boolean", val)
+		return false, fmt.Errorf("invalid conversion from \"%s\" to boolean", val)
 	case bool:
 		return val.(bool), nil
 	default:
-		return false, fmt.Errorf("invalid conversion from from \"%v\" to boolean", val)
+		return false, fmt.Errorf("invalid conversion from \"%v\" to boolean", val)
+	}
+}
+
+// Interpolate the nested references of the path, returns false if any of the nested references
+// could not be found.
+func resolvePath(flattenContext e.Context, path string) (bool, string, error) {
+	for match := nestedReferenceRx.FindStringSubmatchIndex(path); len(match) > 0; {
+		found, _, val, err := contextLookup(flattenContext, string(path[match[2]:match[3]]))
+		if !found || err != nil {
+			return false, path, err
+		}
+		path = path[0:match[0]] + fmt.Sprintf("%v", val) + path[match[1]:]
+		match = nestedReferenceRx.FindStringSubmatchIndex(path)
 	}
+	return true, path, nil
 }
 
-func contextLookup(flattenContext e.Context, path string) (bool, string, any) {
+func contextLookup(flattenContext e.Context, path string) (bool, string, any, error) {
 	if flattenContext == nil {
-		return false, path, nil
+		return false, path, nil, nil
 	}
 
-	rxPath := regexp.MustCompile(NESTED_REFERENCE_RX)
-	for match := rxPath.FindStringSubmatchIndex(path); len(match) > 0; {
-		found, _, val := contextLookup(flattenContext, string(path[match[2]:match[3]]))
-		if !found {
-			return false, path, nil
-		}
-		path = path[0:match[0]] + fmt.Sprintf("%v", val) + path[match[1]:]
-		match = rxPath.FindStringSubmatchIndex(path)
+	found, path, err := resolvePath(flattenContext, path)
+	if !found || err != nil {
+		return false, path, nil, err
 	}
 
-	if val, ok := flattenContext[path]; ok {
-		return true, path, val
+	ok, val, err := lookupPath(flattenContext, path)
+	if err != nil {
+		return false, path, nil, err
+	}
+	return ok, path, val, nil
+}
+
+func evaluate(ctx e.Context, path string, dt DataType, timeOpts *TimeOptions) (bool, string, any, error) {
+	found, resolvedPath, value, err := contextLookup(e.FlattenContext(ctx), path)
+
+	if err != nil || value == nil {
+		return found, resolvedPath, nil, err
 	}
 
-	return false, path, nil
+	val, err := castValue(caster(dt, timeOpts), value)
+	return found, resolvedPath, val, err
 }
 
-func evaluate(ctx e.Context, path string, dt DataType) (bool, string, any, error) {
-	found, resolvedPath, value := contextLookup(e.FlattenContext(ctx), path)
+// Cast the value, each of the values selected by a path with the selectors, see lookupPath.
+func castValue(cast func(any) (any, error), value any) (any, error) {
+	values, ok := value.([]any)
+	if !ok {
+		return cast(value)
+	}
 
-	if !found || value == nil {
-		return found, resolvedPath, nil, nil
+	res := make([]any, len(values))
+	for i, v := range values {
+		val, err := cast(v)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = val
 	}
+	return res, nil
+}
 
+// Cast function of the given data type.
+func caster(dt DataType, timeOpts *TimeOptions) func(any) (any, error) {
 	switch dt {
+	case Date:
+		return func(val any) (any, error) {
+			return toDate(val, timeOpts)
+		}
+	case DateTime:
+		return func(val any) (any, error) {
+			return toDateTime(val, timeOpts)
+		}
 	case Number:
-		val, err := toNumber(value)
-		return found, resolvedPath, val, err
+		return toNumber
 	case Integer:
-		val, err := toInteger(value)
-		return found, resolvedPath, val, err
+		return toInteger
 	case Float:
-		val, err := toFloat(value)
-		return found, resolvedPath, val, err
+		return toFloat
 	case Boolean:
-		val, err := toBoolean(value)
-		return found, resolvedPath, val, err
+		return func(val any) (any, error) {
+			return toBoolean(val)
+		}
 	case String:
-		return found, resolvedPath, toString(value), nil
+		return func(val any) (any, error) {
+			return toString(val), nil
+		}
 	default:
-		return found, resolvedPath, value, nil
+		return func(val any) (any, error) {
+			return val, nil
+		}
 	}
 }
 
-func New(addr string, serOpts *SerializeOptions, simOpts *SimplifyOptions) (e.Evaluable, error) {
-	dt, err := getDataType(addr)
+// Create a reference, the address could be followed by the data type casting, and by the default
+// value used if the referenced value could not be found, e.g. "points.(Number)|0".
+func New(addr string, serOpts *SerializeOptions, simOpts *SimplifyOptions, timeOpts *TimeOptions) (e.Evaluable, error) {
+	path, raw, defaulted := splitDefault(addr)
+
+	var def *defaultValue
+	if defaulted {
+		var err error
+		if def, err = parseDefault(raw); err != nil {
+			return nil, e.NewParseError(e.ErrInvalidReference, err.Error())
+		}
+	}
+
+	dt, err := getDataType(path)
 	if err != nil {
-		return nil, err
+		return nil, e.NewParseError(e.ErrInvalidReference, err.Error())
 	}
 
-	return reference{addr, trimDataType(addr), dt, serOpts, simOpts}, nil
+	return reference{addr, trimDataType(path), dt, def, serOpts, simOpts, timeOpts}, nil
 }
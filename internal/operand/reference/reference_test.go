@@ -1,22 +1,27 @@
 package reference
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"regexp"
 	"testing"
+	"time"
 
 	. "github.com/spaceavocado/goillogical/evaluable"
 	. "github.com/spaceavocado/goillogical/internal/test"
 )
 
+var timeOpts = DefaultTimeOptions()
+
 func ref(val string) Evaluable {
 	serOpts := DefaultSerializeOptions()
 	simOpts := SimplifyOptions{
 		IgnoredPaths:   []string{},
 		IgnoredPathsRx: []regexp.Regexp{},
 	}
-	e, _ := New(val, &serOpts, &simOpts)
+	e, _ := New(val, &serOpts, &simOpts, &timeOpts)
 	return e
 }
 
@@ -32,11 +37,16 @@ func TestNew(t *testing.T) {
 		expected error
 	}{
 		{"ref.(Invalid)", errors.New("unsupported \"Invalid\" type casting")},
+		{"ref|", errors.New("invalid \"\" default value")},
+		{"ref|CA", errors.New("invalid \"CA\" default value")},
+		{"ref|\"CA", errors.New("invalid \"\"CA\" default value")},
+		{"ref|0.(String)", errors.New("invalid \"0.(String)\" default value")},
+		{"ref.(Invalid)|0", errors.New("unsupported \"Invalid\" type casting")},
 	}
 
 	for _, test := range errs {
 
-		if _, err := New(test.input, &serOpts, &simOpts); err.Error() != test.expected.Error() {
+		if _, err := New(test.input, &serOpts, &simOpts, &timeOpts); err.Error() != test.expected.Error() || !errors.Is(err, ErrInvalidReference) {
 			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, err)
 		}
 	}
@@ -100,6 +110,8 @@ func TestGetDataType(t *testing.T) {
 		{"ref.(Integer)", Integer},
 		{"ref.(Float)", Float},
 		{"ref.(Boolean)", Boolean},
+		{"ref.(Date)", Date},
+		{"ref.(DateTime)", DateTime},
 	}
 
 	for _, test := range tests {
@@ -123,6 +135,7 @@ func TestTrimDataType(t *testing.T) {
 		{"ref", "ref"},
 		{"ref.(X)", "ref.(X)"},
 		{"ref.(String)", "ref"},
+		{"ref.(DateTime)", "ref"},
 	}
 
 	for _, test := range tests {
@@ -142,6 +155,11 @@ func TestToNumber(t *testing.T) {
 		{"1.1", 1.1},
 		{true, 1},
 		{false, 0},
+		{json.Number("5"), 5},
+		{json.Number("5.5"), 5.5},
+		{int64(5), 5},
+		{uint8(3), 3},
+		{uint64(math.MaxUint64), float64(math.MaxUint64)},
 	}
 
 	for _, test := range tests {
@@ -154,8 +172,8 @@ func TestToNumber(t *testing.T) {
 		input    any
 		expected error
 	}{
-		{"1,1", errors.New("invalid conversion from from \"1,1\" (string) to number")},
-		{struct{ a string }{a: "b"}, errors.New("invalid conversion from from \"{b}\" to number")},
+		{"1,1", errors.New("invalid conversion from \"1,1\" (string) to number")},
+		{struct{ a string }{a: "b"}, errors.New("invalid conversion from \"{b}\" to number")},
 	}
 	for _, test := range errs {
 		if _, err := toNumber(test.input); err.Error() != test.expected.Error() {
@@ -177,6 +195,10 @@ func TestToInteger(t *testing.T) {
 		{"1.9", 1},
 		{true, 1},
 		{false, 0},
+		{json.Number("5"), 5},
+		{json.Number("5.9"), 5},
+		{int64(7), 7},
+		{uint16(7), 7},
 	}
 
 	for _, test := range tests {
@@ -189,8 +211,9 @@ func TestToInteger(t *testing.T) {
 		input    any
 		expected error
 	}{
-		{"1,1", errors.New("invalid conversion from from \"1,1\" (string) to integer")},
-		{struct{ a string }{a: "b"}, errors.New("invalid conversion from from \"{b}\" to integer")},
+		{"1,1", errors.New("invalid conversion from \"1,1\" (string) to integer")},
+		{struct{ a string }{a: "b"}, errors.New("invalid conversion from \"{b}\" to integer")},
+		{uint64(math.MaxUint64), errors.New("invalid conversion from \"18446744073709551615\" to integer, out of range")},
 	}
 	for _, test := range errs {
 		if _, err := toInteger(test.input); err.Error() != test.expected.Error() {
@@ -209,6 +232,9 @@ func TestToFloat(t *testing.T) {
 		{"1", 1.0},
 		{"1.1", 1.1},
 		{"1.9", 1.9},
+		{json.Number("5"), 5.0},
+		{int64(2), 2.0},
+		{uint8(1), 1.0},
 	}
 
 	for _, test := range tests {
@@ -221,9 +247,9 @@ func TestToFloat(t *testing.T) {
 		input    any
 		expected error
 	}{
-		{"1,1", errors.New("invalid conversion from from \"1,1\" (string) to float")},
-		{true, errors.New("invalid conversion from from \"true\" to float")},
-		{struct{ a string }{a: "b"}, errors.New("invalid conversion from from \"{b}\" to float")},
+		{"1,1", errors.New("invalid conversion from \"1,1\" (string) to float")},
+		{true, errors.New("invalid conversion from \"true\" to float")},
+		{struct{ a string }{a: "b"}, errors.New("invalid conversion from \"{b}\" to float")},
 	}
 	for _, test := range errs {
 		if _, err := toFloat(test.input); err.Error() != test.expected.Error() {
@@ -259,10 +285,10 @@ func TestToBoolean(t *testing.T) {
 		input    any
 		expected error
 	}{
-		{"yes", errors.New("invalid conversion from from \"yes\" to boolean")},
-		{"bogus", errors.New("invalid conversion from from \"bogus\" to boolean")},
-		{2, errors.New("invalid conversion from from \"2\" to boolean")},
-		{[]int{1}, errors.New("invalid conversion from from \"[1]\" to boolean")},
+		{"yes", errors.New("invalid conversion from \"yes\" to boolean")},
+		{"bogus", errors.New("invalid conversion from \"bogus\" to boolean")},
+		{2, errors.New("invalid conversion from \"2\" to boolean")},
+		{[]int{1}, errors.New("invalid conversion from \"[1]\" to boolean")},
 	}
 	for _, test := range errs {
 		if _, err := toBoolean(test.input); err.Error() != test.expected.Error() {
@@ -280,6 +306,7 @@ func TestToString(t *testing.T) {
 		{1.1, "1.100000"},
 		{"1", "1"},
 		{true, "true"},
+		{time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC), "2024-01-02T15:04:05Z"},
 	}
 
 	for _, test := range tests {
@@ -329,12 +356,37 @@ func TestContextLookup(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		if found, path, value := contextLookup(FlattenContext(ctx), test.input); found != test.found || path != test.path || value != test.value {
-			t.Errorf("input (%v): expected %v/%v/%v, got %v/%v/%v", test.input, test.found, test.path, test.value, found, path, value)
+		lookup := func(c Context) (any, error) {
+			found, path, value, err := contextLookup(c, test.input)
+			if found != test.found || path != test.path || value != test.value || err != nil {
+				t.Errorf("input (%v): expected %v/%v/%v, got %v/%v/%v/%v", test.input, test.found, test.path, test.value, found, path, value, err)
+			}
+			return nil, nil
+		}
+		lookup(FlattenContext(ctx))
+		RunResolver(lookup, NewMapResolver(ctx))
+	}
+
+	for _, input := range []string{"refA", "refB.{refC}"} {
+		_, err := RunResolver(func(c Context) (any, error) {
+			found, _, _, err := contextLookup(c, input)
+			if found {
+				t.Errorf("input (%v): expected not found", input)
+			}
+			return nil, err
+		}, failingResolver{})
+		if err == nil || err.Error() != "failed" {
+			t.Errorf("input (%v): expected %v, got %v", input, "failed", err)
 		}
 	}
 }
 
+type failingResolver struct{}
+
+func (failingResolver) Lookup(string) (any, bool, error) {
+	return nil, false, errors.New("failed")
+}
+
 func TestEvaluate(t *testing.T) {
 	ctx := map[string]any{
 		"refA": 1,
@@ -367,7 +419,7 @@ func TestEvaluate(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		if _, _, value, err := evaluate(ctx, test.path, test.dt); value != test.value || err != nil {
+		if _, _, value, err := evaluate(ctx, test.path, test.dt, &timeOpts); value != test.value || err != nil {
 			t.Errorf("input (%v, %v): expected %v, got %v", test.path, test.dt, test.value, value)
 		}
 	}
@@ -382,15 +434,201 @@ func TestEvaluate(t *testing.T) {
 		output any
 	}{
 		{"refA", 1},
+		{"refA|0", 1},
+		{"refJ|0", 0},
+		{"refJ|-1.5", -1.5},
+		{"refJ|\"CA\"", "CA"},
+		{"refJ|\"a|b\"", "a|b"},
+		{"refJ|true", true},
+		{"refJ.(String)|0", "0"},
+		{"refG.(Number)|0", 1},
+		{"refB.{refJ}|2", 2},
+		{"refB.{refC}|false", 2},
 	}
 	for _, test := range tests2 {
-		eval, _ := New(test.addr, &serOpts, &simOpts)
+		eval, _ := New(test.addr, &serOpts, &simOpts, &timeOpts)
 		if output, err := eval.Evaluate(ctx); output != test.output || err != nil {
 			t.Errorf("input (%v): expected %v, got %v", test.addr, test.output, output)
 		}
 	}
 }
 
+func TestCompile(t *testing.T) {
+	ctx := FlattenContext(map[string]any{
+		"refA": 1,
+		"refB": map[string]any{
+			"refB1": 2,
+			"refB2": "refB1",
+		},
+		"refC": "refB1",
+		"refD": "refB2",
+		"refG": "1",
+		"refH": "val",
+	})
+
+	tests := []struct {
+		addr  string
+		value any
+	}{
+		{"refA", 1},
+		{"refA.(String)", "1"},
+		{"refG.(Number)", 1},
+		{"refB.{refC}", 2},
+		{"refB.{refB.{refD}}", 2},
+		{"refB.{refX}", nil},
+		{"refX", nil},
+		{"ref{", nil},
+		{"refX|0", 0},
+		{"refA|0", 1},
+		{"refX.(String)|1", "1"},
+		{"refB.{refX}|\"x\"", "x"},
+	}
+
+	for _, test := range tests {
+		fn, err := ref(test.addr).(Compilable).Compile()
+		if err != nil {
+			t.Errorf("input (%v): unexpected error %v", test.addr, err)
+			continue
+		}
+		expected, _ := ref(test.addr).Evaluate(ctx)
+		if value, err := fn(ctx); value != test.value || value != expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.addr, test.value, value, err)
+		}
+	}
+
+	fn, _ := ref("refH.(Number)").(Compilable).Compile()
+	expected := errors.New("invalid conversion from \"val\" (string) to number")
+	if _, err := fn(ctx); err == nil || err.Error() != expected.Error() {
+		t.Errorf("input (%v): expected %v, got %v", "refH.(Number)", expected, err)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	ctx := FlattenContext(map[string]any{
+		"refA":   1,
+		"refB":   "refA",
+		"refC":   "1.5",
+		"nested": map[string]any{"key": "value"},
+	})
+
+	var tests = []struct {
+		input    string
+		path     string
+		expected any
+	}{
+		{"refA", "refA", 1},
+		{"{refB}", "refA", 1},
+		{"nested.{refB}", "nested.refA", nil},
+		{"refC.(Number)", "refC", 1.5},
+		{"missing", "missing", nil},
+	}
+
+	for _, test := range tests {
+		trace, err := ref(test.input).(Explainable).Explain(ctx)
+		if err != nil || trace.Kind != Reference || trace.Path != test.path || trace.Value != test.expected {
+			t.Errorf("input (%v): expected %v at %v, got %v/%v", test.input, test.expected, test.path, trace, err)
+		}
+	}
+
+	if trace, err := ref("refB.(Boolean)").(Explainable).Explain(ctx); err == nil || trace.Error != err.Error() {
+		t.Errorf("input (%v): expected error, got %v/%v", "refB.(Boolean)", trace, err)
+	}
+}
+
+func TestPath(t *testing.T) {
+	var tests = []struct {
+		input    string
+		path     string
+		dataType DataType
+		def      any
+	}{
+		{"refA", "refA", Undefined, nil},
+		{"refA.(Number)", "refA", Number, nil},
+		{"address.{segment}.(String)", "address.{segment}", String, nil},
+		{"refA.(Number)|0", "refA", Number, 0},
+		{"address.{segment}|\"CA\"", "address.{segment}", Undefined, "CA"},
+	}
+
+	for _, test := range tests {
+		r := ref(test.input).(reference)
+		if def, _ := r.Default(); r.Path() != test.path || r.DataType() != test.dataType || def != test.def || r.Kind() != Reference || r.Operands() != nil {
+			t.Errorf("input (%v): expected %v/%v/%v, got %v/%v/%v", test.input, test.path, test.dataType, test.def, r.Path(), r.DataType(), def)
+		}
+	}
+}
+
+func TestResolvePath(t *testing.T) {
+	ctx := map[string]any{
+		"segment": "city",
+		"index":   1,
+	}
+
+	var tests = []struct {
+		input string
+		ctx   map[string]any
+		path  string
+		found bool
+	}{
+		{"items", ctx, "items", true},
+		{"address.{segment}.(String)", ctx, "address.city", true},
+		{"items[{index}].price", ctx, "items[1].price", true},
+		{"address.{missing}", ctx, "address.{missing}", false},
+		{"items", nil, "items", false},
+	}
+
+	for _, test := range tests {
+		r := ref(test.input).(reference)
+		if path, found, err := r.ResolvePath(test.ctx); path != test.path || found != test.found || err != nil {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v/%v", test.input, test.path, test.found, path, found, err)
+		}
+	}
+}
+
+func TestResolver(t *testing.T) {
+	resolver := NewMapResolver(map[string]any{
+		"refA": "1",
+		"refB": map[string]any{
+			"refB1": 2,
+		},
+		"refC": "refB1",
+	})
+
+	tests := []struct {
+		addr     string
+		expected any
+	}{
+		{"refA.(Number)", 1},
+		{"refB.{refC}", 2},
+		{"refX", nil},
+	}
+
+	for _, test := range tests {
+		if output, err := EvaluateResolver(ref(test.addr), resolver); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.addr, test.expected, output, err)
+		}
+		fn, _ := ref(test.addr).(Compilable).Compile()
+		if output, err := RunResolver(fn, resolver); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.addr, test.expected, output, err)
+		}
+	}
+
+	if value, e := SimplifyResolver(ref("refB.{refC}"), resolver); value != 2 || e != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", "refB.{refC}", 2, value, e)
+	}
+
+	failing := failingResolver{}
+	if _, err := EvaluateResolver(ref("refA"), failing); err == nil || err.Error() != "failed" {
+		t.Errorf("input (%v): expected %v, got %v", "refA", "failed", err)
+	}
+	fn, _ := ref("refB.{refC}").(Compilable).Compile()
+	if _, err := RunResolver(fn, failing); err == nil || err.Error() != "failed" {
+		t.Errorf("input (%v): expected %v, got %v", "refB.{refC}", "failed", err)
+	}
+	if value, e := SimplifyResolver(ref("refA"), failing); value != nil || Fprint(e) != "{refA}" {
+		t.Errorf("input (%v): expected %v, got %v/%v", "refA", "{refA}", value, e)
+	}
+}
+
 func TestIsIgnoredPath(t *testing.T) {
 	simOpts := SimplifyOptions{
 		IgnoredPaths:   []string{"ignored"},
@@ -427,10 +665,13 @@ func TestSerialize(t *testing.T) {
 	}{
 		{"refA", "$refA"},
 		{"refA.(Number)", "$refA.(Number)"},
+		{"refA|0", "$refA|0"},
+		{"refA.(Number)|1.50", "$refA.(Number)|1.50"},
+		{"refA|\"CA\"", "$refA|\"CA\""},
 	}
 
 	for _, test := range tests {
-		e, _ := New(test.input, &serOpts, &simOpts)
+		e, _ := New(test.input, &serOpts, &simOpts, &timeOpts)
 		if value := e.Serialize(); value != test.value {
 			t.Errorf("input (%v): expected %v, got %v", test.input, test.value, value)
 		}
@@ -467,14 +708,44 @@ func TestSimplify(t *testing.T) {
 		{"ignored", nil, ref("ignored")},
 		{"refC.refB1", nil, ref("refC.refB1")},
 		{"ref", nil, ref("ref")},
+		{"refA|0", 1, nil},
+		{"ref|0", nil, ref("ref|0")},
+		{"ignored|0", nil, ref("ignored|0")},
+	}
+
+	for _, test := range tests {
+		e, _ := New(test.input, &opts, &simOpts, &timeOpts)
+		if value, self := e.Simplify(ctx); value != test.value || Fprint(self) != Fprint(test.e) {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v", test.input, test.value, test.e, value, self)
+		}
+	}
+
+	// Default values used while simplified
+	simOpts.UseDefaults = true
+	tests = []struct {
+		input string
+		value any
+		e     any
+	}{
+		{"refA|0", 1, nil},
+		{"ref|0", 0, nil},
+		{"ref.(String)|0", "0", nil},
+		{"ignored|0", nil, ref("ignored|0")},
+		{"ref", nil, ref("ref")},
+		{"ref[*]|0", nil, ref("ref[*]|0")},
 	}
 
 	for _, test := range tests {
-		e, _ := New(test.input, &opts, &simOpts)
+		e, _ := New(test.input, &opts, &simOpts, &timeOpts)
 		if value, self := e.Simplify(ctx); value != test.value || Fprint(self) != Fprint(test.e) {
 			t.Errorf("input (%v): expected %v/%v, got %v/%v", test.input, test.value, test.e, value, self)
 		}
 	}
+
+	e, _ := New("ref|0", &opts, &simOpts, &timeOpts)
+	if value, self := e.Simplify(nil); value != 0 || self != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", "ref|0", 0, value, self)
+	}
 }
 
 func TestString(t *testing.T) {
@@ -490,10 +761,15 @@ func TestString(t *testing.T) {
 	}{
 		{"refA", "{refA}"},
 		{"refA.(Number)", "{refA.(Number)}"},
+		{"refA.{refB}", "{refA.{refB}}"},
+		{"a}b", "{a\\}b}"},
+		{"a{b", "{a\\{b}"},
+		{"a\\b", "{a\\\\b}"},
+		{"refA|\"x}\"", "{refA|\"x\\}\"}"},
 	}
 
 	for _, test := range tests {
-		e, _ := New(test.input, &opts, &simOpts)
+		e, _ := New(test.input, &opts, &simOpts, &timeOpts)
 		if value := e.String(); value != test.value {
 			t.Errorf("input (%v): expected %v, got %v", test.input, test.value, value)
 		}
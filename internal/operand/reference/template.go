@@ -0,0 +1,126 @@
+package reference
+
+import (
+	"fmt"
+	"strings"
+
+	e "github.com/spaceavocado/goillogical/evaluable"
+)
+
+// Pre-parsed reference path, i.e. a sequence of literal parts and nested references, resolved
+// without matching the nested reference pattern on every lookup.
+//
+// Example:
+//
+//	"address.{segment}" => [literal "address.", nested [literal "segment"]]
+type template []templatePart
+
+type templatePart struct {
+	literal string
+	nested  template
+}
+
+// Parse the path into a template, returns false if the path braces are not balanced.
+func parseTemplate(path string) (template, bool) {
+	res, rest, ok := parseTemplateParts(path)
+	if !ok || rest != "" {
+		return nil, false
+	}
+	return res, true
+}
+
+func parseTemplateParts(path string) (template, string, bool) {
+	res := template{}
+	for len(path) > 0 {
+		i := strings.IndexAny(path, "{}")
+		if i < 0 {
+			return append(res, templatePart{literal: path}), "", true
+		}
+		if i > 0 {
+			res = append(res, templatePart{literal: path[:i]})
+		}
+		if path[i] == '}' {
+			return res, path[i:], true
+		}
+
+		nested, rest, ok := parseTemplateParts(path[i+1:])
+		if !ok || len(nested) == 0 || !strings.HasPrefix(rest, "}") {
+			return nil, "", false
+		}
+		res = append(res, templatePart{nested: nested})
+		path = rest[1:]
+	}
+	return res, "", true
+}
+
+func (t template) lookup(ctx e.Context) (bool, any, error) {
+	path, ok, err := t.resolve(ctx)
+	if !ok || err != nil {
+		return false, nil, err
+	}
+	return lookupPath(ctx, path)
+}
+
+func (t template) resolve(ctx e.Context) (string, bool, error) {
+	if len(t) == 1 && t[0].nested == nil {
+		return t[0].literal, true, nil
+	}
+
+	var sb strings.Builder
+	for _, part := range t {
+		if part.nested == nil {
+			sb.WriteString(part.literal)
+			continue
+		}
+		found, val, err := part.nested.lookup(ctx)
+		if !found || err != nil {
+			return "", false, err
+		}
+		if s, ok := val.(string); ok {
+			sb.WriteString(s)
+		} else {
+			sb.WriteString(fmt.Sprintf("%v", val))
+		}
+	}
+	return sb.String(), true, nil
+}
+
+func (t template) String() string {
+	var sb strings.Builder
+	for _, part := range t {
+		if part.nested == nil {
+			sb.WriteString(part.literal)
+			continue
+		}
+		sb.WriteString("{" + part.nested.String() + "}")
+	}
+	return sb.String()
+}
+
+func (t template) references() []string {
+	res := []string{}
+	for _, part := range t {
+		if part.nested != nil {
+			res = append(res, part.nested.String())
+			res = append(res, part.nested.references()...)
+		}
+	}
+	return res
+}
+
+// Get the nested references of the path, outermost first.
+//
+// Example:
+//
+//	"a.{b.{c}}.{d}" => ["b.{c}", "c", "d"]
+func NestedReferences(path string) []string {
+	if t, ok := parseTemplate(path); ok {
+		return t.references()
+	}
+
+	res := []string{}
+	for _, match := range nestedReferenceRx.FindAllStringSubmatch(path, -1) {
+		res = append(res, match[1])
+	}
+	return res
+}
@@ -0,0 +1,76 @@
+package reference
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTemplate(t *testing.T) {
+	var tests = []struct {
+		input    string
+		expected template
+		ok       bool
+	}{
+		{"a", template{{literal: "a"}}, true},
+		{"a.{b}", template{{literal: "a."}, {nested: template{{literal: "b"}}}}, true},
+		{"{a.{b}}[0]", template{{nested: template{{literal: "a."}, {nested: template{{literal: "b"}}}}}, {literal: "[0]"}}, true},
+		{"a{", nil, false},
+		{"a}", nil, false},
+		{"a{}", nil, false},
+	}
+
+	for _, test := range tests {
+		if output, ok := parseTemplate(test.input); ok != test.ok || !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v", test.input, test.expected, test.ok, output, ok)
+		}
+	}
+}
+
+func TestTemplateLookup(t *testing.T) {
+	ctx := map[string]any{
+		"refA":       1,
+		"refB.refB1": 2,
+		"refC":       "refB1",
+		"refD":       "refC",
+		"refE[1]":    "refA",
+	}
+
+	var tests = []struct {
+		input    string
+		found    bool
+		expected any
+	}{
+		{"refA", true, 1},
+		{"refB.{refC}", true, 2},
+		{"refB.{{refD}}", true, 2},
+		{"{refE[{refA}]}", true, 1},
+		{"refB.{refX}", false, nil},
+		{"refX", false, nil},
+	}
+
+	for _, test := range tests {
+		tmpl, _ := parseTemplate(test.input)
+		if found, output, err := tmpl.lookup(ctx); found != test.found || output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v/%v", test.input, test.found, test.expected, found, output, err)
+		}
+	}
+}
+
+func TestNestedReferences(t *testing.T) {
+	var tests = []struct {
+		input    string
+		expected []string
+	}{
+		{"a", []string{}},
+		{"a.{b}", []string{"b"}},
+		{"a.{b.{c}}.{d}", []string{"b.{c}", "c", "d"}},
+		{"{a}[{b}]", []string{"a", "b"}},
+		{"a.{b}}", []string{"b"}},
+	}
+
+	for _, test := range tests {
+		if output := NestedReferences(test.input); !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, output)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package reference
+
+import (
+	"fmt"
+	"time"
+)
+
+// Reference path resolved by the clock at the evaluation time, rather than the context.
+const NOW string = "NOW"
+
+type TimeOptions struct {
+	// Layouts used to parse a string cast to Date, the RFC 3339 date, i.e. "2006-01-02", and
+	// date-time layouts are always accepted.
+	DateLayouts []string
+	// Layouts used to parse a string cast to DateTime, the RFC 3339 layout is always accepted.
+	DateTimeLayouts []string
+	// Clock resolving the NOW reference.
+	Clock func() time.Time
+	// Location of the calendar days of the Date cast, and of the layouts without a time zone.
+	// Defaults to UTC.
+	Location *time.Location
+}
+
+func DefaultTimeOptions() TimeOptions {
+	return TimeOptions{
+		DateLayouts:     []string{},
+		DateTimeLayouts: []string{},
+		Clock:           time.Now,
+		Location:        time.UTC,
+	}
+}
+
+func (o *TimeOptions) location() *time.Location {
+	if o.Location == nil {
+		return time.UTC
+	}
+	return o.Location
+}
+
+func parseTime(val string, loc *time.Location, layouts ...[]string) (time.Time, bool) {
+	for _, group := range layouts {
+		for _, layout := range group {
+			if t, err := time.ParseInLocation(layout, val, loc); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func toDateTime(val any, opts *TimeOptions) (any, error) {
+	switch typed := val.(type) {
+	case time.Time:
+		return typed, nil
+	case string:
+		if t, ok := parseTime(typed, opts.location(), []string{time.RFC3339Nano}, opts.DateTimeLayouts); ok {
+			return t, nil
+		}
+		return nil, fmt.Errorf("invalid conversion from \"%s\" (string) to date time", typed)
+	default:
+		return nil, fmt.Errorf("invalid conversion from \"%v\" to date time", val)
+	}
+}
+
+// Truncate the time to the midnight of its day in the given location, i.e. the times of the same
+// instant are of the same day regardless of their own location.
+func truncateDate(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+func toDate(val any, opts *TimeOptions) (any, error) {
+	switch typed := val.(type) {
+	case time.Time:
+		return truncateDate(typed, opts.location()), nil
+	case string:
+		if t, ok := parseTime(typed, opts.location(), []string{time.DateOnly}, opts.DateLayouts, []string{time.RFC3339Nano}, opts.DateTimeLayouts); ok {
+			return truncateDate(t, opts.location()), nil
+		}
+		return nil, fmt.Errorf("invalid conversion from \"%s\" (string) to date", typed)
+	default:
+		return nil, fmt.Errorf("invalid conversion from \"%v\" to date", val)
+	}
+}
@@ -0,0 +1,136 @@
+package reference
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	. "github.com/spaceavocado/goillogical/evaluable"
+)
+
+var est = time.FixedZone("EST", -5*60*60)
+
+func TestToDateTime(t *testing.T) {
+	opts := TimeOptions{DateTimeLayouts: []string{time.RFC1123}}
+
+	tests := []struct {
+		input    any
+		expected time.Time
+	}{
+		{time.Date(2024, 1, 2, 15, 4, 5, 0, est), time.Date(2024, 1, 2, 15, 4, 5, 0, est)},
+		{"2024-01-02T15:04:05Z", time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"2024-01-02T15:04:05.5-05:00", time.Date(2024, 1, 2, 15, 4, 5, 500000000, est)},
+		{"Tue, 02 Jan 2024 15:04:05 UTC", time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		if output, err := toDateTime(test.input, &opts); err != nil || !output.(time.Time).Equal(test.expected) {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	errs := []struct {
+		input    any
+		expected error
+	}{
+		{"2024-01-02", errors.New("invalid conversion from \"2024-01-02\" (string) to date time")},
+		{1, errors.New("invalid conversion from \"1\" to date time")},
+	}
+	for _, test := range errs {
+		if _, err := toDateTime(test.input, &opts); err == nil || err.Error() != test.expected.Error() {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, err)
+		}
+	}
+}
+
+func TestToDate(t *testing.T) {
+	opts := TimeOptions{DateLayouts: []string{"02/01/2006"}}
+
+	tests := []struct {
+		input    any
+		expected time.Time
+	}{
+		{time.Date(2024, 1, 2, 15, 4, 5, 0, est), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 1, 2, 20, 4, 5, 0, est), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"2024-01-02", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"02/01/2024", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"2024-01-02T23:04:05-05:00", time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"2024-05-05T10:00:00Z", time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC)},
+		{"2024-05-05T08:00:00+05:00", time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		if output, err := toDate(test.input, &opts); err != nil || output != test.expected {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	// Calendar days in the given location.
+	local := TimeOptions{Location: est}
+	located := []struct {
+		input    any
+		expected time.Time
+	}{
+		{time.Date(2024, 1, 3, 2, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, est)},
+		{"2024-01-02", time.Date(2024, 1, 2, 0, 0, 0, 0, est)},
+		{"2024-01-03T02:00:00Z", time.Date(2024, 1, 2, 0, 0, 0, 0, est)},
+	}
+
+	for _, test := range located {
+		if output, err := toDate(test.input, &local); err != nil || output != test.expected {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	errs := []struct {
+		input    any
+		expected error
+	}{
+		{"01-02-2024", errors.New("invalid conversion from \"01-02-2024\" (string) to date")},
+		{true, errors.New("invalid conversion from \"true\" to date")},
+	}
+	for _, test := range errs {
+		if _, err := toDate(test.input, &opts); err == nil || err.Error() != test.expected.Error() {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, err)
+		}
+	}
+}
+
+func TestNow(t *testing.T) {
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	serOpts := DefaultSerializeOptions()
+	simOpts := SimplifyOptions{IgnoredPaths: []string{}, IgnoredPathsRx: []regexp.Regexp{}}
+	opts := TimeOptions{Clock: func() time.Time { return now }}
+
+	tests := []struct {
+		addr     string
+		expected time.Time
+	}{
+		{"NOW", now},
+		{"NOW.(Date)", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		eval, _ := New(test.addr, &serOpts, &simOpts, &opts)
+		if output, err := eval.Evaluate(nil); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.addr, test.expected, output, err)
+		}
+		fn, _ := eval.(Compilable).Compile()
+		if output, err := fn(Context{"NOW": 1}); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.addr, test.expected, output, err)
+		}
+		if output, self := eval.Simplify(nil); output != test.expected || self != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.addr, test.expected, output, self)
+		}
+		if trace, err := eval.(Explainable).Explain(Context{}); trace.Value != test.expected || trace.Path != NOW || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.addr, test.expected, trace, err)
+		}
+	}
+
+	eval, _ := New("signup.(Date)", &serOpts, &simOpts, &opts)
+	expected := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	if output, err := eval.Evaluate(map[string]any{"signup": "2023-06-01"}); output != expected || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", "signup.(Date)", expected, output, err)
+	}
+}
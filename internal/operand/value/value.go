@@ -1,12 +1,20 @@
 package value
 
 import (
+	"context"
 	e "github.com/spaceavocado/goillogical/evaluable"
 
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
 )
 
+var escaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
 type value struct {
 	val any
 }
@@ -15,6 +23,39 @@ func (v value) Evaluate(ctx e.Context) (any, error) {
 	return v.val, nil
 }
 
+func (v value) EvaluateContext(ctx context.Context, data e.Context) (any, error) {
+	return e.Guard(ctx, func(context.Context) (any, error) {
+		return v.Evaluate(data)
+	})
+}
+
+func (v value) Compile() (e.Func, error) {
+	val := v.val
+	return func(e.Context) (any, error) {
+		return val, nil
+	}, nil
+}
+
+func (v value) Explain(e.Context) (e.Trace, error) {
+	return e.NewTrace(e.Value, v, v.val, nil, nil), nil
+}
+
+func (v value) Kind() e.Kind {
+	return e.Value
+}
+
+func (v value) Operator() string {
+	return ""
+}
+
+func (v value) Operands() []e.Evaluable {
+	return nil
+}
+
+func (v value) WithOperands([]e.Evaluable) e.Evaluable {
+	return v
+}
+
 func (v value) Serialize() any {
 	return v.val
 }
@@ -24,17 +65,33 @@ func (v value) Simplify(e.Context) (any, e.Evaluable) {
 }
 
 func (v value) String() string {
-	switch v.val.(type) {
+	switch typed := v.val.(type) {
 	case string:
-		return fmt.Sprintf("\"%s\"", v.val)
+		return fmt.Sprintf("\"%s\"", escaper.Replace(typed))
+	case time.Time:
+		return fmt.Sprintf("\"%s\"", typed.Format(time.RFC3339Nano))
+	case float64:
+		return formatFloat(typed, 64)
+	case float32:
+		return formatFloat(float64(typed), 32)
 	default:
 		return fmt.Sprintf("%v", v.val)
 	}
 }
 
+// Float in the decimal notation, a whole number has a decimal point, e.g. 1.0, so that it is
+// distinguished from an integer.
+func formatFloat(f float64, bitSize int) string {
+	res := strconv.FormatFloat(f, 'f', -1, bitSize)
+	if math.IsInf(f, 0) || math.IsNaN(f) || strings.Contains(res, ".") {
+		return res
+	}
+	return res + ".0"
+}
+
 func isPrimitive(v any) bool {
 	switch v.(type) {
-	case string, int, int8, int16, int32, int64, float32, float64, bool:
+	case string, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, json.Number, bool, time.Time:
 		return true
 	default:
 		return false
@@ -43,7 +100,7 @@ func isPrimitive(v any) bool {
 
 func New(val any) (e.Evaluable, error) {
 	if !isPrimitive(val) {
-		return nil, errors.New("value could be only primitive type, string, number or bool")
+		return nil, errors.New("value could be only primitive type, string, number, bool or time")
 	}
 	return value{val}, nil
 }
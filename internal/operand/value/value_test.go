@@ -2,7 +2,11 @@ package value
 
 import (
 	"errors"
+	"math"
 	"testing"
+	"time"
+
+	e "github.com/spaceavocado/goillogical/evaluable"
 )
 
 func TestEvaluate(t *testing.T) {
@@ -28,7 +32,7 @@ func TestEvaluate(t *testing.T) {
 		input    any
 		expected error
 	}{
-		{nil, errors.New("value could be only primitive type, string, number or bool")},
+		{nil, errors.New("value could be only primitive type, string, number, bool or time")},
 	}
 	for _, test := range errs {
 		if _, err := New(test.input); err.Error() != test.expected.Error() {
@@ -37,6 +41,41 @@ func TestEvaluate(t *testing.T) {
 	}
 }
 
+func TestCompile(t *testing.T) {
+	tests := []any{1, 1.1, "val", true}
+
+	for _, test := range tests {
+		e, _ := New(test)
+		fn, err := e.(value).Compile()
+		if err != nil {
+			t.Errorf("input (%v): unexpected error %v", test, err)
+			continue
+		}
+		if output, err := fn(map[string]any{}); output != test || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test, test, output, err)
+		}
+	}
+}
+
+func TestExplain(t *testing.T) {
+	tests := []any{1, 1.1, "val", true}
+
+	for _, test := range tests {
+		v, _ := New(test)
+		if trace, err := v.(value).Explain(map[string]any{}); trace.Kind != e.Value || trace.Value != test || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test, test, trace, err)
+		}
+	}
+}
+
+func TestOperands(t *testing.T) {
+	v, _ := New(1)
+	n := v.(e.Node)
+	if n.Kind() != e.Value || n.Operator() != "" || n.Operands() != nil || n.WithOperands(nil) != v {
+		t.Errorf("input (%v): expected Value node, got %v/%v/%v", v, n.Kind(), n.Operator(), n.Operands())
+	}
+}
+
 func TestSerialize(t *testing.T) {
 	tests := []struct {
 		input any
@@ -80,7 +119,7 @@ func TestSimplify(t *testing.T) {
 		input    any
 		expected error
 	}{
-		{nil, errors.New("value could be only primitive type, string, number or bool")},
+		{nil, errors.New("value could be only primitive type, string, number, bool or time")},
 	}
 	for _, test := range errs {
 		if _, err := New(test.input); err.Error() != test.expected.Error() {
@@ -96,9 +135,18 @@ func TestString(t *testing.T) {
 	}{
 		{1, "1"},
 		{1.1, "1.1"},
+		{float64(1), "1.0"},
+		{float64(-2), "-2.0"},
+		{1e21, "1000000000000000000000.0"},
+		{float32(1.5), "1.5"},
+		{math.Inf(1), "+Inf"},
+		{uint64(math.MaxUint64), "18446744073709551615"},
 		{"val", "\"val\""},
+		{"say \"hi\"", "\"say \\\"hi\\\"\""},
+		{"a\\b", "\"a\\\\b\""},
 		{true, "true"},
 		{false, "false"},
+		{time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC), "\"2024-01-02T15:04:05Z\""},
 	}
 
 	for _, test := range tests {
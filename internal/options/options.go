@@ -4,6 +4,7 @@ import (
 	"regexp"
 
 	e "github.com/spaceavocado/goillogical/evaluable"
+	custom "github.com/spaceavocado/goillogical/internal/expression/custom"
 	c "github.com/spaceavocado/goillogical/internal/operand/collection"
 	r "github.com/spaceavocado/goillogical/internal/operand/reference"
 )
@@ -16,29 +17,58 @@ type Options struct {
 	Simplify struct {
 		Reference r.SimplifyOptions
 	}
+	Time            r.TimeOptions
 	OperatorMapping e.OperatorMapping
+	Operators       map[string]custom.Operator
+	Strict          bool
+	ThreeValued     bool
+	Budget          e.Budget
+	Workers         int
+	ContextTag      string
+	QuantifierAlias string
 }
 
 func DefaultOperatorMapping() e.OperatorMapping {
 	return map[e.Kind]string{
-		e.And:     "AND",
-		e.Or:      "OR",
-		e.Nor:     "NOR",
-		e.Xor:     "XOR",
-		e.Not:     "NOT",
-		e.Eq:      "==",
-		e.Ne:      "!=",
-		e.Gt:      ">",
-		e.Ge:      ">=",
-		e.Lt:      "<",
-		e.Le:      "<=",
-		e.Nil:     "NIL",
-		e.Present: "PRESENT",
-		e.In:      "IN",
-		e.Nin:     "NOT IN",
-		e.Overlap: "OVERLAP",
-		e.Prefix:  "PREFIX",
-		e.Suffix:  "SUFFIX",
+		e.And:              "AND",
+		e.Or:               "OR",
+		e.Nor:              "NOR",
+		e.Xor:              "XOR",
+		e.Not:              "NOT",
+		e.Eq:               "==",
+		e.Ne:               "!=",
+		e.Gt:               ">",
+		e.Ge:               ">=",
+		e.Lt:               "<",
+		e.Le:               "<=",
+		e.Nil:              "NIL",
+		e.Present:          "PRESENT",
+		e.In:               "IN",
+		e.Nin:              "NOT IN",
+		e.Overlap:          "OVERLAP",
+		e.Prefix:           "PREFIX",
+		e.Suffix:           "SUFFIX",
+		e.Matches:          "MATCHES",
+		e.IMatches:         "IMATCHES",
+		e.Contains:         "CONTAINS",
+		e.NotContains:      "NOT CONTAINS",
+		e.Between:          "BETWEEN",
+		e.BetweenExclusive: "BETWEEN EXCLUSIVE",
+		e.Before:           "BEFORE",
+		e.After:            "AFTER",
+		e.Add:              "+",
+		e.Sub:              "-",
+		e.Mul:              "*",
+		e.Div:              "/",
+		e.Mod:              "%",
+		e.Abs:              "ABS",
+		e.Min:              "MIN",
+		e.Max:              "MAX",
+		e.Round:            "ROUND",
+		e.Any:              "ANY",
+		e.All:              "ALL",
+		e.None:             "NONE",
+		e.Count:            "COUNT",
 	}
 }
 
@@ -59,6 +89,9 @@ func DefaultOptions() Options {
 				IgnoredPathsRx: []regexp.Regexp{},
 			},
 		},
+		Time:            r.DefaultTimeOptions(),
 		OperatorMapping: DefaultOperatorMapping(),
+		Operators:       map[string]custom.Operator{},
+		ContextTag:      e.DefaultContextTag,
 	}
 }
@@ -5,34 +5,64 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"unicode"
 
 	e "github.com/spaceavocado/goillogical/evaluable"
+	abs "github.com/spaceavocado/goillogical/internal/expression/arithmetic/abs"
+	add "github.com/spaceavocado/goillogical/internal/expression/arithmetic/add"
+	div "github.com/spaceavocado/goillogical/internal/expression/arithmetic/div"
+	maximum "github.com/spaceavocado/goillogical/internal/expression/arithmetic/max"
+	minimum "github.com/spaceavocado/goillogical/internal/expression/arithmetic/min"
+	mod "github.com/spaceavocado/goillogical/internal/expression/arithmetic/mod"
+	mul "github.com/spaceavocado/goillogical/internal/expression/arithmetic/mul"
+	round "github.com/spaceavocado/goillogical/internal/expression/arithmetic/round"
+	sub "github.com/spaceavocado/goillogical/internal/expression/arithmetic/sub"
+	after "github.com/spaceavocado/goillogical/internal/expression/comparison/after"
+	before "github.com/spaceavocado/goillogical/internal/expression/comparison/before"
+	between "github.com/spaceavocado/goillogical/internal/expression/comparison/between"
+	contains "github.com/spaceavocado/goillogical/internal/expression/comparison/contains"
 	eq "github.com/spaceavocado/goillogical/internal/expression/comparison/eq"
 	ge "github.com/spaceavocado/goillogical/internal/expression/comparison/ge"
 	gt "github.com/spaceavocado/goillogical/internal/expression/comparison/gt"
 	in "github.com/spaceavocado/goillogical/internal/expression/comparison/in"
 	le "github.com/spaceavocado/goillogical/internal/expression/comparison/le"
 	lt "github.com/spaceavocado/goillogical/internal/expression/comparison/lt"
+	matches "github.com/spaceavocado/goillogical/internal/expression/comparison/matches"
 	ne "github.com/spaceavocado/goillogical/internal/expression/comparison/ne"
 	null "github.com/spaceavocado/goillogical/internal/expression/comparison/nil"
 	nin "github.com/spaceavocado/goillogical/internal/expression/comparison/nin"
+	notcontains "github.com/spaceavocado/goillogical/internal/expression/comparison/notcontains"
 	overlap "github.com/spaceavocado/goillogical/internal/expression/comparison/overlap"
 	prefix "github.com/spaceavocado/goillogical/internal/expression/comparison/prefix"
 	present "github.com/spaceavocado/goillogical/internal/expression/comparison/present"
 	suffix "github.com/spaceavocado/goillogical/internal/expression/comparison/suffix"
+	custom "github.com/spaceavocado/goillogical/internal/expression/custom"
 	and "github.com/spaceavocado/goillogical/internal/expression/logical/and"
 	nor "github.com/spaceavocado/goillogical/internal/expression/logical/nor"
 	not "github.com/spaceavocado/goillogical/internal/expression/logical/not"
 	or "github.com/spaceavocado/goillogical/internal/expression/logical/or"
 	xor "github.com/spaceavocado/goillogical/internal/expression/logical/xor"
+	all "github.com/spaceavocado/goillogical/internal/expression/quantifier/all"
+	anyOf "github.com/spaceavocado/goillogical/internal/expression/quantifier/any"
+	count "github.com/spaceavocado/goillogical/internal/expression/quantifier/count"
+	none "github.com/spaceavocado/goillogical/internal/expression/quantifier/none"
 	collection "github.com/spaceavocado/goillogical/internal/operand/collection"
 	reference "github.com/spaceavocado/goillogical/internal/operand/reference"
 	value "github.com/spaceavocado/goillogical/internal/operand/value"
 	o "github.com/spaceavocado/goillogical/internal/options"
 )
 
+// Minimal length of the word operator names, e.g. "AND", differing by an edit of a near miss,
+// see isNearMiss. Shorter names, e.g. "IN", are near missed only by the case.
+const minEditedWordOperator = 3
+
+type handler struct {
+	arity   e.Arity
+	factory func([]e.Evaluable) (e.Evaluable, error)
+}
+
 type options struct {
-	OperatorHandlers map[string]func([]e.Evaluable) (e.Evaluable, error)
+	OperatorHandlers map[string]handler
 	Serialize        struct {
 		Reference  reference.SerializeOptions
 		Collection collection.SerializeOptions
@@ -40,28 +70,58 @@ type options struct {
 	Simplify struct {
 		Reference reference.SimplifyOptions
 	}
+	Time        reference.TimeOptions
+	Strict      bool
+	ThreeValued bool
 }
 
-func expressionUnary(op string, factory func(string, e.Evaluable) (e.Evaluable, error)) func([]e.Evaluable) (e.Evaluable, error) {
-	return func(operands []e.Evaluable) (e.Evaluable, error) {
+func expressionUnary(op string, factory func(string, e.Evaluable) (e.Evaluable, error)) handler {
+	return handler{e.Arity{Min: 1, Max: 1}, func(operands []e.Evaluable) (e.Evaluable, error) {
 		return factory(op, operands[0])
-	}
+	}}
 }
 
-func expressionBinary(op string, factory func(string, e.Evaluable, e.Evaluable) (e.Evaluable, error)) func([]e.Evaluable) (e.Evaluable, error) {
-	return func(operands []e.Evaluable) (e.Evaluable, error) {
+func expressionBinary(op string, factory func(string, e.Evaluable, e.Evaluable) (e.Evaluable, error)) handler {
+	return handler{e.Arity{Min: 2, Max: 2}, func(operands []e.Evaluable) (e.Evaluable, error) {
 		return factory(op, operands[0], operands[1])
-	}
+	}}
+}
+
+func expressionTernary(op string, factory func(string, e.Evaluable, e.Evaluable, e.Evaluable) (e.Evaluable, error)) handler {
+	return handler{e.Arity{Min: 3, Max: 3}, func(operands []e.Evaluable) (e.Evaluable, error) {
+		return factory(op, operands[0], operands[1], operands[2])
+	}}
 }
 
-func expressionMany(op string, factory func(string, []e.Evaluable, string, string) (e.Evaluable, error), notOp string, norOp string) func([]e.Evaluable) (e.Evaluable, error) {
-	return func(operands []e.Evaluable) (e.Evaluable, error) {
+func expressionMany(op string, factory func(string, []e.Evaluable, string, string) (e.Evaluable, error), notOp string, norOp string) handler {
+	return handler{e.Arity{Min: 2, Max: -1}, func(operands []e.Evaluable) (e.Evaluable, error) {
 		return factory(op, operands, notOp, norOp)
-	}
+	}}
 }
 
-func operatorHandlers(opts e.OperatorMapping) map[string]func([]e.Evaluable) (e.Evaluable, error) {
-	return map[string]func([]e.Evaluable) (e.Evaluable, error){
+func expressionVariadic(op string, arity e.Arity, factory func(string, []e.Evaluable) (e.Evaluable, error)) handler {
+	return handler{arity, func(operands []e.Evaluable) (e.Evaluable, error) {
+		return factory(op, operands)
+	}}
+}
+
+func expressionQuantifier(op string, arity e.Arity, alias string, factory func(string, string, []e.Evaluable) (e.Evaluable, error)) handler {
+	return handler{arity, func(operands []e.Evaluable) (e.Evaluable, error) {
+		return factory(op, alias, operands)
+	}}
+}
+
+func expressionCustom(op string, operator custom.Operator) handler {
+	return handler{operator.Arity, func(operands []e.Evaluable) (e.Evaluable, error) {
+		if !operator.Arity.Accepts(len(operands)) {
+			return nil, e.NewArityError(operator.Arity, fmt.Sprintf("invalid number of operands, \"%s\" expects %s operand(s), got %d", op, operator.Arity, len(operands)))
+		}
+		return custom.New(op, operands, operator.Handler, operator.Simplify)
+	}}
+}
+
+func operatorHandlers(opts e.OperatorMapping, operators map[string]custom.Operator, alias string) map[string]handler {
+	handlers := map[string]handler{
 		// Logical
 		opts[e.And]: expressionMany(opts[e.And], and.New, opts[e.Not], opts[e.Nor]),
 		opts[e.Or]:  expressionMany(opts[e.Or], or.New, opts[e.Not], opts[e.Nor]),
@@ -69,20 +129,49 @@ func operatorHandlers(opts e.OperatorMapping) map[string]func([]e.Evaluable) (e.
 		opts[e.Xor]: expressionMany(opts[e.Xor], xor.New, opts[e.Not], opts[e.Nor]),
 		opts[e.Not]: expressionUnary(opts[e.Not], not.New),
 		// Comparison
-		opts[e.Eq]:      expressionBinary(opts[e.Eq], eq.New),
-		opts[e.Ne]:      expressionBinary(opts[e.Ne], ne.New),
-		opts[e.Gt]:      expressionBinary(opts[e.Gt], gt.New),
-		opts[e.Ge]:      expressionBinary(opts[e.Ge], ge.New),
-		opts[e.Lt]:      expressionBinary(opts[e.Lt], lt.New),
-		opts[e.Le]:      expressionBinary(opts[e.Le], le.New),
-		opts[e.In]:      expressionBinary(opts[e.In], in.New),
-		opts[e.Nin]:     expressionBinary(opts[e.Nin], nin.New),
-		opts[e.Overlap]: expressionBinary(opts[e.Overlap], overlap.New),
-		opts[e.Nil]:     expressionUnary(opts[e.Nil], null.New),
-		opts[e.Present]: expressionUnary(opts[e.Present], present.New),
-		opts[e.Suffix]:  expressionBinary(opts[e.Suffix], suffix.New),
-		opts[e.Prefix]:  expressionBinary(opts[e.Prefix], prefix.New),
+		opts[e.Eq]:               expressionBinary(opts[e.Eq], eq.New),
+		opts[e.Ne]:               expressionBinary(opts[e.Ne], ne.New),
+		opts[e.Gt]:               expressionBinary(opts[e.Gt], gt.New),
+		opts[e.Ge]:               expressionBinary(opts[e.Ge], ge.New),
+		opts[e.Lt]:               expressionBinary(opts[e.Lt], lt.New),
+		opts[e.Le]:               expressionBinary(opts[e.Le], le.New),
+		opts[e.In]:               expressionBinary(opts[e.In], in.New),
+		opts[e.Nin]:              expressionBinary(opts[e.Nin], nin.New),
+		opts[e.Overlap]:          expressionBinary(opts[e.Overlap], overlap.New),
+		opts[e.Nil]:              expressionUnary(opts[e.Nil], null.New),
+		opts[e.Present]:          expressionUnary(opts[e.Present], present.New),
+		opts[e.Suffix]:           expressionBinary(opts[e.Suffix], suffix.New),
+		opts[e.Prefix]:           expressionBinary(opts[e.Prefix], prefix.New),
+		opts[e.Matches]:          expressionBinary(opts[e.Matches], matches.New),
+		opts[e.IMatches]:         expressionBinary(opts[e.IMatches], matches.NewInsensitive),
+		opts[e.Contains]:         expressionBinary(opts[e.Contains], contains.New),
+		opts[e.NotContains]:      expressionBinary(opts[e.NotContains], notcontains.New),
+		opts[e.Between]:          expressionTernary(opts[e.Between], between.New),
+		opts[e.BetweenExclusive]: expressionTernary(opts[e.BetweenExclusive], between.NewExclusive),
+		opts[e.Before]:           expressionBinary(opts[e.Before], before.New),
+		opts[e.After]:            expressionBinary(opts[e.After], after.New),
+		// Arithmetic
+		opts[e.Add]:   expressionBinary(opts[e.Add], add.New),
+		opts[e.Sub]:   expressionBinary(opts[e.Sub], sub.New),
+		opts[e.Mul]:   expressionBinary(opts[e.Mul], mul.New),
+		opts[e.Div]:   expressionBinary(opts[e.Div], div.New),
+		opts[e.Mod]:   expressionBinary(opts[e.Mod], mod.New),
+		opts[e.Abs]:   expressionUnary(opts[e.Abs], abs.New),
+		opts[e.Min]:   expressionVariadic(opts[e.Min], e.Arity{Min: 2, Max: -1}, minimum.New),
+		opts[e.Max]:   expressionVariadic(opts[e.Max], e.Arity{Min: 2, Max: -1}, maximum.New),
+		opts[e.Round]: expressionVariadic(opts[e.Round], e.Arity{Min: 1, Max: 2}, round.New),
+		// Quantifier
+		opts[e.Any]:   expressionQuantifier(opts[e.Any], e.Arity{Min: 2, Max: 2}, alias, anyOf.New),
+		opts[e.All]:   expressionQuantifier(opts[e.All], e.Arity{Min: 2, Max: 2}, alias, all.New),
+		opts[e.None]:  expressionQuantifier(opts[e.None], e.Arity{Min: 2, Max: 2}, alias, none.New),
+		opts[e.Count]: expressionQuantifier(opts[e.Count], e.Arity{Min: 1, Max: 2}, alias, count.New),
 	}
+
+	for op, operator := range operators {
+		handlers[op] = expressionCustom(op, operator)
+	}
+
+	return handlers
 }
 
 type Parser interface {
@@ -115,27 +204,27 @@ func createOperand(input any, opts *options) (e.Evaluable, error) {
 	if t == reflect.Slice {
 		v := reflect.ValueOf(input)
 		if v.Len() == 0 {
-			return nil, errors.New("invalid undefined operand")
+			return nil, e.NewParseError(e.ErrInvalidOperand, "invalid undefined operand")
 		}
 
 		operands := make([]e.Evaluable, v.Len())
 		for i := 0; i < v.Len(); i++ {
-			e, err := parse(v.Index(i).Interface(), opts)
+			eval, err := parse(v.Index(i).Interface(), opts)
 			if err != nil {
-				return nil, err
+				return nil, e.LocateParseError(err, i)
 			}
-			operands[i] = e
+			operands[i] = eval
 		}
 		return collection.New(operands, &opts.Serialize.Collection)
 	}
 
 	addr, err := toReferenceAddr(input, &opts.Serialize.Reference)
 	if err == nil {
-		return reference.New(addr, &opts.Serialize.Reference, &opts.Simplify.Reference)
+		return reference.New(addr, &opts.Serialize.Reference, &opts.Simplify.Reference, &opts.Time)
 	}
 
 	if !e.IsEvaluatedPrimitive(input) {
-		return nil, fmt.Errorf("invalid operand, %v", input)
+		return nil, e.NewParseError(e.ErrInvalidOperand, fmt.Sprintf("invalid operand, %v", input))
 	}
 
 	return value.New(input)
@@ -148,27 +237,198 @@ func createExpression(expression []any, opts *options) (e.Evaluable, error) {
 	case string:
 		handler, ok := opts.OperatorHandlers[typed]
 		if !ok {
-			return nil, errors.New("unexpected logical operator")
+			return nil, e.NewParseError(e.ErrUnexpectedOperator, fmt.Sprintf("unexpected logical operator \"%s\"", typed))
+		}
+
+		if len(operands) < handler.arity.Min || (opts.Strict && !handler.arity.Accepts(len(operands))) {
+			return nil, e.NewArityError(handler.arity, fmt.Sprintf("invalid number of operands, \"%s\" expects %s operand(s), got %d", typed, handler.arity, len(operands)))
 		}
 
 		ops := make([]e.Evaluable, len(operands))
 		for i := 0; i < len(operands); i++ {
-			e, err := parse(operands[i], opts)
+			eval, err := parse(operands[i], opts)
 			if err != nil {
-				return nil, err
+				return nil, e.LocateParseError(err, i+1)
 			}
-			ops[i] = e
+			ops[i] = eval
+		}
+
+		eval, err := handler.factory(ops)
+		if err != nil || !opts.ThreeValued {
+			return eval, err
 		}
+		if t, ok := eval.(e.ThreeValued); ok {
+			return t.ThreeValued(), nil
+		}
+		return eval, nil
+	default:
+		return nil, e.NewParseError(e.ErrUnexpectedOperator, "unexpected logical expression")
+	}
+}
+
+// Normalize the operator name, i.e. upper case with the underscores and repeated whitespace
+// collapsed into a single space, e.g. "not_in" is "NOT IN".
+func normalizeOperator(operator string) string {
+	return strings.Join(strings.Fields(strings.ToUpper(strings.ReplaceAll(operator, "_", " "))), " ")
+}
+
+func isWord(operator string) bool {
+	return strings.IndexFunc(operator, unicode.IsLetter) >= 0
+}
 
-		return handler(ops)
+// Determine whether the normalized names are at most a single edit apart, i.e. an insertion, a
+// deletion, a substitution or a transposition of two adjacent characters.
+func isSingleEdit(a []rune, b []rune) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	switch len(b) - len(a) {
+	case 0:
+		diff := []int{}
+		for i := range a {
+			if a[i] != b[i] {
+				diff = append(diff, i)
+			}
+		}
+		if len(diff) <= 1 {
+			return true
+		}
+		return len(diff) == 2 && diff[1] == diff[0]+1 && a[diff[0]] == b[diff[1]] && a[diff[1]] == b[diff[0]]
+	case 1:
+		i := 0
+		for i < len(a) && a[i] == b[i] {
+			i++
+		}
+		return string(a[i:]) == string(b[i+1:])
 	default:
-		return nil, errors.New("unexpected logical expression")
+		return false
+	}
+}
+
+// Determine whether the unknown operator is a near miss of the registered operator name, i.e. it
+// is equal ignoring the case and the separators, e.g. "not_in", or a single edit away, e.g. "=<"
+// or "ANDD". The word operators must be at least minEditedWordOperator long to be edited.
+func isNearMiss(operator string, name string) bool {
+	a, b := normalizeOperator(operator), normalizeOperator(name)
+	if a == b {
+		return true
 	}
+	if (isWord(a) || isWord(b)) && min(len([]rune(a)), len([]rune(b))) < minEditedWordOperator {
+		return false
+	}
+	return isSingleEdit([]rune(a), []rune(b))
+}
+
+// Determine whether the operator is an upper-case word, i.e. it is written as the word operators
+// are, e.g. "EQQ" or "GREATER".
+func isUpperWord(operator string) bool {
+	if operator == "" || !unicode.IsUpper([]rune(operator)[0]) {
+		return false
+	}
+	for _, r := range operator {
+		if !unicode.IsUpper(r) && !unicode.IsDigit(r) && r != '_' && r != ' ' {
+			return false
+		}
+	}
+	return true
 }
 
+// Heuristic determining whether an unknown expression operator is meant to be an operator, i.e.
+// it is a near miss of a registered operator name, see isNearMiss, e.g. ["=<", 1, 1], or an
+// upper-case word, e.g. ["EQQ", "$a", 1]. A word operator must be also followed by a reference or
+// a nested expression, so that collections of words, e.g. ["CA", "US"] or ["NOTE", "text"], are
+// kept.
+func isOperatorLike(expression []any, opts *options) bool {
+	operator, ok := expression[0].(string)
+	if !ok {
+		return false
+	}
+
+	nearMiss := isUpperWord(operator)
+	for name := range opts.OperatorHandlers {
+		if nearMiss {
+			break
+		}
+		nearMiss = name != "" && isNearMiss(operator, name)
+	}
+	if !nearMiss {
+		return false
+	}
+	if !isWord(operator) {
+		return true
+	}
+
+	for _, operand := range expression[1:] {
+		if operand == nil {
+			continue
+		}
+		if reflect.TypeOf(operand).Kind() == reflect.Slice {
+			return true
+		}
+		if _, err := toReferenceAddr(operand, &opts.Serialize.Reference); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Determine whether the expression is a collection of the registered operator names, e.g.
+// ["+", "-"].
+func isOperatorList(expression []any, opts *options) bool {
+	for _, item := range expression {
+		name, ok := item.(string)
+		if !ok {
+			return false
+		}
+		if _, ok := opts.OperatorHandlers[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// In the strict mode, an expression with a known operator, unless it is a collection of the
+// operator names, e.g. ["+", "-"], or an unknown operator-like operator, is never reinterpreted
+// as a collection.
+func isStrictExpression(expression []any, opts *options) bool {
+	if operator, ok := expression[0].(string); ok {
+		if _, ok := opts.OperatorHandlers[operator]; ok {
+			return !isOperatorList(expression, opts)
+		}
+	}
+	return isOperatorLike(expression, opts)
+}
+
+func toSlice(input any) []any {
+	v := reflect.ValueOf(input)
+	res := make([]any, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		res[i] = v.Index(i).Interface()
+	}
+	return res
+}
+
+// Parse the raw expression node, any error originating from the node itself is annotated with
+// the raw node fragment.
 func parse(input any, opts *options) (e.Evaluable, error) {
+	eval, err := parseNode(input, opts)
+	if err == nil {
+		return eval, nil
+	}
+
+	var pe *e.ParseError
+	if !errors.As(err, &pe) {
+		return nil, &e.ParseError{Fragment: input, Cause: e.ErrInvalidOperand, Message: err.Error()}
+	}
+	if pe.Path == "" && pe.Fragment == nil {
+		pe.Fragment = input
+	}
+	return nil, pe
+}
+
+func parseNode(input any, opts *options) (e.Evaluable, error) {
 	if input == nil {
-		return nil, errors.New("unexpected input")
+		return nil, e.NewParseError(e.ErrUnexpectedInput, "unexpected input")
 	}
 
 	t := reflect.TypeOf(input).Kind()
@@ -176,29 +436,35 @@ func parse(input any, opts *options) (e.Evaluable, error) {
 		return createOperand(input, opts)
 	}
 
-	v := reflect.ValueOf(input)
+	expression := toSlice(input)
 
-	if v.Len() < 2 {
-		return createOperand(input, opts)
+	if len(expression) < 2 {
+		return createOperand(expression, opts)
 	}
 
-	operator := v.Index(0).Interface()
+	operator := expression[0]
 	if isEscaped(fmt.Sprintf("%v", operator), opts.Serialize.Collection.EscapeCharacter) {
-		return createOperand(append([]any{operator.(string)[1:]}, v.Slice(1, v.Len()).Interface().([]any)...), opts)
+		return createOperand(append([]any{operator.(string)[1:]}, expression[1:]...), opts)
 	}
 
-	e, err := createExpression(input.([]any), opts)
+	eval, err := createExpression(expression, opts)
 	if err != nil {
-		return createOperand(input, opts)
+		if errors.Is(err, e.ErrInvalidPattern) || (opts.Strict && isStrictExpression(expression, opts)) {
+			return nil, err
+		}
+		return createOperand(expression, opts)
 	}
 
-	return e, nil
+	return eval, nil
 }
 
 func New(opts *o.Options) Parser {
 	return &parser{opts: options{
-		OperatorHandlers: operatorHandlers(opts.OperatorMapping),
+		OperatorHandlers: operatorHandlers(opts.OperatorMapping, opts.Operators, opts.QuantifierAlias),
 		Serialize:        opts.Serialize,
 		Simplify:         opts.Simplify,
+		Time:             opts.Time,
+		Strict:           opts.Strict,
+		ThreeValued:      opts.ThreeValued,
 	}}
 }
@@ -6,26 +6,47 @@ import (
 	"testing"
 
 	. "github.com/spaceavocado/goillogical/evaluable"
+	abs "github.com/spaceavocado/goillogical/internal/expression/arithmetic/abs"
+	add "github.com/spaceavocado/goillogical/internal/expression/arithmetic/add"
+	div "github.com/spaceavocado/goillogical/internal/expression/arithmetic/div"
+	maximum "github.com/spaceavocado/goillogical/internal/expression/arithmetic/max"
+	minimum "github.com/spaceavocado/goillogical/internal/expression/arithmetic/min"
+	mod "github.com/spaceavocado/goillogical/internal/expression/arithmetic/mod"
+	mul "github.com/spaceavocado/goillogical/internal/expression/arithmetic/mul"
+	round "github.com/spaceavocado/goillogical/internal/expression/arithmetic/round"
+	sub "github.com/spaceavocado/goillogical/internal/expression/arithmetic/sub"
+	after "github.com/spaceavocado/goillogical/internal/expression/comparison/after"
+	before "github.com/spaceavocado/goillogical/internal/expression/comparison/before"
+	between "github.com/spaceavocado/goillogical/internal/expression/comparison/between"
+	contains "github.com/spaceavocado/goillogical/internal/expression/comparison/contains"
 	eq "github.com/spaceavocado/goillogical/internal/expression/comparison/eq"
 	ge "github.com/spaceavocado/goillogical/internal/expression/comparison/ge"
 	gt "github.com/spaceavocado/goillogical/internal/expression/comparison/gt"
 	in "github.com/spaceavocado/goillogical/internal/expression/comparison/in"
 	le "github.com/spaceavocado/goillogical/internal/expression/comparison/le"
 	lt "github.com/spaceavocado/goillogical/internal/expression/comparison/lt"
+	matches "github.com/spaceavocado/goillogical/internal/expression/comparison/matches"
 	ne "github.com/spaceavocado/goillogical/internal/expression/comparison/ne"
 	null "github.com/spaceavocado/goillogical/internal/expression/comparison/nil"
 	nin "github.com/spaceavocado/goillogical/internal/expression/comparison/nin"
+	notcontains "github.com/spaceavocado/goillogical/internal/expression/comparison/notcontains"
 	prefix "github.com/spaceavocado/goillogical/internal/expression/comparison/prefix"
 	present "github.com/spaceavocado/goillogical/internal/expression/comparison/present"
 	suffix "github.com/spaceavocado/goillogical/internal/expression/comparison/suffix"
+	custom "github.com/spaceavocado/goillogical/internal/expression/custom"
 	and "github.com/spaceavocado/goillogical/internal/expression/logical/and"
 	nor "github.com/spaceavocado/goillogical/internal/expression/logical/nor"
 	not "github.com/spaceavocado/goillogical/internal/expression/logical/not"
 	or "github.com/spaceavocado/goillogical/internal/expression/logical/or"
 	xor "github.com/spaceavocado/goillogical/internal/expression/logical/xor"
+	all "github.com/spaceavocado/goillogical/internal/expression/quantifier/all"
+	anyOf "github.com/spaceavocado/goillogical/internal/expression/quantifier/any"
+	count "github.com/spaceavocado/goillogical/internal/expression/quantifier/count"
+	none "github.com/spaceavocado/goillogical/internal/expression/quantifier/none"
 	. "github.com/spaceavocado/goillogical/internal/mock"
 	reference "github.com/spaceavocado/goillogical/internal/operand/reference"
 	. "github.com/spaceavocado/goillogical/internal/options"
+	. "github.com/spaceavocado/goillogical/internal/test"
 )
 
 func addr(val string, opts Options) string {
@@ -174,6 +195,14 @@ func TestComparison(t *testing.T) {
 		{[]any{opts.OperatorMapping[Present], 1, 1}, ExpUnary("OP", present.New, Val(1))},
 		{[]any{opts.OperatorMapping[Suffix], 1, 1}, ExpBinary("OP", suffix.New, Val(1), Val(1))},
 		{[]any{opts.OperatorMapping[Prefix], 1, 1}, ExpBinary("OP", prefix.New, Val(1), Val(1))},
+		{[]any{opts.OperatorMapping[Matches], 1, "a"}, ExpBinary("OP", matches.New, Val(1), Val("a"))},
+		{[]any{opts.OperatorMapping[IMatches], 1, "a"}, ExpBinary("OP", matches.NewInsensitive, Val(1), Val("a"))},
+		{[]any{opts.OperatorMapping[Contains], 1, 1}, ExpBinary("OP", contains.New, Val(1), Val(1))},
+		{[]any{opts.OperatorMapping[NotContains], 1, 1}, ExpBinary("OP", notcontains.New, Val(1), Val(1))},
+		{[]any{opts.OperatorMapping[Between], 1, 0, 2}, ExpTernary("OP", between.New, Val(1), Val(0), Val(2))},
+		{[]any{opts.OperatorMapping[BetweenExclusive], 1, 0, 2}, ExpTernary("OP", between.NewExclusive, Val(1), Val(0), Val(2))},
+		{[]any{opts.OperatorMapping[Before], 1, 1}, ExpBinary("OP", before.New, Val(1), Val(1))},
+		{[]any{opts.OperatorMapping[After], 1, 1}, ExpBinary("OP", after.New, Val(1), Val(1))},
 	}
 
 	for _, test := range tests {
@@ -205,6 +234,71 @@ func TestLogical(t *testing.T) {
 	}
 }
 
+func TestArithmetic(t *testing.T) {
+	opts := DefaultOptions()
+	parser := New(&opts)
+
+	var tests = []struct {
+		input    []any
+		expected Evaluable
+	}{
+		{[]any{opts.OperatorMapping[Add], 1, "$a"}, ExpBinary("OP", add.New, Val(1), Ref("a"))},
+		{[]any{opts.OperatorMapping[Sub], 1, "$a"}, ExpBinary("OP", sub.New, Val(1), Ref("a"))},
+		{[]any{opts.OperatorMapping[Mul], 1, "$a"}, ExpBinary("OP", mul.New, Val(1), Ref("a"))},
+		{[]any{opts.OperatorMapping[Div], 1, "$a"}, ExpBinary("OP", div.New, Val(1), Ref("a"))},
+		{[]any{opts.OperatorMapping[Mod], 1, "$a"}, ExpBinary("OP", mod.New, Val(1), Ref("a"))},
+		{[]any{opts.OperatorMapping[Abs], "$a"}, ExpUnary("OP", abs.New, Ref("a"))},
+		{[]any{opts.OperatorMapping[Min], 1, 2, "$a"}, ExpVariadic("OP", minimum.New, Val(1), Val(2), Ref("a"))},
+		{[]any{opts.OperatorMapping[Max], 1, "$a"}, ExpVariadic("OP", maximum.New, Val(1), Ref("a"))},
+		{[]any{opts.OperatorMapping[Round], "$a"}, ExpVariadic("OP", round.New, Ref("a"))},
+		{[]any{opts.OperatorMapping[Round], "$a", 2}, ExpVariadic("OP", round.New, Ref("a"), Val(2))},
+		{[]any{opts.OperatorMapping[Gt], []any{opts.OperatorMapping[Sub], "$a", "$b"}, 100}, ExpBinary("OP", gt.New, ExpBinary("OP", sub.New, Ref("a"), Ref("b")), Val(100))},
+		// Invalid number of operands, i.e. collections
+		{[]any{opts.OperatorMapping[Min], 1}, Col(Val("MIN"), Val(1))},
+		{[]any{opts.OperatorMapping[Round], 1, 2, 3}, Col(Val("ROUND"), Val(1), Val(2), Val(3))},
+	}
+
+	for _, test := range tests {
+		if output, err := parser.Parse(test.input); output.String() != test.expected.String() || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+}
+
+func TestQuantifier(t *testing.T) {
+	opts := DefaultOptions()
+	parser := New(&opts)
+
+	predicate := []any{opts.OperatorMapping[Gt], "$.price", 40}
+	expected := ExpBinary("OP", gt.New, Ref(".price"), Val(40))
+
+	var tests = []struct {
+		input    []any
+		expected Evaluable
+	}{
+		{[]any{opts.OperatorMapping[Any], "$items", predicate}, ExpQuantifier("OP", "", anyOf.New, Ref("items"), expected)},
+		{[]any{opts.OperatorMapping[All], "$items", predicate}, ExpQuantifier("OP", "", all.New, Ref("items"), expected)},
+		{[]any{opts.OperatorMapping[None], "$items", predicate}, ExpQuantifier("OP", "", none.New, Ref("items"), expected)},
+		{[]any{opts.OperatorMapping[Count], "$items"}, ExpQuantifier("OP", "", count.New, Ref("items"))},
+		{[]any{opts.OperatorMapping[Count], "$items", predicate}, ExpQuantifier("OP", "", count.New, Ref("items"), expected)},
+		// Invalid number of operands, i.e. collections
+		{[]any{opts.OperatorMapping[Any], "$items"}, Col(Val("ANY"), Ref("items"))},
+	}
+
+	for _, test := range tests {
+		if output, err := parser.Parse(test.input); output.String() != test.expected.String() || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	opts.QuantifierAlias = "item"
+	parser = New(&opts)
+	output, err := parser.Parse([]any{"ANY", "$items", []any{"==", "$item.price", 40}})
+	if err != nil || output.(interface{ Alias() string }).Alias() != "item" {
+		t.Errorf("input (%v): expected alias %v, got %v/%v", output, "item", output, err)
+	}
+}
+
 func TestInvalid(t *testing.T) {
 	opts := DefaultOptions()
 	parser := New(&opts)
@@ -215,9 +309,10 @@ func TestInvalid(t *testing.T) {
 	}{
 		{nil, errors.New("unexpected input")},
 		{[]any{}, errors.New("invalid undefined operand")},
-		{[]any{struct{ int }{5}}, errors.New("invalid operand, {5}")},
-		{[]any{"val1", struct{ int }{5}}, errors.New("invalid operand, {5}")},
-		{[]any{"==", struct{ int }{5}}, errors.New("invalid operand, {5}")},
+		{[]any{struct{ int }{5}}, errors.New("invalid operand, {5}, at \"/0\"")},
+		{[]any{"val1", struct{ int }{5}}, errors.New("invalid operand, {5}, at \"/1\"")},
+		{[]any{"==", struct{ int }{5}}, errors.New("invalid operand, {5}, at \"/1\"")},
+		{[]any{"AND", true, []any{"MATCHES", "$a", "("}}, errors.New("invalid pattern \"(\", error parsing regexp: missing closing ): `(`, at \"/2\"")},
 	}
 
 	for _, test := range tests {
@@ -226,3 +321,230 @@ func TestInvalid(t *testing.T) {
 		}
 	}
 }
+
+func TestStrict(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Strict = true
+	parser := New(&opts)
+
+	var tests = []struct {
+		input    any
+		expected Evaluable
+	}{
+		{[]any{opts.OperatorMapping[Eq], 1, 1}, ExpBinary("OP", eq.New, Val(1), Val(1))},
+		{[]any{"CA", "US"}, Col(Val("CA"), Val("US"))},
+		{[]any{"val", "$ref"}, Col(Val("val"), Ref("ref"))},
+		{[]any{"\\==", 1}, Col(Val("=="), Val(1))},
+		{[]any{"\\CA", "$home"}, Col(Val("CA"), Ref("home"))},
+		{[]any{"Ca", "$home"}, Col(Val("Ca"), Ref("home"))},
+		{[]any{"NOTE", "text"}, Col(Val("NOTE"), Val("text"))},
+		{[]any{"+", "-"}, Col(Val("+"), Val("-"))},
+		{[]any{"IN", "$op", []any{"+", "-"}}, ExpBinary("OP", in.New, Ref("op"), Col(Val("+"), Val("-")))},
+		{[]int{1, 2}, Col(Val(1), Val(2))},
+	}
+
+	for _, test := range tests {
+		if output, err := parser.Parse(test.input); err != nil || output.String() != test.expected.String() {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	var errs = []struct {
+		input    any
+		expected error
+		cause    error
+	}{
+		{[]any{"AND", []any{"==", 1}, true}, errors.New("invalid number of operands, \"==\" expects 2 operand(s), got 1, at \"/1\""), ErrOperatorArity},
+		{[]any{"==", 1, 2, 3}, errors.New("invalid number of operands, \"==\" expects 2 operand(s), got 3"), ErrOperatorArity},
+		{[]any{"NOT", true, false}, errors.New("invalid number of operands, \"NOT\" expects 1 operand(s), got 2"), ErrOperatorArity},
+		{[]any{"AND", true}, errors.New("invalid number of operands, \"AND\" expects at least 2 operand(s), got 1"), ErrOperatorArity},
+		{[]any{"ANDD", "$a", true}, errors.New("unexpected logical operator \"ANDD\""), ErrUnexpectedOperator},
+		{[]any{"EQQ", "$a", 1}, errors.New("unexpected logical operator \"EQQ\""), ErrUnexpectedOperator},
+		{[]any{"GREATER", "$a", 1}, errors.New("unexpected logical operator \"GREATER\""), ErrUnexpectedOperator},
+		{[]any{"MATCH", "$a", "x"}, errors.New("unexpected logical operator \"MATCH\""), ErrUnexpectedOperator},
+		{[]any{"NOT_EQUAL", 1, []any{"==", 1, 1}}, errors.New("unexpected logical operator \"NOT_EQUAL\""), ErrUnexpectedOperator},
+		{[]any{"CA", "$home"}, errors.New("unexpected logical operator \"CA\""), ErrUnexpectedOperator},
+		{[]any{"and", "$a", true}, errors.New("unexpected logical operator \"and\""), ErrUnexpectedOperator},
+		{[]any{"NOT_IN", 1, []any{1}}, errors.New("unexpected logical operator \"NOT_IN\""), ErrUnexpectedOperator},
+		{[]any{"=<", 1, 1}, errors.New("unexpected logical operator \"=<\""), ErrUnexpectedOperator},
+	}
+
+	for _, test := range errs {
+		if _, err := parser.Parse(test.input); err == nil || err.Error() != test.expected.Error() || !errors.Is(err, test.cause) {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, err)
+		}
+	}
+
+	opts.Strict = false
+	parser = New(&opts)
+
+	var lenient = []struct {
+		input    any
+		expected Evaluable
+	}{
+		{[]any{"==", 1}, Col(Val("=="), Val(1))},
+		{[]any{"EQQ", "$a", 1}, Col(Val("EQQ"), Ref("a"), Val(1))},
+		{[]any{"NOT", true, false}, ExpUnary("OP", not.New, Val(true))},
+	}
+
+	for _, test := range lenient {
+		if output, err := parser.Parse(test.input); err != nil || output.String() != test.expected.String() {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+}
+
+func TestIsUpperWord(t *testing.T) {
+	var tests = []struct {
+		input    string
+		expected bool
+	}{
+		{"EQQ", true},
+		{"NOT_IN", true},
+		{"NOT IN", true},
+		{"X2", true},
+		{"", false},
+		{"Eq", false},
+		{"eqq", false},
+		{"2X", false},
+		{"=<", false},
+		{"A-B", false},
+	}
+
+	for _, test := range tests {
+		if output := isUpperWord(test.input); output != test.expected {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, output)
+		}
+	}
+}
+
+func TestIsNearMiss(t *testing.T) {
+	var tests = []struct {
+		operator string
+		name     string
+		expected bool
+	}{
+		{"not_in", "NOT IN", true},
+		{"Not  In", "NOT IN", true},
+		{"=<", "<=", true},
+		{"===", "==", true},
+		{"ANDD", "AND", true},
+		{"AN", "AND", false},
+		{"NAD", "AND", true},
+		{"BETWEN", "BETWEEN", true},
+		{"EQQ", "==", false},
+		{"CA", "IN", false},
+		{"IS", "IN", false},
+		{"NO", "NOR", false},
+		{"<>", "<=", true},
+		{"+-", "+", true},
+		{"**", "/", false},
+	}
+
+	for _, test := range tests {
+		if output := isNearMiss(test.operator, test.name); output != test.expected {
+			t.Errorf("input (%v, %v): expected %v, got %v", test.operator, test.name, test.expected, output)
+		}
+	}
+}
+
+func TestThreeValued(t *testing.T) {
+	opts := DefaultOptions()
+	opts.ThreeValued = true
+	parser := New(&opts)
+
+	var tests = []struct {
+		input    any
+		expected any
+	}{
+		{[]any{opts.OperatorMapping[Eq], "$missing", 1}, TruthUnknown},
+		{[]any{opts.OperatorMapping[Not], []any{opts.OperatorMapping[Eq], "$missing", 1}}, TruthUnknown},
+		{[]any{opts.OperatorMapping[Nil], "$missing"}, TruthTrue},
+		{[]any{opts.OperatorMapping[Any], []any{1, 2}, []any{opts.OperatorMapping[Eq], "$.", 2}}, TruthTrue},
+		{[]any{opts.OperatorMapping[Add], "$a", 1}, 2},
+	}
+
+	for _, test := range tests {
+		output, err := parser.Parse(test.input)
+		if err != nil {
+			t.Errorf("input (%v): unexpected error %v", test.input, err)
+			continue
+		}
+		if value, err := output.Evaluate(map[string]any{"a": 1}); value != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, value, err)
+		}
+	}
+}
+
+func TestCustom(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Operators["SUM"] = custom.Operator{
+		Arity:   Arity{Min: 2, Max: 3},
+		Handler: func([]any) (any, error) { return 0, nil },
+	}
+	parser := New(&opts)
+
+	var tests = []struct {
+		input    any
+		expected string
+	}{
+		{[]any{"SUM", 1, "$a"}, "(1 SUM {a})"},
+		{[]any{"SUM", 1, 2, []any{"SUM", 3, 4}}, "(1 SUM 2, (3 SUM 4))"},
+		{[]any{"==", []any{"SUM", 1, 2}, 3}, "((1 SUM 2) == 3)"},
+	}
+
+	for _, test := range tests {
+		if output, err := parser.Parse(test.input); err != nil || output.String() != test.expected {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	opts.Strict = true
+	parser = New(&opts)
+
+	var errs = []struct {
+		input    any
+		expected error
+	}{
+		{[]any{"SUM", 1, 2, 3, 4}, errors.New("invalid number of operands, \"SUM\" expects 2 to 3 operand(s), got 4")},
+		{[]any{"AND", true, []any{"SUM", 1}}, errors.New("invalid number of operands, \"SUM\" expects 2 to 3 operand(s), got 1, at \"/2\"")},
+	}
+
+	for _, test := range errs {
+		if _, err := parser.Parse(test.input); err == nil || err.Error() != test.expected.Error() || !errors.Is(err, ErrOperatorArity) {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, err)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Strict = true
+	parser := New(&opts)
+
+	var errs = []struct {
+		input    any
+		path     string
+		fragment any
+		arity    *Arity
+		cause    error
+	}{
+		{nil, "", nil, nil, ErrUnexpectedInput},
+		{[]any{}, "", []any{}, nil, ErrInvalidOperand},
+		{[]any{"AND", []any{"==", 1, 1}, []any{"OR", true, []any{"==", struct{}{}, 1}}}, "/2/2/1", struct{}{}, nil, ErrInvalidOperand},
+		{[]any{"AND", true, []any{"NOT", true, false}}, "/2", []any{"NOT", true, false}, &Arity{Min: 1, Max: 1}, ErrOperatorArity},
+		{[]any{"OR", true, []any{"ANDD", "$a", 1}}, "/2", []any{"ANDD", "$a", 1}, nil, ErrUnexpectedOperator},
+		{[]any{"==", "$a.(Bogus)", 1}, "/1", "$a.(Bogus)", nil, ErrInvalidReference},
+		{[]any{1, []any{2, struct{}{}}}, "/1/1", struct{}{}, nil, ErrInvalidOperand},
+		{[]any{"NOT", []any{"IMATCHES", "$a", "["}}, "/1", []any{"IMATCHES", "$a", "["}, nil, ErrInvalidPattern},
+	}
+
+	for _, test := range errs {
+		_, err := parser.Parse(test.input)
+
+		var pe *ParseError
+		if !errors.As(err, &pe) || pe.Path != test.path || Fprint(pe.Fragment) != Fprint(test.fragment) || Fprint(pe.Arity) != Fprint(test.arity) || !errors.Is(err, test.cause) {
+			t.Errorf("input (%v): expected %v/%v/%v/%v, got %v", test.input, test.path, test.fragment, test.arity, test.cause, err)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package statement
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type tokenKind byte
+
+const (
+	tokenEOF tokenKind = iota
+	tokenLeftParen
+	tokenRightParen
+	tokenLeftBracket
+	tokenRightBracket
+	tokenComma
+	tokenReference
+	tokenString
+	tokenNumber
+	tokenOperator
+	tokenWord
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value any
+	pos   int
+}
+
+const ANGLE_OPERATOR_RX string = `^<[a-z]+( [a-z]+)*>`
+const NUMBER_RX string = `^-?\d+(\.\d+)?([eE][+-]?\d+)?`
+const WORD_RX string = `^[A-Za-z_][A-Za-z0-9_]*`
+
+var angleOperatorRx = regexp.MustCompile(ANGLE_OPERATOR_RX)
+var numberRx = regexp.MustCompile(NUMBER_RX)
+var wordRx = regexp.MustCompile(WORD_RX)
+
+var symbolOperators = []string{"==", "!=", ">=", "<=", ">", "<", "+", "-", "*", "/", "%"}
+
+type lexer struct {
+	input  string
+	pos    int
+	tokens []token
+}
+
+func (l *lexer) emit(kind tokenKind, text string, value any, pos int) {
+	l.tokens = append(l.tokens, token{kind, text, value, pos})
+}
+
+// Reference address enclosed in the braces at the current position, honoring nested
+// {references}, and the backslash escaped characters, e.g. {a\}b} => "a}b". Returns the
+// address and the length of the reference.
+func (l *lexer) reference() (string, int, error) {
+	var sb strings.Builder
+	depth := 0
+	for i := l.pos; i < len(l.input); i++ {
+		switch l.input[i] {
+		case '\\':
+			if i+1 == len(l.input) {
+				return "", 0, fmt.Errorf("unterminated reference at position %d", l.pos)
+			}
+			i++
+			sb.WriteByte(l.input[i])
+			continue
+		case '{':
+			depth++
+			if depth == 1 {
+				continue
+			}
+		case '}':
+			depth--
+			if depth == 0 {
+				return sb.String(), i + 1 - l.pos, nil
+			}
+		}
+		sb.WriteByte(l.input[i])
+	}
+	return "", 0, fmt.Errorf("unterminated reference at position %d", l.pos)
+}
+
+func (l *lexer) string() (string, int, error) {
+	var sb strings.Builder
+	for i := l.pos + 1; i < len(l.input); i++ {
+		switch l.input[i] {
+		case '\\':
+			if i+1 == len(l.input) {
+				return "", 0, fmt.Errorf("unterminated string at position %d", l.pos)
+			}
+			i++
+			sb.WriteByte(l.input[i])
+		case '"':
+			return sb.String(), i + 1 - l.pos, nil
+		default:
+			sb.WriteByte(l.input[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string at position %d", l.pos)
+}
+
+// A minus sign directly followed by a digit is a negative number, unless it follows an operand
+// in which case it is an operator.
+func (l *lexer) followsOperand() bool {
+	if len(l.tokens) == 0 {
+		return false
+	}
+	switch t := l.tokens[len(l.tokens)-1]; t.kind {
+	case tokenRightParen, tokenRightBracket, tokenReference, tokenString, tokenNumber:
+		return true
+	case tokenWord:
+		return t.text == "true" || t.text == "false"
+	default:
+		return false
+	}
+}
+
+func (l *lexer) next() error {
+	rest := l.input[l.pos:]
+	start := l.pos
+
+	switch rest[0] {
+	case '(':
+		l.emit(tokenLeftParen, "(", nil, start)
+		l.pos++
+		return nil
+	case ')':
+		l.emit(tokenRightParen, ")", nil, start)
+		l.pos++
+		return nil
+	case '[':
+		l.emit(tokenLeftBracket, "[", nil, start)
+		l.pos++
+		return nil
+	case ']':
+		l.emit(tokenRightBracket, "]", nil, start)
+		l.pos++
+		return nil
+	case ',':
+		l.emit(tokenComma, ",", nil, start)
+		l.pos++
+		return nil
+	case '{':
+		addr, n, err := l.reference()
+		if err != nil {
+			return err
+		}
+		l.emit(tokenReference, rest[:n], addr, start)
+		l.pos += n
+		return nil
+	case '"':
+		val, n, err := l.string()
+		if err != nil {
+			return err
+		}
+		l.emit(tokenString, rest[:n], val, start)
+		l.pos += n
+		return nil
+	}
+
+	if m := angleOperatorRx.FindString(rest); m != "" {
+		l.emit(tokenOperator, m, nil, start)
+		l.pos += len(m)
+		return nil
+	}
+
+	if m := numberRx.FindString(rest); m != "" && (m[0] != '-' || !l.followsOperand()) {
+		l.emit(tokenNumber, m, nil, start)
+		l.pos += len(m)
+		return nil
+	}
+
+	for _, op := range symbolOperators {
+		if strings.HasPrefix(rest, op) {
+			l.emit(tokenOperator, op, nil, start)
+			l.pos += len(op)
+			return nil
+		}
+	}
+
+	if m := wordRx.FindString(rest); m != "" {
+		l.emit(tokenWord, m, nil, start)
+		l.pos += len(m)
+		return nil
+	}
+
+	return fmt.Errorf("unexpected character %q at position %d", rest[0], start)
+}
+
+// Determine whether the custom operator name could be expressed in the statement, i.e. it is a
+// single word, e.g. "WITHIN", not reserved by the statement syntax, e.g. "AND", "NOT" or "true".
+func IsOperatorName(name string) bool {
+	if name == "" || wordRx.FindString(name) != name {
+		return false
+	}
+	if _, ok := logicalOperators[name]; ok {
+		return false
+	}
+	if _, ok := arithmeticFunctions[name]; ok {
+		return false
+	}
+	return name != notOperator && name != "true" && name != "false"
+}
+
+func tokenize(input string) ([]token, error) {
+	l := &lexer{input: input}
+	for l.pos < len(l.input) {
+		switch l.input[l.pos] {
+		case ' ', '\t', '\n', '\r':
+			l.pos++
+			continue
+		}
+		if err := l.next(); err != nil {
+			return nil, err
+		}
+	}
+	l.emit(tokenEOF, "", nil, l.pos)
+	return l.tokens, nil
+}
@@ -0,0 +1,102 @@
+package statement
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	var tests = []struct {
+		input    string
+		expected []tokenKind
+	}{
+		{"", []tokenKind{tokenEOF}},
+		{"()[],", []tokenKind{tokenLeftParen, tokenRightParen, tokenLeftBracket, tokenRightBracket, tokenComma, tokenEOF}},
+		{"{a.{b}}", []tokenKind{tokenReference, tokenEOF}},
+		{`{a\}b} {a\{b}`, []tokenKind{tokenReference, tokenReference, tokenEOF}},
+		{`"val"`, []tokenKind{tokenString, tokenEOF}},
+		{"1, -1.5, 2e3", []tokenKind{tokenNumber, tokenComma, tokenNumber, tokenComma, tokenNumber, tokenEOF}},
+		{"== != >= <= > <", []tokenKind{tokenOperator, tokenOperator, tokenOperator, tokenOperator, tokenOperator, tokenOperator, tokenEOF}},
+		{"<in> <not in> <is nil>", []tokenKind{tokenOperator, tokenOperator, tokenOperator, tokenEOF}},
+		{"{a} <{b}", []tokenKind{tokenReference, tokenOperator, tokenReference, tokenEOF}},
+		{"AND true", []tokenKind{tokenWord, tokenWord, tokenEOF}},
+		{"+ - * / %", []tokenKind{tokenOperator, tokenOperator, tokenOperator, tokenOperator, tokenOperator, tokenEOF}},
+		{"{a}-1", []tokenKind{tokenReference, tokenOperator, tokenNumber, tokenEOF}},
+		{"(1)-1", []tokenKind{tokenLeftParen, tokenNumber, tokenRightParen, tokenOperator, tokenNumber, tokenEOF}},
+		{"1 - -1", []tokenKind{tokenNumber, tokenOperator, tokenNumber, tokenEOF}},
+		{"ABS -1", []tokenKind{tokenWord, tokenNumber, tokenEOF}},
+		{"true -1", []tokenKind{tokenWord, tokenOperator, tokenNumber, tokenEOF}},
+	}
+
+	for _, test := range tests {
+		output, err := tokenize(test.input)
+		if err != nil || len(output) != len(test.expected) {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+			continue
+		}
+		for i, token := range output {
+			if token.kind != test.expected[i] {
+				t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, output)
+				break
+			}
+		}
+	}
+
+	var values = []struct {
+		input    string
+		expected any
+	}{
+		{"{a.{b}}", "a.{b}"},
+		{`"say \"hi\""`, `say "hi"`},
+		{`"a\\b"`, `a\b`},
+	}
+
+	for _, test := range values {
+		if output, err := tokenize(test.input); err != nil || output[0].value != test.expected {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	var errs = []struct {
+		input    string
+		expected error
+	}{
+		{"{a", errors.New("unterminated reference at position 0")},
+		{`{a\`, errors.New("unterminated reference at position 0")},
+		{`"a`, errors.New("unterminated string at position 0")},
+		{`"a\`, errors.New("unterminated string at position 0")},
+		{"1 # 2", errors.New("unexpected character '#' at position 2")},
+	}
+
+	for _, test := range errs {
+		if _, err := tokenize(test.input); err == nil || err.Error() != test.expected.Error() {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, err)
+		}
+	}
+}
+
+func TestIsOperatorName(t *testing.T) {
+	var tests = []struct {
+		input    string
+		expected bool
+	}{
+		{"WITHIN", true},
+		{"near_by2", true},
+		{"BETWEEN", true},
+		{"", false},
+		{"NEAR BY", false},
+		{"~=", false},
+		{"==", false},
+		{"2X", false},
+		{"AND", false},
+		{"NOT", false},
+		{"ABS", false},
+		{"true", false},
+	}
+
+	for _, test := range tests {
+		if output := IsOperatorName(test.input); output != test.expected {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, output)
+		}
+	}
+}
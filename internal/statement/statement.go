@@ -0,0 +1,437 @@
+// Statement is the text grammar of the expressions, i.e. the form produced by Evaluable.String(),
+// parsed back into the raw expression form.
+package statement
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	e "github.com/spaceavocado/goillogical/evaluable"
+	o "github.com/spaceavocado/goillogical/internal/options"
+)
+
+// Text form of the comparison operators, as rendered by the comparison and quantifier
+// expressions.
+var comparisonOperators = map[string]e.Kind{
+	"==":                  e.Eq,
+	"!=":                  e.Ne,
+	">":                   e.Gt,
+	">=":                  e.Ge,
+	"<":                   e.Lt,
+	"<=":                  e.Le,
+	"<in>":                e.In,
+	"<not in>":            e.Nin,
+	"<overlaps>":          e.Overlap,
+	"<prefixes>":          e.Prefix,
+	"<with suffix>":       e.Suffix,
+	"<matches>":           e.Matches,
+	"<imatches>":          e.IMatches,
+	"<contains>":          e.Contains,
+	"<not contains>":      e.NotContains,
+	"<between>":           e.Between,
+	"<between exclusive>": e.BetweenExclusive,
+	"<before>":            e.Before,
+	"<after>":             e.After,
+	"<is nil>":            e.Nil,
+	"<is present>":        e.Present,
+	"<any>":               e.Any,
+	"<all>":               e.All,
+	"<none>":              e.None,
+	"<count>":             e.Count,
+}
+
+// Text form of the logical operators, as rendered by the logical expressions.
+var logicalOperators = map[string]e.Kind{
+	"AND": e.And,
+	"OR":  e.Or,
+	"NOR": e.Nor,
+	"XOR": e.Xor,
+}
+
+// Text form of the arithmetic operators, as rendered by the arithmetic expressions, grouped by
+// their precedence.
+var additiveOperators = map[string]e.Kind{
+	"+": e.Add,
+	"-": e.Sub,
+}
+
+var multiplicativeOperators = map[string]e.Kind{
+	"*": e.Mul,
+	"/": e.Div,
+	"%": e.Mod,
+}
+
+// Text form of the arithmetic functions, as rendered by the arithmetic expressions, and the
+// maximal number of their arguments, negative for unbounded number of arguments.
+var arithmeticFunctions = map[string]struct {
+	kind e.Kind
+	max  int
+}{
+	"ABS":   {e.Abs, 1},
+	"MIN":   {e.Min, -1},
+	"MAX":   {e.Max, -1},
+	"ROUND": {e.Round, 2},
+}
+
+const notOperator string = "NOT"
+
+type Parser interface {
+	Parse(statement string) (any, error)
+}
+
+type parser struct {
+	opts *o.Options
+}
+
+type state struct {
+	tokens []token
+	pos    int
+	opts   *o.Options
+}
+
+// Parse the statement into the raw expression form, e.g.
+// `(({a} == 5) AND ({b} <in> [1, 2]))` => ["AND", ["==", "$a", 5], ["IN", "$b", [1, 2]]]
+func (p parser) Parse(statement string) (any, error) {
+	tokens, err := tokenize(statement)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &state{tokens: tokens, opts: p.opts}
+	res, err := s.logical()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := s.peek(); t.kind != tokenEOF {
+		return nil, unexpected(t)
+	}
+	return res, nil
+}
+
+func unexpected(t token) error {
+	if t.kind == tokenEOF {
+		return fmt.Errorf("unexpected end of statement at position %d", t.pos)
+	}
+	return fmt.Errorf("unexpected \"%s\" at position %d", t.text, t.pos)
+}
+
+func (s *state) peek() token {
+	return s.tokens[s.pos]
+}
+
+func (s *state) advance() token {
+	t := s.tokens[s.pos]
+	if t.kind != tokenEOF {
+		s.pos++
+	}
+	return t
+}
+
+func (s *state) expect(kind tokenKind) (token, error) {
+	t := s.advance()
+	if t.kind != kind {
+		return t, unexpected(t)
+	}
+	return t, nil
+}
+
+func (s *state) operator(kind e.Kind, t token) (string, error) {
+	op, ok := s.opts.OperatorMapping[kind]
+	if !ok {
+		return "", fmt.Errorf("unsupported operator \"%s\" at position %d", t.text, t.pos)
+	}
+	return op, nil
+}
+
+func (s *state) logicalOperator(t token) (e.Kind, bool) {
+	if t.kind != tokenWord {
+		return e.Unknown, false
+	}
+	kind, ok := logicalOperators[t.text]
+	return kind, ok
+}
+
+// Raw operator of the comparison operator token, i.e. built-in comparison operators, or custom
+// operators written by their name.
+func (s *state) comparisonOperator(t token) (string, bool, error) {
+	if t.kind != tokenOperator && t.kind != tokenWord {
+		return "", false, nil
+	}
+	if _, ok := s.opts.Operators[t.text]; ok && t.kind == tokenWord {
+		return t.text, true, nil
+	}
+	kind, ok := comparisonOperators[t.text]
+	if !ok {
+		return "", false, nil
+	}
+	op, err := s.operator(kind, t)
+	return op, true, err
+}
+
+func (s *state) isOperandStart(t token) bool {
+	switch t.kind {
+	case tokenLeftParen, tokenLeftBracket, tokenReference, tokenString, tokenNumber:
+		return true
+	case tokenWord:
+		_, ok := arithmeticFunctions[t.text]
+		return ok || t.text == "true" || t.text == "false"
+	default:
+		return false
+	}
+}
+
+// logical := negation { OPERATOR negation }, where all the operators within a sequence must
+// be the same, i.e. mixed operators must be grouped with parentheses.
+func (s *state) logical() (any, error) {
+	first, err := s.negation()
+	if err != nil {
+		return nil, err
+	}
+
+	opToken := s.peek()
+	kind, ok := s.logicalOperator(opToken)
+	if !ok {
+		return first, nil
+	}
+
+	op, err := s.operator(kind, opToken)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []any{op, first}
+	for {
+		t := s.peek()
+		next, ok := s.logicalOperator(t)
+		if !ok {
+			break
+		}
+		if next != kind {
+			return nil, fmt.Errorf("mixed logical operators \"%s\" and \"%s\" at position %d, use parentheses", opToken.text, t.text, t.pos)
+		}
+		s.advance()
+
+		operand, err := s.negation()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, operand)
+	}
+	return res, nil
+}
+
+// negation := NOT negation | comparison
+func (s *state) negation() (any, error) {
+	t := s.peek()
+	if t.kind != tokenWord || t.text != notOperator {
+		return s.comparison()
+	}
+	s.advance()
+
+	op, err := s.operator(e.Not, t)
+	if err != nil {
+		return nil, err
+	}
+
+	operand, err := s.negation()
+	if err != nil {
+		return nil, err
+	}
+	return []any{op, operand}, nil
+}
+
+// comparison := arithmetic [ OPERATOR [ arithmetic { "," arithmetic } ] ]
+func (s *state) comparison() (any, error) {
+	left, err := s.arithmetic()
+	if err != nil {
+		return nil, err
+	}
+
+	op, ok, err := s.comparisonOperator(s.peek())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return left, nil
+	}
+	s.advance()
+
+	res := []any{op, left}
+	if !s.isOperandStart(s.peek()) {
+		return res, nil
+	}
+
+	for {
+		right, err := s.arithmetic()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, right)
+
+		if s.peek().kind != tokenComma {
+			return res, nil
+		}
+		s.advance()
+	}
+}
+
+// arithmetic := term { ( "+" | "-" ) term }
+func (s *state) arithmetic() (any, error) {
+	return s.binary(additiveOperators, s.term)
+}
+
+// term := factor { ( "*" | "/" | "%" ) factor }
+func (s *state) term() (any, error) {
+	return s.binary(multiplicativeOperators, s.factor)
+}
+
+// Left associative sequence of the binary arithmetic operators of the same precedence, e.g.
+// {a} - {b} - 1 => ["-", ["-", "$a", "$b"], 1]
+func (s *state) binary(operators map[string]e.Kind, operand func() (any, error)) (any, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t := s.peek()
+		kind, ok := operators[t.text]
+		if t.kind != tokenOperator || !ok {
+			return left, nil
+		}
+		s.advance()
+
+		op, err := s.operator(kind, t)
+		if err != nil {
+			return nil, err
+		}
+
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = []any{op, left, right}
+	}
+}
+
+// factor := FUNCTION arithmetic { "," arithmetic } | primary
+func (s *state) factor() (any, error) {
+	t := s.peek()
+	fn, ok := arithmeticFunctions[t.text]
+	if t.kind != tokenWord || !ok {
+		return s.primary()
+	}
+	s.advance()
+
+	op, err := s.operator(fn.kind, t)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []any{op}
+	for {
+		arg, err := s.arithmetic()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, arg)
+
+		if (fn.max >= 0 && len(res) > fn.max) || s.peek().kind != tokenComma {
+			return res, nil
+		}
+		s.advance()
+	}
+}
+
+// primary := "(" logical ")" | operand
+func (s *state) primary() (any, error) {
+	if s.peek().kind != tokenLeftParen {
+		return s.operand()
+	}
+	s.advance()
+
+	res, err := s.logical()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.expect(tokenRightParen); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// operand := reference | string | number | boolean | collection
+func (s *state) operand() (any, error) {
+	t := s.advance()
+	switch t.kind {
+	case tokenReference:
+		return s.opts.Serialize.Reference.To(t.value.(string)), nil
+	case tokenString:
+		return t.value, nil
+	case tokenNumber:
+		return number(t)
+	case tokenWord:
+		switch t.text {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	case tokenLeftBracket:
+		return s.collection()
+	}
+	return nil, unexpected(t)
+}
+
+// collection := "[" logical { "," logical } "]"
+func (s *state) collection() (any, error) {
+	res := []any{}
+	for {
+		item, err := s.logical()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, item)
+
+		t := s.advance()
+		if t.kind == tokenRightBracket {
+			break
+		}
+		if t.kind != tokenComma {
+			return nil, unexpected(t)
+		}
+	}
+
+	// Collection head matching an operator must be escaped, otherwise it would be parsed
+	// as an expression.
+	if head, ok := res[0].(string); ok {
+		if _, escaped := s.opts.Serialize.Collection.EscapedOperators[head]; escaped {
+			res[0] = s.opts.Serialize.Collection.EscapeCharacter + head
+		}
+	}
+	return res, nil
+}
+
+func number(t token) (any, error) {
+	if !strings.ContainsAny(t.text, ".eE") {
+		if val, err := strconv.Atoi(t.text); err == nil {
+			return val, nil
+		}
+		if val, err := strconv.ParseUint(t.text, 10, 64); err == nil {
+			return val, nil
+		}
+	}
+
+	val, err := strconv.ParseFloat(t.text, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number \"%s\" at position %d", t.text, t.pos)
+	}
+	return val, nil
+}
+
+func New(opts *o.Options) Parser {
+	return &parser{opts}
+}
@@ -0,0 +1,103 @@
+package statement
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/spaceavocado/goillogical/evaluable"
+	custom "github.com/spaceavocado/goillogical/internal/expression/custom"
+	. "github.com/spaceavocado/goillogical/internal/options"
+	. "github.com/spaceavocado/goillogical/internal/test"
+)
+
+func TestParse(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Serialize.Collection.EscapedOperators["=="] = true
+	parser := New(&opts)
+
+	var tests = []struct {
+		input    string
+		expected any
+	}{
+		{"1", 1},
+		{"-1.5", -1.5},
+		{`"val"`, "val"},
+		{"true", true},
+		{"{a}", "$a"},
+		{"{a.{b}.(Number)}", "$a.{b}.(Number)"},
+		{"[1, \"val\", {a}]", []any{1, "val", "$a"}},
+		{"[\"==\", 1]", []any{"\\==", 1}},
+		{"({a} == 1)", []any{"==", "$a", 1}},
+		{"{a} == 1", []any{"==", "$a", 1}},
+		{"({a} <is nil>)", []any{"NIL", "$a"}},
+		{"({a} <not in> [1, 2])", []any{"NOT IN", "$a", []any{1, 2}}},
+		{"({a} <matches> \"^a\")", []any{"MATCHES", "$a", "^a"}},
+		{"({a} <imatches> {b})", []any{"IMATCHES", "$a", "$b"}},
+		{"({a} <contains> \"b\")", []any{"CONTAINS", "$a", "b"}},
+		{"([1, 2] <not contains> {b})", []any{"NOT CONTAINS", []any{1, 2}, "$b"}},
+		{"({a} <between> 1, {b})", []any{"BETWEEN", "$a", 1, "$b"}},
+		{"({a} <between exclusive> \"a\", \"c\")", []any{"BETWEEN EXCLUSIVE", "$a", "a", "c"}},
+		{"({a.(DateTime)} <before> \"2024-01-02\")", []any{"BEFORE", "$a.(DateTime)", "2024-01-02"}},
+		{"({a} <after> {NOW})", []any{"AFTER", "$a", "$NOW"}},
+		{"({items} <any> ({.price} > 40))", []any{"ANY", "$items", []any{">", "$.price", 40}}},
+		{"({items} <all> {.active})", []any{"ALL", "$items", "$.active"}},
+		{"({items} <none> ({.} <in> [1, 2]))", []any{"NONE", "$items", []any{"IN", "$.", []any{1, 2}}}},
+		{"({items} <count>) > 2", []any{">", []any{"COUNT", "$items"}, 2}},
+		{"({items} <count> ({.price} > 40))", []any{"COUNT", "$items", []any{">", "$.price", 40}}},
+		{"(({a} - {b}) > 100)", []any{">", []any{"-", "$a", "$b"}, 100}},
+		{"{a} - {b} - 1", []any{"-", []any{"-", "$a", "$b"}, 1}},
+		{"{a} + {b} * 2 >= {c} % 3", []any{">=", []any{"+", "$a", []any{"*", "$b", 2}}, []any{"%", "$c", 3}}},
+		{"({a} + {b}) / -2", []any{"/", []any{"+", "$a", "$b"}, -2}},
+		{"{a} -1", []any{"-", "$a", 1}},
+		{"(ABS -5)", []any{"ABS", -5}},
+		{"(MIN {a}, 1, {b} * 2)", []any{"MIN", "$a", 1, []any{"*", "$b", 2}}},
+		{"(ROUND ({a} / 3), 2) == 1", []any{"==", []any{"ROUND", []any{"/", "$a", 3}, 2}, 1}},
+		{"{a} <between> ABS {b}, MAX 1, 2", []any{"BETWEEN", "$a", []any{"ABS", "$b"}, []any{"MAX", 1, 2}}},
+		{"[1, {a} + 1]", []any{1, []any{"+", "$a", 1}}},
+		{"(({a} == 1) AND ({b} > 2) AND ({c} <is present>))", []any{"AND", []any{"==", "$a", 1}, []any{">", "$b", 2}, []any{"PRESENT", "$c"}}},
+		{"{a} == 1 OR {b} == 2", []any{"OR", []any{"==", "$a", 1}, []any{"==", "$b", 2}}},
+		{"(NOT ({a} == 1))", []any{"NOT", []any{"==", "$a", 1}}},
+		{"NOT NOT true", []any{"NOT", []any{"NOT", true}}},
+		{"((true XOR false) NOR (1 != 2))", []any{"NOR", []any{"XOR", true, false}, []any{"!=", 1, 2}}},
+	}
+
+	for _, test := range tests {
+		if output, err := parser.Parse(test.input); Fprint(output) != Fprint(test.expected) || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, Fprint(test.expected), Fprint(output), err)
+		}
+	}
+
+	var errs = []struct {
+		input    string
+		expected error
+	}{
+		{"", errors.New("unexpected end of statement at position 0")},
+		{"(1 == 1", errors.New("unexpected end of statement at position 7")},
+		{"1 == 1)", errors.New("unexpected \")\" at position 6")},
+		{"[1 2]", errors.New("unexpected \"2\" at position 3")},
+		{"bogus", errors.New("unexpected \"bogus\" at position 0")},
+		{"{a} + ", errors.New("unexpected end of statement at position 6")},
+		{"(ABS)", errors.New("unexpected \")\" at position 4")},
+		{"true AND false OR true", errors.New("mixed logical operators \"AND\" and \"OR\" at position 15, use parentheses")},
+	}
+
+	for _, test := range errs {
+		if _, err := parser.Parse(test.input); err == nil || err.Error() != test.expected.Error() {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, err)
+		}
+	}
+
+	opts.Operators["WITHIN"] = custom.Operator{Arity: Arity{Min: 3, Max: 3}}
+	if output, err := parser.Parse("({a} WITHIN 1, 2) AND true"); Fprint(output) != Fprint([]any{"AND", []any{"WITHIN", "$a", 1, 2}, true}) || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", "({a} WITHIN 1, 2) AND true", []any{"AND", []any{"WITHIN", "$a", 1, 2}, true}, output, err)
+	}
+
+	opts.OperatorMapping = OperatorMapping{Eq: "IS"}
+	if output, err := parser.Parse("{a} == 1"); Fprint(output) != Fprint([]any{"IS", "$a", 1}) || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", "{a} == 1", []any{"IS", "$a", 1}, output, err)
+	}
+	expected := errors.New("unsupported operator \"AND\" at position 5")
+	if _, err := parser.Parse("true AND true"); err == nil || err.Error() != expected.Error() {
+		t.Errorf("input (%v): expected %v, got %v", "true AND true", expected, err)
+	}
+}
@@ -0,0 +1,52 @@
+package goillogical
+
+import (
+	e "github.com/spaceavocado/goillogical/evaluable"
+)
+
+// Program is a compiled Evaluable, i.e. a reusable predicate with the reference paths, cast
+// functions and operator handlers resolved upfront.
+type Program interface {
+	// Run the program in the given context, the context is flattened at most once, see Flatten.
+	Run(e.Context) (any, error)
+	// Run the program, looking up the references by the given resolver instead of the data
+	// context, see Resolver.
+	RunResolver(Resolver) (any, error)
+}
+
+type program struct {
+	fn   e.Func
+	opts []FlattenOption
+}
+
+func (p program) Run(ctx e.Context) (any, error) {
+	flattened, err := e.Flatten(ctx, p.opts...)
+	if err != nil {
+		return nil, err
+	}
+	return p.fn(flattened)
+}
+
+func (p program) RunResolver(r Resolver) (any, error) {
+	return e.RunResolver(p.fn, r)
+}
+
+// Compile the evaluable into a Program, useful when the same expression is evaluated repeatedly.
+// The context is flattened by the given options, e.g. the context tag of the engine, see
+// FlattenTag and WithContextTag.
+//
+// Example:
+//
+//	eval, _ := i.Parse([]any{"==", "$name", "peter"})
+//	p, err := illogical.Compile(eval)
+//
+//	p.Run(map[string]any{"name": "peter"}) // true
+//
+//	p, err = illogical.Compile(eval, illogical.FlattenTag("rule"))
+func Compile(eval e.Evaluable, opts ...FlattenOption) (Program, error) {
+	fn, err := e.Compile(eval)
+	if err != nil {
+		return nil, err
+	}
+	return program{fn, opts}, nil
+}
@@ -0,0 +1,126 @@
+package goillogical
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/spaceavocado/goillogical/internal/test"
+)
+
+func TestCompile(t *testing.T) {
+	illogical := New()
+	ctx := map[string]any{
+		"name":    "peter",
+		"age":     21,
+		"score":   "10.5",
+		"options": []int{1, 2, 3},
+		"address": map[string]any{
+			"city": "Toronto",
+		},
+		"segment": "city",
+	}
+
+	var tests = []any{
+		1,
+		"$name",
+		"$missing",
+		"$score.(Number)",
+		"$age.(String)",
+		"$address.{segment}",
+		[]any{1, "$name", "$options[1]"},
+		[]any{"==", "$name", "peter"},
+		[]any{">", "$age", 18.5},
+		[]any{"IN", "$age", []any{18, 21}},
+		[]any{"PREFIX", "pe", "$name"},
+		[]any{"NIL", "$missing"},
+		[]any{"AND", []any{"==", "$address.city", "Toronto"}, []any{"PRESENT", "$name"}},
+		[]any{"OR", []any{"==", "$name", "john"}, []any{"<", "$age", 18}},
+		[]any{"NOT", []any{"OVERLAP", []any{1, 5}, []any{"$options[0]", 4}}},
+		[]any{"XOR", []any{"==", 1, 1}, []any{"==", 1, 2}},
+		[]any{"NOR", []any{"==", 1, 2}, []any{"==", "$address.{segment}", "Ottawa"}},
+	}
+
+	for _, test := range tests {
+		eval, err := illogical.Parse(test)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err := Compile(eval)
+		if err != nil {
+			t.Errorf("input (%v): unexpected error %v", test, err)
+			continue
+		}
+
+		expected, _ := eval.Evaluate(ctx)
+		if output, err := p.Run(ctx); Fprint(output) != Fprint(expected) || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test, expected, output, err)
+		}
+	}
+
+	eval, _ := illogical.Parse([]any{"NIL", "$name"})
+	p, _ := Compile(eval)
+	if output, err := p.Run(nil); output != true || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", eval, true, output, err)
+	}
+
+	errs := []struct {
+		input    any
+		expected error
+	}{
+		{"$name.(Number)", errors.New("invalid conversion from \"peter\" (string) to number")},
+		{[]any{"AND", "$name", true}, errors.New("invalid evaluated operand, must be boolean value")},
+	}
+
+	for _, test := range errs {
+		eval, _ := illogical.Parse(test.input)
+		p, _ := Compile(eval)
+		if _, err := p.Run(ctx); err == nil || err.Error() != test.expected.Error() {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, err)
+		}
+	}
+
+	type user struct {
+		Age int `rule:"age"`
+	}
+
+	eval, _ = illogical.Parse([]any{">=", "$user.age", 18})
+	p, _ = Compile(eval, FlattenTag("rule"))
+	if output, err := p.Run(map[string]any{"user": user{21}}); output != true || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", eval, true, output, err)
+	}
+}
+
+var benchmarkExpression = []any{"AND",
+	[]any{"==", "$user.country", "CA"},
+	[]any{">=", "$user.age", 18},
+	[]any{"IN", "$user.{field}", []any{"gold", "silver"}},
+	[]any{"OR", []any{"PREFIX", "pe", "$user.name"}, []any{"NOT", []any{"NIL", "$user.score.(Number)"}}},
+}
+
+var benchmarkContext = map[string]any{
+	"field": "tier",
+	"user": map[string]any{
+		"name":    "peter",
+		"country": "CA",
+		"age":     21,
+		"tier":    "gold",
+		"score":   "10.5",
+	},
+}
+
+func BenchmarkEvaluate(b *testing.B) {
+	eval, _ := New().Parse(benchmarkExpression)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		eval.Evaluate(benchmarkContext)
+	}
+}
+
+func BenchmarkRun(b *testing.B) {
+	eval, _ := New().Parse(benchmarkExpression)
+	p, _ := Compile(eval)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Run(benchmarkContext)
+	}
+}
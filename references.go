@@ -0,0 +1,101 @@
+package goillogical
+
+import (
+	"slices"
+
+	e "github.com/spaceavocado/goillogical/evaluable"
+	r "github.com/spaceavocado/goillogical/internal/operand/reference"
+)
+
+// Reference used in an expression.
+type ReferenceInfo struct {
+	// Reference path, without the data type casting, e.g. "address.{segment}".
+	Path string
+	// Data type the referenced value is cast to, Undefined if not cast.
+	DataType DataType
+	// Nested references the path is interpolated with, outermost first, e.g. ["segment"].
+	Nested []string
+	// Whether the path is resolved only in the evaluation context, i.e. the path contains nested
+	// references, or selectors, e.g. "items[*].price", "items[-1]" or "..price".
+	Dynamic bool
+}
+
+type referenceKey struct {
+	path string
+	dt   DataType
+}
+
+// Get the references used in the expression, in order of appearance. A reference used more than
+// once with the same data type is listed once. The references to the elements of a quantifier
+// collection, e.g. "$.price", and the built-in $NOW reference, resolved by the clock instead of
+// the context, are not listed.
+//
+// Example:
+//
+//	e, _ := i.Parse([]any{"AND", []any{"==", "$address.{segment}", "Toronto"}, []any{">", "$age.(Number)", 18}})
+//
+//	illogical.References(e)
+//	// [
+//	//   {Path: "address.{segment}", DataType: Undefined, Nested: ["segment"], Dynamic: true},
+//	//   {Path: "age", DataType: Number, Nested: [], Dynamic: false},
+//	// ]
+func References(eval e.Evaluable) []ReferenceInfo {
+	res := []ReferenceInfo{}
+	seen := map[referenceKey]bool{}
+	walkReferences(eval, nil, func(ref Reference) {
+		if ref.Path() == r.NOW {
+			return
+		}
+		key := referenceKey{ref.Path(), ref.DataType()}
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+
+		nested := r.NestedReferences(ref.Path())
+		res = append(res, ReferenceInfo{
+			Path:     ref.Path(),
+			DataType: ref.DataType(),
+			Nested:   nested,
+			Dynamic:  len(nested) > 0 || r.HasSelectors(ref.Path()),
+		})
+	})
+	return res
+}
+
+// Quantifier node, i.e. the predicate is evaluated with the elements of the collection bound to
+// the alias.
+type quantifier interface {
+	Node
+	Alias() string
+}
+
+// Walk the references of the evaluable, the references to the elements of the collections, i.e.
+// scoped by the alias of an enclosing quantifier, are skipped.
+func walkReferences(eval e.Evaluable, aliases []string, fn func(Reference)) {
+	e.Walk(eval, func(n e.Evaluable) bool {
+		if q, ok := n.(quantifier); ok {
+			operands := q.Operands()
+			walkReferences(operands[0], aliases, fn)
+			scoped := append(slices.Clone(aliases), q.Alias())
+			for _, operand := range operands[1:] {
+				walkReferences(operand, scoped, fn)
+			}
+			return false
+		}
+
+		if ref, ok := n.(Reference); ok && !isScoped(ref.Path(), aliases) {
+			fn(ref)
+		}
+		return true
+	})
+}
+
+func isScoped(path string, aliases []string) bool {
+	for _, alias := range aliases {
+		if _, ok := e.ScopedPath(alias, path); ok {
+			return true
+		}
+	}
+	return false
+}
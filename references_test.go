@@ -0,0 +1,66 @@
+package goillogical
+
+import (
+	"testing"
+
+	. "github.com/spaceavocado/goillogical/internal/test"
+)
+
+func TestReferences(t *testing.T) {
+	illogical := New()
+
+	var tests = []struct {
+		input    any
+		expected []ReferenceInfo
+	}{
+		{1, []ReferenceInfo{}},
+		{"$a", []ReferenceInfo{{"a", Undefined, []string{}, false}}},
+		{
+			[]any{"AND", []any{"==", "$address.{segment}", "Toronto"}, []any{">", "$age.(Number)", 18}},
+			[]ReferenceInfo{
+				{"address.{segment}", Undefined, []string{"segment"}, true},
+				{"age", Number, []string{}, false},
+			},
+		},
+		{
+			[]any{"OR", []any{"==", "$a", "$b"}, []any{"IN", "$a", []any{"$a.(String)", "$list[{index}]"}}},
+			[]ReferenceInfo{
+				{"a", Undefined, []string{}, false},
+				{"b", Undefined, []string{}, false},
+				{"a", String, []string{}, false},
+				{"list[{index}]", Undefined, []string{"index"}, true},
+			},
+		},
+		{[]any{"BEFORE", "$signup", "$NOW"}, []ReferenceInfo{{"signup", Undefined, []string{}, false}}},
+		{"$NOW.(Date)", []ReferenceInfo{}},
+		{
+			[]any{"IN", "$country", "$countries[*]"},
+			[]ReferenceInfo{
+				{"country", Undefined, []string{}, false},
+				{"countries[*]", Undefined, []string{}, true},
+			},
+		},
+		{"$items[-1].price", []ReferenceInfo{{"items[-1].price", Undefined, []string{}, true}}},
+		{"$..price", []ReferenceInfo{{"..price", Undefined, []string{}, true}}},
+		{"$items[0].price", []ReferenceInfo{{"items[0].price", Undefined, []string{}, false}}},
+		{"${a.{b}}", []ReferenceInfo{{"{a.{b}}", Undefined, []string{"a.{b}", "b"}, true}}},
+		{
+			[]any{"ANY", "$items", []any{"AND", []any{">", "$.price", "$limit"}, []any{"ALL", "$.tags", []any{"!=", "$.", "$item"}}}},
+			[]ReferenceInfo{
+				{"items", Undefined, []string{}, false},
+				{"limit", Undefined, []string{}, false},
+				{"item", Undefined, []string{}, false},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		eval, err := illogical.Parse(test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output := References(eval); Fprint(output) != Fprint(test.expected) {
+			t.Errorf("input (%v): expected %v, got %v", test.input, test.expected, output)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package goillogical
+
+import (
+	e "github.com/spaceavocado/goillogical/evaluable"
+)
+
+// Resolver resolves the reference paths on demand, i.e. an alternative to the data context
+// which does not need to be flattened upfront. The path is in the flattened form, e.g.
+// "address.city", "options[0]".
+type Resolver = e.Resolver
+
+// Create a resolver looking up the paths in the nested data context on demand, i.e. without
+// flattening the whole data context. A value which could not be resolved, e.g. a function, is
+// reported as ErrUnsupportedValue error.
+func NewMapResolver(ctx map[string]any, opts ...FlattenOption) Resolver {
+	return e.NewMapResolver(ctx, opts...)
+}
+
+// Create a resolver looking up the paths in the flattened data context, the data context is
+// flattened once, unless it is flattened already. An error of the flattening is reported by
+// every lookup.
+func NewFlattenedResolver(ctx e.Context, opts ...FlattenOption) Resolver {
+	return e.NewFlattenedResolver(ctx, opts...)
+}
+
+// Create a resolver caching the results of the given resolver, i.e. each path is looked up at
+// most once. Errors are not cached.
+func NewCachingResolver(r Resolver) Resolver {
+	return e.NewCachingResolver(r)
+}
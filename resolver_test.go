@@ -0,0 +1,83 @@
+package goillogical
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/spaceavocado/goillogical/internal/test"
+)
+
+type profileResolver struct {
+	lookups []string
+}
+
+func (r *profileResolver) Lookup(path string) (any, bool, error) {
+	r.lookups = append(r.lookups, path)
+	switch path {
+	case "tier":
+		return "gold", true, nil
+	case "field":
+		return "tier", true, nil
+	case "broken":
+		return nil, false, errors.New("profile unavailable")
+	default:
+		return nil, false, nil
+	}
+}
+
+func TestResolver(t *testing.T) {
+	illogical := New()
+	resolver := &profileResolver{}
+	caching := NewCachingResolver(resolver)
+
+	var tests = []struct {
+		input    any
+		expected any
+	}{
+		{[]any{"==", "$tier", "gold"}, true},
+		{[]any{"==", "${field}", "gold"}, true},
+		{[]any{"AND", []any{"PRESENT", "$tier"}, []any{"NIL", "$missing"}}, true},
+	}
+
+	for _, test := range tests {
+		if output, err := illogical.EvaluateResolver(test.input, caching); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+
+		eval, _ := illogical.Parse(test.input)
+		p, _ := Compile(eval)
+		if output, err := p.RunResolver(caching); output != test.expected || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, output, err)
+		}
+	}
+
+	if Fprint(resolver.lookups) != Fprint([]string{"tier", "field", "missing"}) {
+		t.Errorf("expected each path to be looked up once, got %v", resolver.lookups)
+	}
+
+	if value, eval, err := illogical.SimplifyResolver([]any{"AND", []any{"==", "$tier", "gold"}, []any{"==", "$missing", 1}}, caching); value != nil || Fprint(eval) != "({missing} == 1)" || err != nil {
+		t.Errorf("expected %v, got %v/%v/%v", "({missing} == 1)", value, eval, err)
+	}
+
+	expected := errors.New("profile unavailable")
+	if _, err := illogical.EvaluateResolver([]any{"==", "$broken", 1}, caching); err == nil || err.Error() != expected.Error() {
+		t.Errorf("input (%v): expected %v, got %v", []any{"==", "$broken", 1}, expected, err)
+	}
+
+	nested := map[string]any{"user": map[string]any{"tags": []any{"a", "b"}}}
+	for _, r := range []Resolver{NewMapResolver(nested), NewFlattenedResolver(nested)} {
+		if output, err := illogical.EvaluateResolver([]any{"==", "$user.tags[1]", "b"}, r); output != true || err != nil {
+			t.Errorf("input (%v): expected %v, got %v/%v", []any{"==", "$user.tags[1]", "b"}, true, output, err)
+		}
+	}
+
+	// A resolver within the data context is a value of the data context.
+	ctx := map[string]any{"_resolved": caching, "_resolver": caching, "tier": "silver"}
+	if output, err := illogical.Evaluate([]any{"==", "$tier", "silver"}, ctx); output != true || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", ctx, true, output, err)
+	}
+
+	if _, err := illogical.EvaluateResolver([]any{"==", struct{}{}, 1}, caching); err == nil {
+		t.Errorf("input (%v): expected error, got nil", []any{"==", struct{}{}, 1})
+	}
+}
@@ -0,0 +1,263 @@
+package goillogical
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	e "github.com/spaceavocado/goillogical/evaluable"
+)
+
+// Inverted index of the rules by the values of a reference, see RuleIndex.
+type slot struct {
+	ref e.Evaluable
+	// Positions of the rules per indexed value, see indexKey.
+	keys map[any][]int
+	// Positions of all of the rules of the slot.
+	rules []int
+}
+
+// RuleIndex is an ordered set of rules, matched in the given data context as all of the matching
+// rules, see AllMatches, evaluating only the candidate rules. A rule is a candidate if its first
+// conjunct, i.e. the rule itself or the first operand of the AND expressions, is not an equality,
+// or a membership, of a reference and static values, e.g. ["==", "$country", "CA"] or
+// ["IN", "$country", ["CA", "US"]], or if the value of the reference is the indexed value. The
+// matched rules, and the rule errors, are identical to evaluating all of the rules.
+type RuleIndex struct {
+	rules     []rule
+	slots     []*slot
+	unindexed []int
+	// Nil reference values are not indexed with the three-valued logic, i.e. the comparison is
+	// unknown rather than false, and the following conjuncts are evaluated.
+	threeValued bool
+	flatten     func(e.Context) (e.Context, error)
+}
+
+// Create a rule index of the rules parsed, and compiled, by the engine, the disabled rules are
+// omitted. Fails if a rule could not be parsed, see RuleError, or the rule identifiers are not
+// unique.
+//
+// Example:
+//
+//	ri, err := illogical.NewRuleIndex(i,
+//		illogical.Rule{ID: "ca", Expression: []any{"AND", []any{"==", "$country", "CA"}, []any{">=", "$age", 19}}},
+//		illogical.Rule{ID: "us", Expression: []any{"AND", []any{"==", "$country", "US"}, []any{">=", "$age", 21}}},
+//	)
+func NewRuleIndex(engine Goillogical, rules ...Rule) (*RuleIndex, error) {
+	ri := &RuleIndex{rules: []rule{}, slots: []*slot{}, unindexed: []int{}, threeValued: engine.ThreeValued(), flatten: flattenOf(engine)}
+
+	slots := map[string]*slot{}
+	ids := map[string]bool{}
+	for _, r := range rules {
+		if ids[r.ID] {
+			return nil, &RuleError{r.ID, errors.New("duplicate rule identifier")}
+		}
+		ids[r.ID] = true
+
+		eval, err := engine.Parse(r.Expression)
+		if err != nil {
+			return nil, &RuleError{r.ID, err}
+		}
+		match, err := matcher(eval)
+		if err != nil {
+			return nil, &RuleError{r.ID, err}
+		}
+		if r.Disabled {
+			continue
+		}
+
+		pos := len(ri.rules)
+		ri.rules = append(ri.rules, rule{r, match})
+
+		ref, keys, ok := indexable(eval)
+		if !ok {
+			ri.unindexed = append(ri.unindexed, pos)
+			continue
+		}
+
+		id := fmt.Sprint(ref.Serialize())
+		s, ok := slots[id]
+		if !ok {
+			s = &slot{ref, map[any][]int{}, []int{}}
+			slots[id] = s
+			ri.slots = append(ri.slots, s)
+		}
+		s.rules = append(s.rules, pos)
+		for _, key := range keys {
+			if !slices.Contains(s.keys[key], pos) {
+				s.keys[key] = append(s.keys[key], pos)
+			}
+		}
+	}
+	return ri, nil
+}
+
+// Indexed reference, and its indexed values, of the first conjunct of the evaluable, false if
+// the conjunct is not an equality, or a membership, of a reference and static values.
+func indexable(eval e.Evaluable) (e.Evaluable, []any, bool) {
+	operands := e.OperandsOf(eval)
+	switch e.KindOf(eval) {
+	case e.And:
+		if len(operands) == 0 {
+			return nil, nil, false
+		}
+		return indexable(operands[0])
+	case e.Eq, e.In:
+		if len(operands) != 2 {
+			return nil, nil, false
+		}
+		ref, static := operands[0], operands[1]
+		if e.KindOf(ref) != e.Reference {
+			ref, static = static, ref
+		}
+		if e.KindOf(ref) != e.Reference {
+			return nil, nil, false
+		}
+
+		keys, ok := staticKeys(static, e.KindOf(eval) == e.In)
+		return ref, keys, ok
+	default:
+		return nil, nil, false
+	}
+}
+
+// Indexed values of the static operand, i.e. a value, or a collection of values of a membership.
+func staticKeys(eval e.Evaluable, collection bool) ([]any, bool) {
+	values := []any{}
+	switch {
+	case !collection && e.KindOf(eval) == e.Value:
+		val, err := eval.Evaluate(e.Context{})
+		if err != nil {
+			return nil, false
+		}
+		values = append(values, val)
+	case collection && e.KindOf(eval) == e.Collection:
+		for _, item := range e.OperandsOf(eval) {
+			if e.KindOf(item) != e.Value {
+				return nil, false
+			}
+		}
+		val, err := eval.Evaluate(e.Context{})
+		items, ok := val.([]any)
+		if err != nil || !ok {
+			return nil, false
+		}
+		values = append(values, items...)
+	default:
+		return nil, false
+	}
+
+	keys := make([]any, len(values))
+	for j, val := range values {
+		key, ok := indexKey(val)
+		if !ok {
+			return nil, false
+		}
+		keys[j] = key
+	}
+	return keys, true
+}
+
+// Index key of the value, i.e. equal values, see the EQ expression, have the same key. Numbers
+// of any numeric type are keyed by their float value, false if the value could not be keyed,
+// e.g. a time, or a value of a custom type.
+func indexKey(val any) (any, bool) {
+	switch typed := val.(type) {
+	case string, bool:
+		return typed, true
+	case int:
+		return float64(typed), true
+	case int8:
+		return float64(typed), true
+	case int16:
+		return float64(typed), true
+	case int32:
+		return float64(typed), true
+	case int64:
+		return float64(typed), true
+	case uint:
+		return float64(typed), true
+	case uint8:
+		return float64(typed), true
+	case uint16:
+		return float64(typed), true
+	case uint32:
+		return float64(typed), true
+	case uint64:
+		return float64(typed), true
+	case float32:
+		return float64(typed), true
+	case float64:
+		return typed, true
+	case json.Number:
+		f, err := typed.Float64()
+		return f, err == nil
+	default:
+		return nil, false
+	}
+}
+
+// Positions of the candidate rules of the slot in the flattened data context, i.e. all of the
+// rules of the slot if the reference value could not be keyed.
+func (s *slot) candidates(ctx e.Context, threeValued bool) []int {
+	val, err := s.ref.Evaluate(ctx)
+	if err != nil {
+		return s.rules
+	}
+	if val == nil {
+		if threeValued {
+			return s.rules
+		}
+		return nil
+	}
+	key, ok := indexKey(val)
+	if !ok {
+		return s.rules
+	}
+	return s.keys[key]
+}
+
+// Rules of the rule index, in their order, the disabled rules are omitted.
+func (ri *RuleIndex) Rules() []Rule {
+	res := make([]Rule, len(ri.rules))
+	for j, r := range ri.rules {
+		res[j] = r.Rule
+	}
+	return res
+}
+
+// Match all of the rules in the given data context, in the order of the rules, see
+// RuleSet.Match. Only the candidate rules are evaluated, see RuleIndex.
+//
+// Example:
+//
+// rules, errs := ri.Match(map[string]any{"country": "CA", "age": 20})
+//
+// rules[0].ID // ca
+func (ri *RuleIndex) Match(ctx e.Context, tags ...string) ([]Rule, []error) {
+	flattened, err := ri.flatten(ctx)
+	if err != nil {
+		return []Rule{}, []error{err}
+	}
+
+	candidates := slices.Clone(ri.unindexed)
+	for _, s := range ri.slots {
+		candidates = append(candidates, s.candidates(flattened, ri.threeValued)...)
+	}
+	slices.Sort(candidates)
+
+	res, errs := []Rule{}, []error{}
+	for _, pos := range candidates {
+		r := ri.rules[pos]
+		ok, err := r.matches(flattened, tags)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if ok {
+			res = append(res, r.Rule)
+		}
+	}
+	return res, errs
+}
@@ -0,0 +1,258 @@
+package goillogical
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	e "github.com/spaceavocado/goillogical/evaluable"
+)
+
+func TestIndexable(t *testing.T) {
+	illogical := New()
+
+	var tests = []struct {
+		input    any
+		ref      string
+		expected []any
+	}{
+		{[]any{"==", "$country", "CA"}, "$country", []any{"CA"}},
+		{[]any{"==", 5, "$age"}, "$age", []any{float64(5)}},
+		{[]any{"==", "$age.(Number)", json.Number("5")}, "$age.(Number)", []any{float64(5)}},
+		{[]any{"IN", "$country", []any{"CA", "US"}}, "$country", []any{"CA", "US"}},
+		{[]any{"IN", []any{true, 1}, "$flag"}, "$flag", []any{true, float64(1)}},
+		{[]any{"AND", []any{"==", "$country", "CA"}, []any{">", "$age", 18}}, "$country", []any{"CA"}},
+		{[]any{"AND", []any{"AND", []any{"==", "$a|1", 1}, []any{"==", "$b", 2}}, []any{"==", "$c", 3}}, "$a|1", []any{float64(1)}},
+		{[]any{"AND", []any{">", "$age", 18}, []any{"==", "$country", "CA"}}, "", nil},
+		{[]any{"OR", []any{"==", "$country", "CA"}, []any{"==", "$country", "US"}}, "", nil},
+		{[]any{"==", "$a", "$b"}, "", nil},
+		{[]any{"==", 1, 1}, "", nil},
+		{[]any{"!=", "$country", "CA"}, "", nil},
+		{[]any{"IN", "$country", []any{"CA", "$other"}}, "", nil},
+		{[]any{"IN", "$country", "$countries[*]"}, "", nil},
+		{[]any{"==", "$signup", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, "", nil},
+	}
+
+	for _, test := range tests {
+		eval, err := illogical.Parse(test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ref, keys, ok := indexable(eval)
+		if ok != (test.ref != "") || (ok && (ref.Serialize() != test.ref || !reflect.DeepEqual(keys, test.expected))) {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v/%v", test.input, test.ref, test.expected, ref, keys, ok)
+		}
+	}
+}
+
+func TestIndexKey(t *testing.T) {
+	var tests = []struct {
+		input    any
+		expected any
+		ok       bool
+	}{
+		{"CA", "CA", true},
+		{true, true, true},
+		{5, float64(5), true},
+		{int8(5), float64(5), true},
+		{uint64(5), float64(5), true},
+		{float32(0.5), float64(0.5), true},
+		{5.5, 5.5, true},
+		{json.Number("5"), float64(5), true},
+		{json.Number("x"), nil, false},
+		{Tier("gold"), nil, false},
+		{time.Time{}, nil, false},
+		{[]any{1}, nil, false},
+	}
+
+	for _, test := range tests {
+		if output, ok := indexKey(test.input); ok != test.ok || (ok && output != test.expected) {
+			t.Errorf("input (%v): expected %v/%v, got %v/%v", test.input, test.expected, test.ok, output, ok)
+		}
+	}
+}
+
+type Tier string
+
+func TestRuleIndexMatch(t *testing.T) {
+	rules := []Rule{
+		{ID: "ca", Tags: []string{"geo"}, Expression: []any{"AND", []any{"==", "$country", "CA"}, []any{">=", "$age", 19}}},
+		{ID: "us", Tags: []string{"geo"}, Expression: []any{"AND", []any{"==", "$country", "US"}, []any{">=", "$age", 21}}},
+		{ID: "na", Expression: []any{"IN", "$country", []any{"CA", "US", "MX"}}},
+		{ID: "adult", Expression: []any{">=", "$age", 18}},
+		{ID: "gold", Expression: []any{"==", "$tier", "gold"}, Disabled: true},
+		{ID: "lucky", Expression: []any{"==", "$number", 7}},
+	}
+
+	ri, err := NewRuleIndex(New(), rules...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ruleIDs(ri.Rules()), []string{"ca", "us", "na", "adult", "lucky"}) {
+		t.Errorf("expected enabled rules, got %v", ruleIDs(ri.Rules()))
+	}
+
+	var tests = []struct {
+		input    map[string]any
+		tags     []string
+		expected []string
+	}{
+		{map[string]any{"country": "CA", "age": 20}, nil, []string{"ca", "na", "adult"}},
+		{map[string]any{"country": "US", "age": 20}, nil, []string{"na", "adult"}},
+		{map[string]any{"country": "MX", "age": 30, "tier": "gold"}, nil, []string{"na", "adult"}},
+		{map[string]any{"country": "CA", "age": 20}, []string{"geo"}, []string{"ca"}},
+		{map[string]any{"number": 7.0}, nil, []string{"lucky"}},
+		{map[string]any{"number": json.Number("7")}, nil, []string{"lucky"}},
+		{map[string]any{"number": uint8(7)}, nil, []string{"lucky"}},
+		{map[string]any{"number": "7"}, nil, []string{}},
+		{map[string]any{"country": Tier("CA"), "age": 20}, nil, []string{"adult"}},
+		{map[string]any{"country": "XX"}, nil, []string{}},
+	}
+
+	for _, test := range tests {
+		if output, errs := ri.Match(test.input, test.tags...); !reflect.DeepEqual(ruleIDs(output), test.expected) || len(errs) > 0 {
+			t.Errorf("input (%v): expected %v, got %v/%v", test.input, test.expected, ruleIDs(output), errs)
+		}
+	}
+
+	var errs = [][]Rule{
+		{{ID: "a", Expression: []any{"==", struct{}{}, 1}}},
+		{{ID: "a", Expression: []any{"==", 1, 1}}, {ID: "a", Expression: []any{"==", 1, 1}}},
+	}
+
+	for _, input := range errs {
+		if _, err := NewRuleIndex(New(), input...); err == nil {
+			t.Errorf("input (%v): expected error, got nil", input)
+		}
+	}
+}
+
+// Random rules of the conjunctions of the equality, membership and range comparisons.
+func randomRules(r *rand.Rand, n int) []Rule {
+	countries := []any{"CA", "US", "MX", "DE", "FR"}
+	leaf := func() any {
+		switch r.Intn(6) {
+		case 0:
+			return []any{"==", "$country", countries[r.Intn(len(countries))]}
+		case 1:
+			return []any{"IN", "$country", []any{countries[r.Intn(len(countries))], countries[r.Intn(len(countries))]}}
+		case 2:
+			return []any{"==", r.Intn(5), "$tier"}
+		case 3:
+			return []any{">=", "$age", r.Intn(60)}
+		case 4:
+			return []any{"==", "$vip", r.Intn(2) == 0}
+		default:
+			return []any{"==", []any{"/", 10, "$age"}, 1}
+		}
+	}
+
+	res := make([]Rule, n)
+	for j := range res {
+		var exp any = leaf()
+		if r.Intn(4) > 0 {
+			exp = []any{"AND", leaf(), leaf()}
+		}
+		if r.Intn(8) == 0 {
+			exp = []any{"OR", exp, leaf()}
+		}
+		res[j] = Rule{ID: fmt.Sprint(j), Expression: exp, Disabled: r.Intn(10) == 0}
+	}
+	return res
+}
+
+func randomContext(r *rand.Rand) map[string]any {
+	res := map[string]any{}
+	if r.Intn(5) > 0 {
+		res["country"] = []any{"CA", "US", "MX", "DE", "FR", "XX"}[r.Intn(6)]
+	}
+	if r.Intn(5) > 0 {
+		res["tier"] = []any{0, 1.0, uint(2), json.Number("3"), "4"}[r.Intn(5)]
+	}
+	if r.Intn(5) > 0 {
+		res["age"] = r.Intn(70)
+	}
+	if r.Intn(5) > 0 {
+		res["vip"] = r.Intn(2) == 0
+	}
+	return res
+}
+
+func TestRuleIndexBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	// Any engine implementation, e.g. a wrapper, is indexed by its three-valued logic.
+	type wrapper struct{ Goillogical }
+
+	for _, engine := range []Goillogical{New(), New(WithThreeValuedLogic()), wrapper{New()}, wrapper{New(WithThreeValuedLogic())}} {
+		rules := randomRules(r, 500)
+
+		ri, err := NewRuleIndex(engine, rules...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ri.threeValued != engine.ThreeValued() {
+			t.Errorf("expected %v, got %v", engine.ThreeValued(), ri.threeValued)
+		}
+		rs, err := NewRuleSet(engine, AllMatches, rules...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for j := 0; j < 200; j++ {
+			ctx := randomContext(r)
+			expected, expectedErrs := rs.Match(ctx)
+			output, errs := ri.Match(ctx)
+			if !reflect.DeepEqual(ruleIDs(output), ruleIDs(expected)) || fmt.Sprint(errs) != fmt.Sprint(expectedErrs) {
+				t.Fatalf("input (%v): expected %v/%v, got %v/%v", ctx, ruleIDs(expected), expectedErrs, ruleIDs(output), errs)
+			}
+		}
+	}
+}
+
+func benchmarkRules(n int) []Rule {
+	res := make([]Rule, n)
+	for j := range res {
+		res[j] = Rule{
+			ID:         fmt.Sprint(j),
+			Expression: []any{"AND", []any{"==", "$country", fmt.Sprintf("C%d", j%200)}, []any{">=", "$age", j % 50}},
+		}
+	}
+	return res
+}
+
+var benchmarkMatchContext = e.Context{"country": "C42", "age": 30}
+
+func BenchmarkRuleIndexMatch(b *testing.B) {
+	ri, _ := NewRuleIndex(New(), benchmarkRules(20000)...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ri.Match(benchmarkMatchContext)
+	}
+}
+
+func BenchmarkRuleSetMatch(b *testing.B) {
+	rs, _ := NewRuleSet(New(), AllMatches, benchmarkRules(20000)...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rs.Match(benchmarkMatchContext)
+	}
+}
+
+func BenchmarkEvaluateLoop(b *testing.B) {
+	illogical := New()
+	rules := benchmarkRules(20000)
+	evals := make([]e.Evaluable, len(rules))
+	for j, r := range rules {
+		evals[j], _ = illogical.Parse(r.Expression)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, eval := range evals {
+			eval.Evaluate(benchmarkMatchContext)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package goillogical
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	e "github.com/spaceavocado/goillogical/evaluable"
+)
+
+// Rule of a RuleSet, i.e. a named expression with the output of the rule when it matches.
+type Rule struct {
+	// Unique identifier of the rule within the rule set.
+	ID string `json:"id"`
+	// Priority of the rule, see HighestPriority.
+	Priority int `json:"priority"`
+	// Tags of the rule, used to match a subset of the rules, see RuleSet.Match.
+	Tags []string `json:"tags,omitempty"`
+	// Raw expression of the rule, evaluated into a boolean value.
+	Expression any `json:"expression"`
+	// Output payload of the rule.
+	Output any `json:"output,omitempty"`
+	// A disabled rule is never matched.
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// Strategy choosing the matched rules of a RuleSet.
+type Strategy int
+
+const (
+	// The first matching rule, in the order of the rules.
+	FirstMatch Strategy = iota
+	// All of the matching rules, in the order of the rules.
+	AllMatches
+	// The matching rule of the highest priority, the first of them on a tie.
+	HighestPriority
+)
+
+var strategyNames = map[Strategy]string{
+	FirstMatch:      "first",
+	AllMatches:      "all",
+	HighestPriority: "priority",
+}
+
+func (s Strategy) String() string {
+	return strategyNames[s]
+}
+
+// Strategy is represented by its name in the text and JSON forms, i.e. "first", "all" or
+// "priority".
+func (s Strategy) MarshalText() ([]byte, error) {
+	if name, ok := strategyNames[s]; ok {
+		return []byte(name), nil
+	}
+	return nil, fmt.Errorf("invalid rule set strategy %d", s)
+}
+
+func (s *Strategy) UnmarshalText(text []byte) error {
+	for strategy, name := range strategyNames {
+		if name == string(text) {
+			*s = strategy
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid rule set strategy \"%s\"", text)
+}
+
+// Rule error describing the failure of a rule, i.e. the rule could not be parsed or evaluated.
+type RuleError struct {
+	// Identifier of the failing rule.
+	ID string
+	// Cause of the failure, e.g. a ParseError.
+	Err error
+}
+
+func (r *RuleError) Error() string {
+	return fmt.Sprintf("rule \"%s\", %s", r.ID, r.Err)
+}
+
+func (r *RuleError) Unwrap() error {
+	return r.Err
+}
+
+type rule struct {
+	Rule
+	match func(e.Context) (bool, error)
+}
+
+// RuleSet is an ordered set of rules parsed by the engine, matched in the given data context by
+// the strategy.
+type RuleSet struct {
+	strategy Strategy
+	rules    []rule
+	// Rules ordered by the priority, see HighestPriority.
+	prioritized []rule
+	flatten     func(e.Context) (e.Context, error)
+}
+
+// Create a rule set of the rules parsed, and compiled, by the engine. Fails if a rule could not
+// be parsed, see RuleError, or the rule identifiers are not unique.
+//
+// Example:
+//
+//	rs, err := illogical.NewRuleSet(i, illogical.FirstMatch,
+//		illogical.Rule{ID: "minor", Expression: []any{"<", "$age", 18}, Output: "kids"},
+//		illogical.Rule{ID: "adult", Expression: []any{">=", "$age", 18}, Output: "adults"},
+//	)
+func NewRuleSet(engine Goillogical, strategy Strategy, rules ...Rule) (*RuleSet, error) {
+	if _, ok := strategyNames[strategy]; !ok {
+		return nil, fmt.Errorf("invalid rule set strategy %d", strategy)
+	}
+
+	rs := &RuleSet{strategy: strategy, rules: make([]rule, len(rules)), flatten: flattenOf(engine)}
+	ids := map[string]bool{}
+	for j, r := range rules {
+		if ids[r.ID] {
+			return nil, &RuleError{r.ID, errors.New("duplicate rule identifier")}
+		}
+		ids[r.ID] = true
+
+		eval, err := engine.Parse(r.Expression)
+		if err != nil {
+			return nil, &RuleError{r.ID, err}
+		}
+		match, err := matcher(eval)
+		if err != nil {
+			return nil, &RuleError{r.ID, err}
+		}
+		rs.rules[j] = rule{r, match}
+	}
+
+	rs.prioritized = slices.Clone(rs.rules)
+	slices.SortStableFunc(rs.prioritized, func(a, b rule) int {
+		return cmp.Compare(b.Priority, a.Priority)
+	})
+	return rs, nil
+}
+
+// Flattening of the data context by the engine, i.e. naming the struct fields by its context tag,
+// see Goillogical.ContextTag.
+func flattenOf(engine Goillogical) func(e.Context) (e.Context, error) {
+	tag := e.FlattenTag(engine.ContextTag())
+	return func(ctx e.Context) (e.Context, error) {
+		return e.Flatten(ctx, tag)
+	}
+}
+
+// Strategy of the rule set.
+func (rs *RuleSet) Strategy() Strategy {
+	return rs.strategy
+}
+
+// Rules of the rule set, in their order.
+func (rs *RuleSet) Rules() []Rule {
+	res := make([]Rule, len(rs.rules))
+	for j, r := range rs.rules {
+		res[j] = r.Rule
+	}
+	return res
+}
+
+// Rule is matched, i.e. it is enabled, tagged by any of the tags if given, and evaluated into
+// true.
+func (r rule) matches(ctx e.Context, tags []string) (bool, error) {
+	if r.Disabled {
+		return false, nil
+	}
+	if len(tags) > 0 && !slices.ContainsFunc(tags, func(tag string) bool { return slices.Contains(r.Tags, tag) }) {
+		return false, nil
+	}
+
+	ok, err := r.match(ctx)
+	if err != nil {
+		return false, &RuleError{r.ID, err}
+	}
+	return ok, nil
+}
+
+// Match the rules in the given data context by the strategy of the rule set, only the rules
+// tagged by any of the given tags are matched if any tags are given. A failing rule does not
+// abort the matching, it is reported as a RuleError, and it is not matched. The rules following
+// the first matching rule are not evaluated with the FirstMatch and HighestPriority strategies.
+//
+// Example:
+//
+// rules, errs := rs.Match(map[string]any{"age": 21})
+//
+// rules[0].Output // adults
+func (rs *RuleSet) Match(ctx e.Context, tags ...string) ([]Rule, []error) {
+	flattened, err := rs.flatten(ctx)
+	if err != nil {
+		return []Rule{}, []error{err}
+	}
+
+	rules := rs.rules
+	if rs.strategy == HighestPriority {
+		rules = rs.prioritized
+	}
+
+	res, errs := []Rule{}, []error{}
+	for _, r := range rules {
+		ok, err := r.matches(flattened, tags)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		res = append(res, r.Rule)
+		if rs.strategy != AllMatches {
+			break
+		}
+	}
+	return res, errs
+}
+
+type ruleSetJSON struct {
+	Strategy Strategy `json:"strategy"`
+	Rules    []Rule   `json:"rules"`
+}
+
+// Rule set is represented by its strategy and rules in the JSON form, see LoadRuleSet.
+//
+// Example:
+//
+//	{
+//	  "strategy": "first",
+//	  "rules": [
+//	    {"id": "adult", "priority": 0, "expression": [">=", "$age", 18], "output": "adults"}
+//	  ]
+//	}
+func (rs *RuleSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ruleSetJSON{rs.strategy, rs.Rules()})
+}
+
+// Load the rule set from its JSON form, see RuleSet.MarshalJSON. The rules are enabled unless
+// "disabled": true, the strategy defaults to FirstMatch.
+//
+// Example:
+//
+// rs, err := illogical.LoadRuleSet(i, []byte(`{"strategy": "all", "rules": [{"id": "adult", "expression": [">=", "$age", 18]}]}`))
+func LoadRuleSet(engine Goillogical, data []byte) (*RuleSet, error) {
+	var raw ruleSetJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	return NewRuleSet(engine, raw.Strategy, raw.Rules...)
+}
@@ -0,0 +1,188 @@
+package goillogical
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	e "github.com/spaceavocado/goillogical/evaluable"
+)
+
+func ruleIDs(rules []Rule) []string {
+	res := []string{}
+	for _, r := range rules {
+		res = append(res, r.ID)
+	}
+	return res
+}
+
+func TestRuleSetMatch(t *testing.T) {
+	rules := []Rule{
+		{ID: "minor", Priority: 1, Tags: []string{"age"}, Expression: []any{"<", "$age", 18}, Output: "kids"},
+		{ID: "adult", Priority: 1, Tags: []string{"age"}, Expression: []any{">=", "$age", 18}, Output: "adults"},
+		{ID: "local", Priority: 5, Tags: []string{"geo"}, Expression: []any{"==", "$city", "Toronto"}, Output: "locals"},
+		{ID: "vip", Priority: 9, Expression: []any{"==", "$tier", "gold"}, Output: "vips", Disabled: true},
+		{ID: "senior", Priority: 3, Tags: []string{"age"}, Expression: []any{">=", "$age", 65}, Output: "seniors"},
+	}
+	ctx := map[string]any{"age": 70, "city": "Toronto", "tier": "gold"}
+
+	var tests = []struct {
+		strategy Strategy
+		tags     []string
+		expected []string
+	}{
+		{FirstMatch, nil, []string{"adult"}},
+		{AllMatches, nil, []string{"adult", "local", "senior"}},
+		{HighestPriority, nil, []string{"local"}},
+		{FirstMatch, []string{"geo"}, []string{"local"}},
+		{AllMatches, []string{"age"}, []string{"adult", "senior"}},
+		{HighestPriority, []string{"age"}, []string{"senior"}},
+		{AllMatches, []string{"none"}, []string{}},
+	}
+
+	for _, test := range tests {
+		rs, err := NewRuleSet(New(), test.strategy, rules...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output, errs := rs.Match(ctx, test.tags...); !reflect.DeepEqual(ruleIDs(output), test.expected) || len(errs) > 0 {
+			t.Errorf("input (%v, %v): expected %v, got %v/%v", test.strategy, test.tags, test.expected, ruleIDs(output), errs)
+		}
+	}
+
+	// Highest priority on a tie is the first of them.
+	rs, _ := NewRuleSet(New(), HighestPriority, rules[0], rules[1], Rule{ID: "any", Priority: 1, Expression: []any{"PRESENT", "$age"}})
+	if output, _ := rs.Match(ctx); !reflect.DeepEqual(ruleIDs(output), []string{"adult"}) {
+		t.Errorf("input (%v): expected %v, got %v", HighestPriority, []string{"adult"}, ruleIDs(output))
+	}
+}
+
+func TestRuleSetMatchErrors(t *testing.T) {
+	rs, err := NewRuleSet(New(), AllMatches,
+		Rule{ID: "sum", Expression: []any{"+", "$age", 1}},
+		Rule{ID: "ratio", Expression: []any{">", []any{"/", 1, "$age"}, 1}},
+		Rule{ID: "adult", Expression: []any{">=", "$age", 18}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output, errs := rs.Match(map[string]any{"age": 0})
+	if !reflect.DeepEqual(ruleIDs(output), []string{}) || len(errs) != 2 {
+		t.Fatalf("expected %v/%v errors, got %v/%v", []string{}, 2, ruleIDs(output), errs)
+	}
+
+	var re *RuleError
+	if !errors.As(errs[0], &re) || re.ID != "sum" || errs[0].Error() != "rule \"sum\", invalid evaluated expression, must be boolean value" {
+		t.Errorf("expected %v rule error, got %v", "sum", errs[0])
+	}
+	if !errors.As(errs[1], &re) || re.ID != "ratio" || !errors.Is(errs[1], ErrDivisionByZero) {
+		t.Errorf("expected %v rule error, got %v", "ratio", errs[1])
+	}
+
+	// Rules following the failing rules are matched.
+	if output, errs := rs.Match(map[string]any{"age": 21}); !reflect.DeepEqual(ruleIDs(output), []string{"adult"}) || len(errs) != 1 {
+		t.Errorf("expected %v, got %v/%v", []string{"adult"}, ruleIDs(output), errs)
+	}
+
+	if _, errs := rs.Match(map[string]any{"fn": func() {}}); len(errs) != 1 || !errors.Is(errs[0], ErrUnsupportedValue) {
+		t.Errorf("expected %v, got %v", ErrUnsupportedValue, errs)
+	}
+}
+
+func TestNewRuleSetErrors(t *testing.T) {
+	var tests = []struct {
+		strategy Strategy
+		rules    []Rule
+		expected string
+		cause    error
+	}{
+		{FirstMatch, []Rule{{ID: "a", Expression: []any{"==", struct{}{}, 1}}}, "a", ErrInvalidOperand},
+		{FirstMatch, []Rule{{ID: "a", Expression: []any{"==", 1, 1}}, {ID: "a", Expression: []any{"==", 1, 1}}}, "a", nil},
+		{Strategy(9), []Rule{}, "", nil},
+	}
+
+	for _, test := range tests {
+		_, err := NewRuleSet(New(), test.strategy, test.rules...)
+		if err == nil {
+			t.Errorf("input (%v): expected error, got nil", test.rules)
+			continue
+		}
+		var re *RuleError
+		if test.expected != "" && (!errors.As(err, &re) || re.ID != test.expected) {
+			t.Errorf("input (%v): expected %v rule error, got %v", test.rules, test.expected, err)
+		}
+		if test.cause != nil && !errors.Is(err, test.cause) {
+			t.Errorf("input (%v): expected %v, got %v", test.rules, test.cause, err)
+		}
+	}
+}
+
+func TestRuleSetContextTag(t *testing.T) {
+	type user struct {
+		Age int `rule:"age"`
+	}
+
+	// Any engine implementation, e.g. a wrapper, is flattened by its context tag.
+	type wrapper struct{ Goillogical }
+
+	for _, engine := range []Goillogical{New(WithContextTag("rule")), wrapper{New(WithContextTag("rule"))}} {
+		rs, _ := NewRuleSet(engine, FirstMatch, Rule{ID: "adult", Expression: []any{">=", "$user.age", 18}})
+		if output, errs := rs.Match(map[string]any{"user": user{21}}); !reflect.DeepEqual(ruleIDs(output), []string{"adult"}) || len(errs) > 0 {
+			t.Errorf("expected %v, got %v/%v", []string{"adult"}, ruleIDs(output), errs)
+		}
+	}
+}
+
+func TestRuleSetJSON(t *testing.T) {
+	data := `{
+		"strategy": "priority",
+		"rules": [
+			{"id": "adult", "priority": 1, "tags": ["age"], "expression": [">=", "$age", 18], "output": {"segment": "adults"}},
+			{"id": "senior", "priority": 2, "expression": [">=", "$age", 65], "output": "seniors", "disabled": true}
+		]
+	}`
+
+	rs, err := LoadRuleSet(New(), []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Rule{
+		{ID: "adult", Priority: 1, Tags: []string{"age"}, Expression: []any{">=", "$age", float64(18)}, Output: map[string]any{"segment": "adults"}},
+		{ID: "senior", Priority: 2, Expression: []any{">=", "$age", float64(65)}, Output: "seniors", Disabled: true},
+	}
+	if rs.Strategy() != HighestPriority || !reflect.DeepEqual(rs.Rules(), expected) {
+		t.Errorf("input (%v): expected %v/%v, got %v/%v", data, HighestPriority, expected, rs.Strategy(), rs.Rules())
+	}
+	if output, _ := rs.Match(e.Context{"age": 70}); !reflect.DeepEqual(ruleIDs(output), []string{"adult"}) {
+		t.Errorf("input (%v): expected %v, got %v", data, []string{"adult"}, ruleIDs(output))
+	}
+
+	// Round trip.
+	stored, err := json.Marshal(rs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadRuleSet(New(), stored)
+	if err != nil || loaded.Strategy() != rs.Strategy() || !reflect.DeepEqual(loaded.Rules(), rs.Rules()) {
+		t.Errorf("input (%s): expected %v, got %v/%v", stored, rs.Rules(), loaded, err)
+	}
+
+	var errs = []string{
+		`{"strategy": "best", "rules": []}`,
+		`{"rules": [{"id": "a", "expression": null}]}`,
+		`{"rules": {}}`,
+	}
+
+	for _, input := range errs {
+		if _, err := LoadRuleSet(New(), []byte(input)); err == nil {
+			t.Errorf("input (%v): expected error, got nil", input)
+		}
+	}
+
+	if _, err := json.Marshal(Strategy(9)); err == nil {
+		t.Errorf("input (%v): expected error, got nil", Strategy(9))
+	}
+}
@@ -0,0 +1,77 @@
+package goillogical
+
+import (
+	e "github.com/spaceavocado/goillogical/evaluable"
+	r "github.com/spaceavocado/goillogical/internal/operand/reference"
+)
+
+// Evaluable exposing its structure, i.e. the expression kind, the operator and the operands.
+// All of the parsed evaluables are nodes.
+type Node = e.Node
+
+// Visitor of the evaluables, returns false to skip the operands of the visited evaluable.
+type Visitor = e.Visitor
+
+// Data type the referenced value is cast to, e.g. $age.(Number).
+type DataType = r.DataType
+
+// Reference data types.
+const (
+	Undefined   = r.Undefined
+	Unsupported = r.Unsupported
+	Number      = r.Number
+	Integer     = r.Integer
+	Float       = r.Float
+	String      = r.String
+	Boolean     = r.Boolean
+	Date        = r.Date
+	DateTime    = r.DateTime
+)
+
+// Reference node, i.e. a node of the e.Reference kind.
+type Reference interface {
+	Node
+	// Get the reference path, without the data type casting, e.g. "address.{segment}".
+	Path() string
+	// Get the data type the referenced value is cast to, Undefined if not cast.
+	DataType() DataType
+}
+
+// Walk the evaluable tree in depth-first order, i.e. the evaluable is visited before its
+// operands.
+//
+// Example:
+//
+//	e, _ := i.Parse([]any{"AND", []any{"==", "$a", 1}, []any{">", "$b.(Number)", 2}})
+//
+//	illogical.Walk(e, func(n e.Evaluable) bool {
+//		if ref, ok := n.(illogical.Reference); ok {
+//			ref.Path()     // a, b
+//			ref.DataType() // Undefined, Number
+//		}
+//		return true
+//	})
+func Walk(eval e.Evaluable, visit Visitor) {
+	e.Walk(eval, visit)
+}
+
+// Rewrite the evaluable tree bottom-up, i.e. the operands are rewritten first, then the
+// evaluable, holding the rewritten operands, is passed to the rewrite function. Returning the
+// given evaluable, or nil, keeps it unchanged. The original tree is not modified.
+//
+// Example:
+//
+//	e, _ := i.Parse([]any{"AND", []any{"==", "$a", 1}, []any{"==", "$b", 2}})
+//	b, _ := i.Parse("$c")
+//
+//	rewritten := illogical.Rewrite(e, func(n e.Evaluable) e.Evaluable {
+//		if ref, ok := n.(illogical.Reference); ok && ref.Path() == "b" {
+//			return b
+//		}
+//		return n
+//	})
+//
+//	rewritten.String() // (({a} == 1) AND ({c} == 2))
+func Rewrite(eval e.Evaluable, rewrite func(e.Evaluable) e.Evaluable) e.Evaluable {
+	return e.Rewrite(eval, rewrite)
+}
@@ -0,0 +1,55 @@
+package goillogical
+
+import (
+	"strings"
+	"testing"
+
+	e "github.com/spaceavocado/goillogical/evaluable"
+)
+
+func TestWalk(t *testing.T) {
+	illogical := New()
+	eval, _ := illogical.Parse([]any{"AND", []any{"==", "$a", 1}, []any{"IN", "$b.(Number)", []any{1, "$c"}}})
+
+	kinds := []string{}
+	refs := []string{}
+	Walk(eval, func(n e.Evaluable) bool {
+		kinds = append(kinds, e.KindOf(n).String())
+		if ref, ok := n.(Reference); ok {
+			refs = append(refs, ref.Path()+":"+string(ref.DataType()))
+		}
+		return true
+	})
+
+	expected := "And,Eq,Reference,Value,In,Reference,Collection,Value,Reference"
+	if output := strings.Join(kinds, ","); output != expected {
+		t.Errorf("input (%v): expected %v, got %v", eval, expected, output)
+	}
+	expected = "a:Undefined,b:Number,c:Undefined"
+	if output := strings.Join(refs, ","); output != expected {
+		t.Errorf("input (%v): expected %v, got %v", eval, expected, output)
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	illogical := New()
+	eval, _ := illogical.Parse([]any{"AND", []any{"==", "$a", 1}, []any{"==", "$b", 2}})
+	c, _ := illogical.Parse("$c")
+
+	rewritten := Rewrite(eval, func(n e.Evaluable) e.Evaluable {
+		if ref, ok := n.(Reference); ok && ref.Path() == "b" {
+			return c
+		}
+		return n
+	})
+
+	if expected := "(({a} == 1) AND ({c} == 2))"; rewritten.String() != expected {
+		t.Errorf("input (%v): expected %v, got %v", eval, expected, rewritten)
+	}
+	if output, err := rewritten.Evaluate(map[string]any{"a": 1, "b": 1, "c": 2}); output != true || err != nil {
+		t.Errorf("input (%v): expected %v, got %v/%v", rewritten, true, output, err)
+	}
+	if expected := "(({a} == 1) AND ({b} == 2))"; eval.String() != expected {
+		t.Errorf("input (%v): expected unmodified %v", eval, expected)
+	}
+}